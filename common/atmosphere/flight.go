@@ -0,0 +1,43 @@
+package atmosphere
+
+import (
+	"fmt"
+	"math"
+)
+
+// FlightCondition describes flight at Mach number at geopotential pressure altitude
+// in the atmosphere with temperature deviation DeltaT from standard.
+// Free stream of FlightCondition is calorically perfect ISA air (R, K); it is an estimate only,
+// engine nodes take velocity and stagnation state from the properties of their own gas
+type FlightCondition struct {
+	Altitude float64
+	Mach     float64
+	DeltaT   float64
+}
+
+// Ambient returns static state of the atmosphere
+func (fc FlightCondition) Ambient() (State, error) {
+	if fc.Mach < 0 {
+		return State{}, fmt.Errorf("invalid mach number %f", fc.Mach)
+	}
+	return ISA(fc.Altitude, fc.DeltaT)
+}
+
+// Velocity returns free-stream velocity of calorically perfect ISA air, m / s
+func (fc FlightCondition) Velocity() (float64, error) {
+	var ambient, err = fc.Ambient()
+	if err != nil {
+		return 0, err
+	}
+	return fc.Mach * ambient.SoundSpeed, nil
+}
+
+// Stagnation returns free-stream stagnation temperature and pressure of calorically perfect ISA air
+func (fc FlightCondition) Stagnation() (float64, float64, error) {
+	var ambient, err = fc.Ambient()
+	if err != nil {
+		return 0, 0, err
+	}
+	var tau = 1 + (K-1)/2*fc.Mach*fc.Mach
+	return ambient.T * tau, ambient.P * math.Pow(tau, K/(K-1)), nil
+}
@@ -0,0 +1,146 @@
+// Package atmosphere implements International Standard Atmosphere (ISO 2533)
+// up to 84852 m of geopotential altitude
+package atmosphere
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// R is specific gas constant of ISA air, J / (kg * K)
+	R = 287.05287
+	K = 1.4
+	// G0 is standard acceleration of gravity, m / s^2
+	G0 = 9.80665
+	// EarthRadius is nominal Earth radius used in geopotential altitude conversion, m
+	EarthRadius = 6356766.
+
+	SeaLevelT   = 288.15
+	SeaLevelP   = 101325.
+	SeaLevelRho = 1.225
+
+	// MaxAltitude is upper geopotential altitude limit of the model, m
+	MaxAltitude = 84852.
+	// MinAltitude is lower geopotential altitude limit of the model, m
+	MinAltitude = -5000.
+
+	// Sutherland law constants of air
+	sutherlandBeta = 1.458e-6
+	sutherlandS    = 110.4
+)
+
+// layer is a layer of the atmosphere with constant temperature gradient
+type layer struct {
+	baseAltitude float64
+	baseT        float64
+	baseP        float64
+	// lapseRate is temperature gradient, K / m
+	lapseRate float64
+}
+
+var layers = buildLayers(
+	[]float64{0, 11000, 20000, 32000, 47000, 51000, 71000},
+	[]float64{-6.5e-3, 0, 1e-3, 2.8e-3, 0, -2.8e-3, -2e-3},
+)
+
+// State describes standard atmosphere at some altitude
+type State struct {
+	// Altitude is geopotential pressure altitude, m
+	Altitude float64
+	T        float64
+	P        float64
+	Rho      float64
+	// SoundSpeed is in m / s
+	SoundSpeed float64
+	// Mu is dynamic viscosity, Pa * s
+	Mu float64
+}
+
+// ISA returns atmosphere at geopotential pressure altitude (m) with deviation deltaT of
+// temperature from standard. Pressure does not depend on the deviation by definition of pressure altitude
+func ISA(altitude, deltaT float64) (State, error) {
+	if altitude < MinAltitude || altitude > MaxAltitude {
+		return State{}, fmt.Errorf("altitude %f is out of range [%f, %f]", altitude, MinAltitude, MaxAltitude)
+	}
+	var l = findLayer(altitude)
+	var tStd = l.baseT + l.lapseRate*(altitude-l.baseAltitude)
+	var t = tStd + deltaT
+	if t <= 0 {
+		return State{}, fmt.Errorf("temperature deviation %f gives non-positive temperature", deltaT)
+	}
+	var p = l.pressure(altitude)
+
+	return State{
+		Altitude:   altitude,
+		T:          t,
+		P:          p,
+		Rho:        p / (R * t),
+		SoundSpeed: math.Sqrt(K * R * t),
+		Mu:         sutherlandBeta * math.Pow(t, 1.5) / (t + sutherlandS),
+	}, nil
+}
+
+// PressureAltitude returns geopotential altitude (m) at which standard pressure equals p
+func PressureAltitude(p float64) (float64, error) {
+	var pMin = findLayer(MaxAltitude).pressure(MaxAltitude)
+	var pMax = layers[0].pressure(MinAltitude)
+	if p < pMin || p > pMax {
+		return 0, fmt.Errorf("pressure %f is out of range [%f, %f]", p, pMin, pMax)
+	}
+
+	var l = layers[0]
+	for _, candidate := range layers {
+		if candidate.baseP >= p {
+			l = candidate
+		}
+	}
+	if l.lapseRate == 0 {
+		return l.baseAltitude - R*l.baseT/G0*math.Log(p/l.baseP), nil
+	}
+	var tRatio = math.Pow(p/l.baseP, -l.lapseRate*R/G0)
+	return l.baseAltitude + l.baseT*(tRatio-1)/l.lapseRate, nil
+}
+
+// GeopotentialAltitude converts geometric altitude to geopotential one
+func GeopotentialAltitude(geometricAltitude float64) float64 {
+	return EarthRadius * geometricAltitude / (EarthRadius + geometricAltitude)
+}
+
+// GeometricAltitude converts geopotential altitude to geometric one
+func GeometricAltitude(geopotentialAltitude float64) float64 {
+	return EarthRadius * geopotentialAltitude / (EarthRadius - geopotentialAltitude)
+}
+
+func (l layer) pressure(altitude float64) float64 {
+	var dh = altitude - l.baseAltitude
+	if l.lapseRate == 0 {
+		return l.baseP * math.Exp(-G0*dh/(R*l.baseT))
+	}
+	return l.baseP * math.Pow(1+l.lapseRate*dh/l.baseT, -G0/(l.lapseRate*R))
+}
+
+func findLayer(altitude float64) layer {
+	var result = layers[0]
+	for _, l := range layers {
+		if altitude >= l.baseAltitude {
+			result = l
+		}
+	}
+	return result
+}
+
+func buildLayers(baseAltitudes, lapseRates []float64) []layer {
+	var result = make([]layer, len(baseAltitudes))
+	result[0] = layer{baseAltitude: baseAltitudes[0], baseT: SeaLevelT, baseP: SeaLevelP, lapseRate: lapseRates[0]}
+	for i := 1; i != len(result); i++ {
+		var prev = result[i-1]
+		result[i] = layer{
+			baseAltitude: baseAltitudes[i],
+			baseT:        prev.baseT + prev.lapseRate*(baseAltitudes[i]-prev.baseAltitude),
+			baseP:        prev.pressure(baseAltitudes[i]),
+			lapseRate:    lapseRates[i],
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package atmosphere
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reference values of ISO 2533 tables
+func TestISA(t *testing.T) {
+	var testCases = []struct {
+		altitude float64
+		t        float64
+		p        float64
+		rho      float64
+	}{
+		{0, 288.15, 101325, 1.2250},
+		{-1000, 294.65, 113929, 1.3470},
+		{5000, 255.65, 54019.9, 0.73612},
+		{11000, 216.65, 22632, 0.36392},
+		{20000, 216.65, 5474.9, 0.088035},
+		{32000, 228.65, 868.02, 0.013225},
+		{47000, 270.65, 110.91, 1.4275e-3},
+		{51000, 270.65, 66.939, 8.6160e-4},
+		{71000, 214.65, 3.9564, 6.4211e-5},
+	}
+
+	for _, tc := range testCases {
+		var state, err = ISA(tc.altitude, 0)
+		assert.Nil(t, err)
+		assert.InDelta(t, tc.t, state.T, 1e-6, "%f", tc.altitude)
+		assert.InDelta(t, 1, state.P/tc.p, 1e-4, "%f", tc.altitude)
+		assert.InDelta(t, 1, state.Rho/tc.rho, 1e-4, "%f", tc.altitude)
+	}
+
+	var sl, _ = ISA(0, 0)
+	assert.InDelta(t, 340.294, sl.SoundSpeed, 1e-3)
+	assert.InDelta(t, 1.7894e-5, sl.Mu, 1e-9)
+
+	_, err := ISA(90000, 0)
+	assert.NotNil(t, err)
+	_, err = ISA(0, -300)
+	assert.NotNil(t, err)
+}
+
+func TestISA_Deviation(t *testing.T) {
+	var std, _ = ISA(3000, 0)
+	var hot, err = ISA(3000, 15)
+	assert.Nil(t, err)
+	assert.InDelta(t, std.T+15, hot.T, 1e-9)
+	assert.InDelta(t, std.P, hot.P, 1e-9)
+	assert.True(t, hot.Rho < std.Rho)
+}
+
+func TestPressureAltitude(t *testing.T) {
+	for _, altitude := range []float64{-2000, 0, 5000, 11000, 15000, 25000, 40000, 50000, 60000, 80000} {
+		var state, _ = ISA(altitude, 0)
+		var result, err = PressureAltitude(state.P)
+		assert.Nil(t, err)
+		assert.InDelta(t, altitude, result, 1e-6)
+	}
+	var _, err = PressureAltitude(2e5)
+	assert.NotNil(t, err)
+}
+
+func TestGeopotentialAltitude(t *testing.T) {
+	assert.InDelta(t, 10000, GeometricAltitude(GeopotentialAltitude(10000)), 1e-6)
+	assert.True(t, GeopotentialAltitude(10000) < 10000)
+}
+
+func TestFlightCondition(t *testing.T) {
+	var fc = FlightCondition{Altitude: 11000, Mach: 0.8}
+	var tStag, pStag, err = fc.Stagnation()
+	assert.Nil(t, err)
+	assert.InDelta(t, 216.65*1.128, tStag, 1e-6)
+	assert.InDelta(t, 22632*1.5243, pStag, 5)
+
+	velocity, err := fc.Velocity()
+	assert.Nil(t, err)
+	assert.InDelta(t, 236.1, velocity, 0.1)
+
+	fc.Mach = -1
+	_, err = fc.Ambient()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+package gdf
+
+import (
+	"fmt"
+	"math"
+)
+
+// FlowRatios describes flow state relative to the critical (sonic) state of the same flow
+type FlowRatios struct {
+	T     float64
+	P     float64
+	Rho   float64
+	V     float64
+	TStag float64
+	PStag float64
+}
+
+// FannoParameter returns 4 * f * L* / D, where L* is the length of adiabatic duct
+// with friction factor f and diameter D required to reach sonic state from the Mach number mach
+func FannoParameter(mach float64, k float64) float64 {
+	var m2 = mach * mach
+	return (1-m2)/(k*m2) + (k+1)/(2*k)*math.Log((k+1)*m2/(2+(k-1)*m2))
+}
+
+// FannoRatios returns ratios of adiabatic flow with friction at Mach number mach
+func FannoRatios(mach float64, k float64) FlowRatios {
+	var m2 = mach * mach
+	var t = (k + 1) / (2 + (k-1)*m2)
+	var v = mach * math.Sqrt(t)
+	return FlowRatios{
+		T:     t,
+		P:     math.Sqrt(t) / mach,
+		Rho:   1 / v,
+		V:     v,
+		TStag: 1,
+		PStag: math.Pow(1/t, (k+1)/(2*(k-1))) / mach,
+	}
+}
+
+// MachFromFanno returns Mach number at which Fanno parameter equals parameter
+func MachFromFanno(parameter float64, k float64, branch Branch) (float64, error) {
+	if parameter < 0 {
+		return 0, fmt.Errorf("invalid Fanno parameter %f", parameter)
+	}
+	var f = func(mach float64) (float64, error) {
+		return FannoParameter(mach, k) - parameter, nil
+	}
+	if branch == Subsonic {
+		return solve(f, machMin, 1)
+	}
+	if limit := FannoParameter(machMax, k); parameter >= limit {
+		return 0, fmt.Errorf("Fanno parameter %f exceeds supersonic limit %f", parameter, limit)
+	}
+	return solve(f, 1, machMax)
+}
+
+// RayleighRatios returns ratios of frictionless flow with heat addition at Mach number mach
+func RayleighRatios(mach float64, k float64) FlowRatios {
+	var m2 = mach * mach
+	var p = (1 + k) / (1 + k*m2)
+	var v = (k + 1) * m2 / (1 + k*m2)
+	var stagFactor = (2 + (k-1)*m2) / (k + 1)
+	return FlowRatios{
+		T:     m2 * p * p,
+		P:     p,
+		Rho:   1 / v,
+		V:     v,
+		TStag: m2 * p * p * stagFactor,
+		PStag: p * math.Pow(stagFactor, k/(k-1)),
+	}
+}
+
+// MachFromRayleigh returns Mach number at which stagnation temperature
+// of Rayleigh flow related to its critical value equals tStagRatio
+func MachFromRayleigh(tStagRatio float64, k float64, branch Branch) (float64, error) {
+	if tStagRatio < 0 || tStagRatio > 1 {
+		return 0, fmt.Errorf("invalid stagnation temperature ratio %f", tStagRatio)
+	}
+	var f = func(mach float64) (float64, error) {
+		return RayleighRatios(mach, k).TStag - tStagRatio, nil
+	}
+	if branch == Subsonic {
+		return solve(f, 0, 1)
+	}
+	if limit := RayleighRatios(machMax, k).TStag; tStagRatio <= limit {
+		return 0, fmt.Errorf("stagnation temperature ratio %f is below supersonic limit %f", tStagRatio, limit)
+	}
+	return solve(f, 1, machMax)
+}
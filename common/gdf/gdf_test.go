@@ -0,0 +1,178 @@
+package gdf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const k = 1.4
+
+func TestInverses(t *testing.T) {
+	for _, lambda := range []float64{0.1, 0.5, 0.9, 1, 1.3, 2} {
+		var branch = Subsonic
+		if lambda > 1 {
+			branch = Supersonic
+		}
+		var result, err = LambdaFromQ(QNorm(lambda, k), k, branch)
+		assert.Nil(t, err)
+		assert.InDelta(t, lambda, result, 1e-8)
+
+		result, err = LambdaFromPi(Pi(lambda, k), k)
+		assert.Nil(t, err)
+		assert.InDelta(t, lambda, result, 1e-10)
+
+		result, err = LambdaFromTau(Tau(lambda, k), k)
+		assert.Nil(t, err)
+		assert.InDelta(t, lambda, result, 1e-10)
+
+		result, err = LambdaFromEpsilon(Epsilon(lambda, k), k)
+		assert.Nil(t, err)
+		assert.InDelta(t, lambda, result, 1e-10)
+	}
+
+	assert.InDelta(t, 1, QNorm(1, k), 1e-12)
+	assert.InDelta(t, Q(0.7, k, 287)/Q(1, k, 287), QNorm(0.7, k), 1e-12)
+
+	var _, err = LambdaFromQ(1.1, k, Subsonic)
+	assert.NotNil(t, err)
+
+	for kk := 1.2; kk < 1.4; kk += 1e-3 {
+		assert.InDelta(t, 0, QNorm(LambdaMax(kk), kk), 1e-12, "%f", kk)
+	}
+	// expansion to vacuum
+	lambdaMax, err := LambdaFromQ(0, k, Supersonic)
+	assert.Nil(t, err)
+	assert.InDelta(t, LambdaMax(k), lambdaMax, 1e-6)
+
+	_, err = LambdaFromPi(-0.1, k)
+	assert.NotNil(t, err)
+}
+
+func TestNormalShock(t *testing.T) {
+	var shock, err = NormalShock(2, k)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.57735, shock.Mach2, 1e-5)
+	assert.InDelta(t, 4.5, shock.PRatio, 1e-10)
+	assert.InDelta(t, 2.66667, shock.RhoRatio, 1e-5)
+	assert.InDelta(t, 1.6875, shock.TRatio, 1e-10)
+	assert.InDelta(t, 0.72087, shock.PStagRatio, 1e-5)
+
+	// lambda2 = 1 / lambda1
+	assert.InDelta(t, 1/Lambda(2, k), Lambda(shock.Mach2, k), 1e-10)
+
+	shock, err = NormalShock(1, k)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1, shock.PStagRatio, 1e-12)
+
+	_, err = NormalShock(0.9, k)
+	assert.NotNil(t, err)
+}
+
+func TestObliqueShock(t *testing.T) {
+	var deflection = common.ToRadians(10)
+	var weak, err = ObliqueShock(2, deflection, k, WeakShock)
+	assert.Nil(t, err)
+	assert.InDelta(t, 39.31, common.ToDegrees(weak.Angle), 0.01)
+	assert.InDelta(t, 1.6405, weak.Mach2, 1e-3)
+	assert.InDelta(t, 1.7066, weak.PRatio, 1e-3)
+	assert.InDelta(t, deflection, weak.Deflection, 1e-10)
+
+	strong, err := ObliqueShock(2, deflection, k, StrongShock)
+	assert.Nil(t, err)
+	assert.True(t, strong.Angle > weak.Angle)
+	assert.True(t, strong.Mach2 < 1, "%f", strong.Mach2)
+	assert.InDelta(t, deflection, strong.Deflection, 1e-10)
+
+	deflectionMax, err := MaxDeflection(2, k)
+	assert.Nil(t, err)
+	assert.InDelta(t, 22.97, common.ToDegrees(deflectionMax), 0.01)
+
+	_, err = ObliqueShock(2, deflectionMax+0.01, k, WeakShock)
+	assert.NotNil(t, err)
+	_, err = ObliqueShock(0.8, deflection, k, WeakShock)
+	assert.NotNil(t, err)
+}
+
+func TestPrandtlMeyer(t *testing.T) {
+	var nu, err = PrandtlMeyer(2, k)
+	assert.Nil(t, err)
+	assert.InDelta(t, 26.38, common.ToDegrees(nu), 0.01)
+
+	mach, err := MachFromPrandtlMeyer(nu, k)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2, mach, 1e-8)
+
+	_, err = MachFromPrandtlMeyer(math.Pi, k)
+	assert.NotNil(t, err)
+	_, err = PrandtlMeyer(0.5, k)
+	assert.NotNil(t, err)
+}
+
+func TestFanno(t *testing.T) {
+	assert.InDelta(t, 0.30500, FannoParameter(2, k), 1e-5)
+	assert.InDelta(t, 1.0691, FannoParameter(0.5, k), 1e-4)
+	assert.InDelta(t, 1.6875, FannoRatios(2, k).PStag, 1e-4)
+	assert.InDelta(t, 1, FannoRatios(1, k).P, 1e-12)
+
+	for _, item := range []struct {
+		mach   float64
+		branch Branch
+	}{{0.5, Subsonic}, {2, Supersonic}} {
+		var mach, err = MachFromFanno(FannoParameter(item.mach, k), k, item.branch)
+		assert.Nil(t, err)
+		assert.InDelta(t, item.mach, mach, 1e-8)
+	}
+
+	var _, err = MachFromFanno(1, k, Supersonic)
+	assert.NotNil(t, err)
+}
+
+func TestRayleigh(t *testing.T) {
+	var ratios = RayleighRatios(2, k)
+	assert.InDelta(t, 0.79339, ratios.TStag, 1e-5)
+	assert.InDelta(t, 0.36364, ratios.P, 1e-5)
+	assert.InDelta(t, 1.50310, ratios.PStag, 1e-5)
+	assert.InDelta(t, 1, RayleighRatios(1, k).TStag, 1e-12)
+
+	for _, item := range []struct {
+		mach   float64
+		branch Branch
+	}{{0.5, Subsonic}, {2, Supersonic}} {
+		var mach, err = MachFromRayleigh(RayleighRatios(item.mach, k).TStag, k, item.branch)
+		assert.Nil(t, err)
+		assert.InDelta(t, item.mach, mach, 1e-8)
+	}
+
+	var _, err = MachFromRayleigh(0.3, k, Supersonic)
+	assert.NotNil(t, err)
+}
+
+func TestNozzle(t *testing.T) {
+	var massRate, pStag, tStag, r = 10., 5e5, 1000., 287.
+
+	var throat = ThroatArea(massRate, pStag, tStag, k, r)
+	// critical mass flux
+	var rhoCrit = pStag / (r * tStag) * Epsilon(1, k)
+	assert.InDelta(t, massRate/(rhoCrit*ACrit(k, r, tStag)), throat, 1e-6*throat)
+
+	// convergent nozzle above critical pressure
+	var exit, err = ExitArea(massRate, pStag, tStag, 0.6*pStag, k, r)
+	assert.Nil(t, err)
+	assert.True(t, exit > throat)
+
+	// convergent-divergent nozzle below critical pressure
+	exit, err = ExitArea(massRate, pStag, tStag, 1e5, k, r)
+	assert.Nil(t, err)
+	lambda, err := LambdaFromAreaRatio(exit/throat, k, Supersonic)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1e5/pStag, Pi(lambda, k), 1e-8)
+	assert.True(t, PiCrit(k) > 1e5/pStag)
+
+	_, err = ExitArea(massRate, pStag, tStag, 2*pStag, k, r)
+	assert.NotNil(t, err)
+	_, err = LambdaFromAreaRatio(0.5, k, Subsonic)
+	assert.NotNil(t, err)
+}
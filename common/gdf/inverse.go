@@ -0,0 +1,95 @@
+package gdf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+)
+
+// Branch selects subsonic or supersonic solution of inverse problems
+type Branch int
+
+const (
+	Subsonic Branch = iota
+	Supersonic
+)
+
+const (
+	precision = 1e-12
+	iterLimit = 200
+	// lower bound of subsonic root search
+	machMin = 1e-8
+	// upper bound of supersonic root search
+	machMax = 1e4
+)
+
+func (b Branch) String() string {
+	if b == Supersonic {
+		return "supersonic"
+	}
+	return "subsonic"
+}
+
+// LambdaMax returns maximal velocity coefficient (flow into vacuum)
+func LambdaMax(k float64) float64 {
+	return math.Sqrt((k + 1) / (k - 1))
+}
+
+// QNorm returns mass flow function q(lambda) normalized to unity at lambda = 1
+func QNorm(lambda float64, k float64) float64 {
+	// flow expanded to vacuum passes no mass; rounding near LambdaMax must not produce NaN
+	var tau = Tau(lambda, k)
+	if tau <= 0 {
+		return 0
+	}
+	return math.Pow((k+1)/2, 1/(k-1)) * lambda * math.Pow(tau, 1/(k-1))
+}
+
+// LambdaFromQ returns velocity coefficient at which mass flow function
+// normalized to unity at critical flow is equal to q
+func LambdaFromQ(q float64, k float64, branch Branch) (float64, error) {
+	if q < 0 || q > 1 {
+		return 0, fmt.Errorf("invalid mass flow function value %f", q)
+	}
+	var f = func(lambda float64) (float64, error) {
+		return QNorm(lambda, k) - q, nil
+	}
+	if branch == Supersonic {
+		return solve(f, 1, LambdaMax(k))
+	}
+	return solve(f, 0, 1)
+}
+
+// LambdaFromPi returns velocity coefficient at which static to stagnation pressure ratio equals pi
+func LambdaFromPi(pi float64, k float64) (float64, error) {
+	if pi < 0 || pi > 1 {
+		return 0, fmt.Errorf("invalid pressure ratio %f", pi)
+	}
+	return LambdaFromTau(math.Pow(pi, (k-1)/k), k)
+}
+
+// LambdaFromTau returns velocity coefficient at which static to stagnation temperature ratio equals tau
+func LambdaFromTau(tau float64, k float64) (float64, error) {
+	if tau < 0 || tau > 1 {
+		return 0, fmt.Errorf("invalid temperature ratio %f", tau)
+	}
+	return math.Sqrt((1 - tau) * (k + 1) / (k - 1)), nil
+}
+
+// LambdaFromEpsilon returns velocity coefficient at which static to stagnation density ratio equals epsilon
+func LambdaFromEpsilon(epsilon float64, k float64) (float64, error) {
+	if epsilon < 0 || epsilon > 1 {
+		return 0, fmt.Errorf("invalid density ratio %f", epsilon)
+	}
+	return LambdaFromTau(math.Pow(epsilon, k-1), k)
+}
+
+// solve returns root of f on the interval [a, b], f(a) and f(b) must have different signs
+func solve(f root.Func, a, b float64) (float64, error) {
+	var report, err = root.NewBrentSolver(precision, iterLimit).Solve(f, a, b)
+	if err != nil {
+		return 0, err
+	}
+	return report.X, nil
+}
@@ -0,0 +1,45 @@
+package gdf
+
+import (
+	"fmt"
+	"math"
+)
+
+// ThroatArea returns area of the critical section of isentropic nozzle
+// passing massRate of gas with stagnation pressure pStag and temperature tStag
+func ThroatArea(massRate, pStag, tStag float64, k float64, R float64) float64 {
+	return FlowArea(massRate, pStag, tStag, 1, k, R)
+}
+
+// FlowArea returns section area where isentropic flow of massRate has velocity coefficient lambda
+func FlowArea(massRate, pStag, tStag, lambda float64, k float64, R float64) float64 {
+	return massRate * math.Sqrt(tStag) / (Q(lambda, k, R) * pStag)
+}
+
+// ExitArea returns exit area of isentropic nozzle fully expanding massRate of gas
+// with stagnation pressure pStag and temperature tStag to the back pressure pExit.
+// Nozzle is convergent if pExit is above critical pressure and convergent-divergent otherwise
+func ExitArea(massRate, pStag, tStag, pExit float64, k float64, R float64) (float64, error) {
+	if pExit <= 0 || pExit >= pStag {
+		return 0, fmt.Errorf("invalid back pressure %f (stagnation pressure %f)", pExit, pStag)
+	}
+	var lambda, err = LambdaFromPi(pExit/pStag, k)
+	if err != nil {
+		return 0, err
+	}
+	return FlowArea(massRate, pStag, tStag, lambda, k, R), nil
+}
+
+// LambdaFromAreaRatio returns velocity coefficient in the section of isentropic nozzle
+// which area relative to the critical section area equals areaRatio
+func LambdaFromAreaRatio(areaRatio float64, k float64, branch Branch) (float64, error) {
+	if areaRatio < 1 {
+		return 0, fmt.Errorf("area ratio %f is less than unity", areaRatio)
+	}
+	return LambdaFromQ(1/areaRatio, k, branch)
+}
+
+// PiCrit returns static to stagnation pressure ratio of the critical flow
+func PiCrit(k float64) float64 {
+	return Pi(1, k)
+}
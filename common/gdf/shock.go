@@ -0,0 +1,144 @@
+package gdf
+
+import (
+	"fmt"
+	"math"
+)
+
+// ShockBranch selects weak or strong oblique shock
+type ShockBranch int
+
+const (
+	WeakShock ShockBranch = iota
+	StrongShock
+)
+
+// Shock describes flow behind a shock wave. Ratios are taken
+// between values downstream and upstream of the shock
+type Shock struct {
+	Mach2 float64
+	// Angle is the angle between shock wave and upstream velocity, rad
+	Angle float64
+	// Deflection is the angle of flow turn in the shock, rad
+	Deflection float64
+	PRatio     float64
+	TRatio     float64
+	RhoRatio   float64
+	PStagRatio float64
+}
+
+// NormalShock returns flow behind normal shock in flow with Mach number mach1.
+// In terms of velocity coefficient lambda2 = 1 / lambda1
+func NormalShock(mach1 float64, k float64) (Shock, error) {
+	if mach1 < 1 {
+		return Shock{}, fmt.Errorf("shock is impossible in subsonic flow (mach = %f)", mach1)
+	}
+	var m2 = mach1 * mach1
+	var rhoRatio = (k + 1) * m2 / ((k-1)*m2 + 2)
+	var pRatio = 1 + 2*k/(k+1)*(m2-1)
+
+	return Shock{
+		Mach2:      math.Sqrt((1 + (k-1)/2*m2) / (k*m2 - (k-1)/2)),
+		Angle:      math.Pi / 2,
+		PRatio:     pRatio,
+		TRatio:     pRatio / rhoRatio,
+		RhoRatio:   rhoRatio,
+		PStagRatio: math.Pow(rhoRatio, k/(k-1)) * math.Pow(pRatio, -1/(k-1)),
+	}, nil
+}
+
+// ObliqueShock returns oblique shock with wave angle which deflects flow with Mach number mach1 by deflection
+func ObliqueShock(mach1, deflection float64, k float64, branch ShockBranch) (Shock, error) {
+	if mach1 <= 1 {
+		return Shock{}, fmt.Errorf("shock is impossible in subsonic flow (mach = %f)", mach1)
+	}
+	if deflection < 0 {
+		return Shock{}, fmt.Errorf("invalid deflection %f", deflection)
+	}
+
+	var angleMax = maxDeflectionShockAngle(mach1, k)
+	var deflectionMax = shockDeflection(mach1, angleMax, k)
+	if deflection > deflectionMax {
+		return Shock{}, fmt.Errorf(
+			"deflection %f exceeds maximal deflection %f of attached shock at mach = %f", deflection, deflectionMax, mach1,
+		)
+	}
+
+	var f = func(angle float64) (float64, error) {
+		return shockDeflection(mach1, angle, k) - deflection, nil
+	}
+	var angle float64
+	var err error
+	if branch == StrongShock {
+		angle, err = solve(f, angleMax, math.Pi/2)
+	} else {
+		angle, err = solve(f, math.Asin(1/mach1), angleMax)
+	}
+	if err != nil {
+		return Shock{}, err
+	}
+	return ObliqueShockFromAngle(mach1, angle, k)
+}
+
+// ObliqueShockFromAngle returns oblique shock with wave angle angle in flow with Mach number mach1
+func ObliqueShockFromAngle(mach1, angle float64, k float64) (Shock, error) {
+	var normal, err = NormalShock(mach1*math.Sin(angle), k)
+	if err != nil {
+		return Shock{}, err
+	}
+	var deflection = shockDeflection(mach1, angle, k)
+	normal.Mach2 /= math.Sin(angle - deflection)
+	normal.Angle = angle
+	normal.Deflection = deflection
+	return normal, nil
+}
+
+// MaxDeflection returns maximal flow deflection by attached oblique shock
+func MaxDeflection(mach1 float64, k float64) (float64, error) {
+	if mach1 <= 1 {
+		return 0, fmt.Errorf("shock is impossible in subsonic flow (mach = %f)", mach1)
+	}
+	return shockDeflection(mach1, maxDeflectionShockAngle(mach1, k), k), nil
+}
+
+// PrandtlMeyer returns Prandtl-Meyer function (rad) of Mach number
+func PrandtlMeyer(mach float64, k float64) (float64, error) {
+	if mach < 1 {
+		return 0, fmt.Errorf("Prandtl-Meyer function is undefined for subsonic flow (mach = %f)", mach)
+	}
+	var c = math.Sqrt((k + 1) / (k - 1))
+	var x = math.Sqrt(mach*mach - 1)
+	return c*math.Atan(x/c) - math.Atan(x), nil
+}
+
+// MachFromPrandtlMeyer returns Mach number at which Prandtl-Meyer function equals nu
+func MachFromPrandtlMeyer(nu float64, k float64) (float64, error) {
+	var nuMax = math.Pi / 2 * (LambdaMax(k) - 1)
+	if nu < 0 || nu >= nuMax {
+		return 0, fmt.Errorf("Prandtl-Meyer function %f is out of range [0, %f)", nu, nuMax)
+	}
+	var f = func(mach float64) (float64, error) {
+		var result, err = PrandtlMeyer(mach, k)
+		return result - nu, err
+	}
+	if value, _ := f(machMax); value < 0 {
+		return 0, fmt.Errorf("Prandtl-Meyer function %f corresponds to too high mach number", nu)
+	}
+	return solve(f, 1, machMax)
+}
+
+// shockDeflection returns flow deflection by oblique shock with wave angle (theta-beta-M relation)
+func shockDeflection(mach1, angle float64, k float64) float64 {
+	var m2 = mach1 * mach1
+	var sin = math.Sin(angle)
+	var num = 2 / math.Tan(angle) * (m2*sin*sin - 1)
+	var denom = m2*(k+math.Cos(2*angle)) + 2
+	return math.Atan(num / denom)
+}
+
+// maxDeflectionShockAngle returns wave angle of the shock with maximal deflection
+func maxDeflectionShockAngle(mach1 float64, k float64) float64 {
+	var m2 = mach1 * mach1
+	var root = math.Sqrt((k + 1) * ((k+1)/16*m2*m2 + (k-1)/2*m2 + 1))
+	return math.Asin(math.Sqrt(((k+1)/4*m2 - 1 + root) / (k * m2)))
+}
@@ -0,0 +1,113 @@
+package root
+
+import (
+	"fmt"
+	"math"
+)
+
+const brentMethod = "brent"
+
+// NewBrentSolver returns Brent's method solver combining bisection,
+// secant and inverse quadratic interpolation steps
+func NewBrentSolver(precision float64, iterLimit int) Solver {
+	return &brentSolver{
+		precision: precision,
+		iterLimit: iterLimit,
+	}
+}
+
+type brentSolver struct {
+	precision float64
+	iterLimit int
+}
+
+func (solver *brentSolver) Solve(f Func, a, b float64) (Report, error) {
+	var report = Report{Method: brentMethod}
+
+	var fa, errA = checkedCall(f, a)
+	if errA != nil {
+		return report, errA
+	}
+	var fb, errB = checkedCall(f, b)
+	if errB != nil {
+		return report, errB
+	}
+	report.FuncCalls = 2
+	if err := checkBracket(fa, fb, a, b); err != nil {
+		return report, err
+	}
+
+	// b is the best approximation, a is the previous one, c is the counterpoint of b
+	var c, fc = a, fa
+	var d = b - a
+	var e = d
+
+	for i := 0; i != solver.iterLimit; i++ {
+		report.IterNum = i + 1
+
+		if fb*fc > 0 {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		var tol = 0.5 * solver.precision * math.Max(math.Abs(b), 1)
+		var m = 0.5 * (c - b)
+		if fb == 0 || math.Abs(m) <= tol {
+			report.X, report.F, report.Converged = b, fb, true
+			return report, nil
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			var s = fb / fa
+			if a == c {
+				// secant step
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// inverse quadratic interpolation step
+				var qa = fa / fc
+				var r = fb / fc
+				p = s * (2*m*qa*(qa-r) - (b-a)*(r-1))
+				q = (qa - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = m
+			}
+		} else {
+			d = m
+			e = m
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+
+		var err error
+		if fb, err = checkedCall(f, b); err != nil {
+			return report, err
+		}
+		report.FuncCalls++
+	}
+
+	report.X, report.F = b, fb
+	return report, fmt.Errorf("failed to converge in %d iterations (%s)", solver.iterLimit, report)
+}
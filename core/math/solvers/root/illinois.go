@@ -0,0 +1,79 @@
+package root
+
+import "fmt"
+
+const illinoisMethod = "illinois"
+
+// NewIllinoisSolver returns regula falsi solver with Illinois modification:
+// function value at the endpoint retained twice in a row is halved,
+// which prevents one-sided convergence of the classic regula falsi
+func NewIllinoisSolver(precision float64, iterLimit int) Solver {
+	return &illinoisSolver{
+		precision: precision,
+		iterLimit: iterLimit,
+	}
+}
+
+type illinoisSolver struct {
+	precision float64
+	iterLimit int
+}
+
+func (solver *illinoisSolver) Solve(f Func, a, b float64) (Report, error) {
+	var report = Report{Method: illinoisMethod}
+
+	var fa, errA = checkedCall(f, a)
+	if errA != nil {
+		return report, errA
+	}
+	var fb, errB = checkedCall(f, b)
+	if errB != nil {
+		return report, errB
+	}
+	report.FuncCalls = 2
+	if err := checkBracket(fa, fb, a, b); err != nil {
+		return report, err
+	}
+	if fa == 0 {
+		report.X, report.F, report.Converged = a, fa, true
+		return report, nil
+	}
+
+	// side shows which endpoint was retained on previous iteration
+	var side = 0
+	var x, fx = b, fb
+	for i := 0; i != solver.iterLimit; i++ {
+		report.IterNum = i + 1
+		if fx == 0 || converged(b-a, x, solver.precision) {
+			report.X, report.F, report.Converged = x, fx, true
+			return report, nil
+		}
+
+		x = (a*fb - b*fa) / (fb - fa)
+		var err error
+		if fx, err = checkedCall(f, x); err != nil {
+			return report, err
+		}
+		report.FuncCalls++
+
+		if fx*fb > 0 {
+			b, fb = x, fx
+			if side == -1 {
+				fa /= 2
+			}
+			side = -1
+		} else if fx*fa > 0 {
+			a, fa = x, fx
+			if side == 1 {
+				fb /= 2
+			}
+			side = 1
+		} else {
+			report.X, report.F, report.Converged = x, fx, true
+			return report, nil
+		}
+	}
+
+	report.X, report.F = x, fx
+	return report, fmt.Errorf("failed to converge in %d iterations (%s)", solver.iterLimit, report)
+}
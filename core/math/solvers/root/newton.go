@@ -0,0 +1,121 @@
+package root
+
+import (
+	"fmt"
+	"math"
+)
+
+const newtonMethod = "safe_newton"
+
+// NewSafeNewtonSolver returns Newton solver safeguarded with bisection:
+// Newton step falling out of the current bracket or converging too slowly
+// is replaced with the bisection step.
+// If derivative is nil it is calculated with forward difference with relative step derivativeStep
+func NewSafeNewtonSolver(derivative Func, derivativeStep, precision float64, iterLimit int) Solver {
+	return &safeNewtonSolver{
+		derivative:     derivative,
+		derivativeStep: derivativeStep,
+		precision:      precision,
+		iterLimit:      iterLimit,
+	}
+}
+
+type safeNewtonSolver struct {
+	derivative     Func
+	derivativeStep float64
+	precision      float64
+	iterLimit      int
+}
+
+func (solver *safeNewtonSolver) Solve(f Func, a, b float64) (Report, error) {
+	var report = Report{Method: newtonMethod}
+
+	var fa, errA = checkedCall(f, a)
+	if errA != nil {
+		return report, errA
+	}
+	var fb, errB = checkedCall(f, b)
+	if errB != nil {
+		return report, errB
+	}
+	report.FuncCalls = 2
+	if err := checkBracket(fa, fb, a, b); err != nil {
+		return report, err
+	}
+	if fa == 0 {
+		report.X, report.F, report.Converged = a, fa, true
+		return report, nil
+	}
+	if fb == 0 {
+		report.X, report.F, report.Converged = b, fb, true
+		return report, nil
+	}
+
+	// orient the bracket so that f(lo) < 0 < f(hi)
+	var lo, hi = a, b
+	if fa > 0 {
+		lo, hi = b, a
+	}
+
+	var x = 0.5 * (a + b)
+	var dxOld = math.Abs(b - a)
+	var dx = dxOld
+	var fx, err = checkedCall(f, x)
+	if err != nil {
+		return report, err
+	}
+	report.FuncCalls++
+
+	for i := 0; i != solver.iterLimit; i++ {
+		report.IterNum = i + 1
+
+		var df, dfErr = solver.getDerivative(f, x, fx, &report)
+		if dfErr != nil {
+			return report, dfErr
+		}
+
+		var outOfBracket = ((x-hi)*df-fx)*((x-lo)*df-fx) > 0
+		var tooSlow = math.Abs(2*fx) > math.Abs(dxOld*df)
+		if df == 0 || outOfBracket || tooSlow {
+			dxOld = dx
+			dx = 0.5 * (hi - lo)
+			x = lo + dx
+		} else {
+			dxOld = dx
+			dx = fx / df
+			x -= dx
+		}
+
+		if fx, err = checkedCall(f, x); err != nil {
+			return report, err
+		}
+		report.FuncCalls++
+
+		if fx == 0 || converged(dx, x, solver.precision) {
+			report.X, report.F, report.Converged = x, fx, true
+			return report, nil
+		}
+		if fx < 0 {
+			lo = x
+		} else {
+			hi = x
+		}
+	}
+
+	report.X, report.F = x, fx
+	return report, fmt.Errorf("failed to converge in %d iterations (%s)", solver.iterLimit, report)
+}
+
+func (solver *safeNewtonSolver) getDerivative(f Func, x, fx float64, report *Report) (float64, error) {
+	if solver.derivative != nil {
+		return checkedCall(solver.derivative, x)
+	}
+
+	var h = solver.derivativeStep * math.Max(math.Abs(x), 1)
+	var fh, err = checkedCall(f, x+h)
+	if err != nil {
+		return 0, err
+	}
+	report.FuncCalls++
+	return (fh - fx) / h, nil
+}
@@ -0,0 +1,138 @@
+package root
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Func is a scalar function which root is searched
+type Func func(x float64) (float64, error)
+
+// Report describes the process of root search
+type Report struct {
+	Method    string
+	X         float64
+	F         float64
+	IterNum   int
+	FuncCalls int
+	Converged bool
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf(
+		"%s: x = %g, f(x) = %g, iterations = %d, function calls = %d, converged = %v",
+		r.Method, r.X, r.F, r.IterNum, r.FuncCalls, r.Converged,
+	)
+}
+
+// Solver searches root of f on the interval [a, b].
+// f(a) and f(b) must have different signs
+type Solver interface {
+	Solve(f Func, a, b float64) (Report, error)
+}
+
+// FixedPointFunc converts fixed point problem x = g(x) to root problem x - g(x) = 0
+func FixedPointFunc(g Func) Func {
+	return func(x float64) (float64, error) {
+		var gx, err = g(x)
+		if err != nil {
+			return 0, err
+		}
+		return x - gx, nil
+	}
+}
+
+// Bracket expands interval [a, b] geometrically (by growFactor) in the downhill direction
+// until it contains sign change of f. Returns bracketing interval with a < b
+func Bracket(f Func, a, b, growFactor float64, iterLimit int) (float64, float64, error) {
+	if a == b {
+		return 0, 0, errors.New("initial interval has zero length")
+	}
+	if growFactor <= 1 {
+		return 0, 0, fmt.Errorf("invalid grow factor %f", growFactor)
+	}
+	if a > b {
+		a, b = b, a
+	}
+
+	var fa, errA = checkedCall(f, a)
+	if errA != nil {
+		return 0, 0, errA
+	}
+	var fb, errB = checkedCall(f, b)
+	if errB != nil {
+		return 0, 0, errB
+	}
+
+	for i := 0; i != iterLimit; i++ {
+		if fa*fb <= 0 {
+			return a, b, nil
+		}
+
+		var err error
+		if math.Abs(fa) < math.Abs(fb) {
+			a += growFactor * (a - b)
+			fa, err = checkedCall(f, a)
+		} else {
+			b += growFactor * (b - a)
+			fb, err = checkedCall(f, b)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if fa*fb <= 0 {
+		return a, b, nil
+	}
+	return 0, 0, fmt.Errorf("failed to bracket root in %d iterations: last interval [%g, %g]", iterLimit, a, b)
+}
+
+// BracketWithin searches sign change of f on the grid of pointNum points dividing [a, b] uniformly.
+// It is useful when f is defined only within [a, b]
+func BracketWithin(f Func, a, b float64, pointNum int) (float64, float64, error) {
+	if pointNum < 2 {
+		return 0, 0, fmt.Errorf("invalid point number %d", pointNum)
+	}
+
+	var xPrev = a
+	var fPrev, err = checkedCall(f, xPrev)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i := 1; i != pointNum; i++ {
+		var x = a + (b-a)*float64(i)/float64(pointNum-1)
+		var fx, err = checkedCall(f, x)
+		if err != nil {
+			return 0, 0, err
+		}
+		if fPrev*fx <= 0 {
+			return math.Min(xPrev, x), math.Max(xPrev, x), nil
+		}
+		xPrev, fPrev = x, fx
+	}
+	return 0, 0, fmt.Errorf("no sign change of function found on [%g, %g]", a, b)
+}
+
+func checkedCall(f Func, x float64) (float64, error) {
+	var y, err = f(x)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(y) || math.IsInf(y, 0) {
+		return 0, fmt.Errorf("invalid function value %f at x = %g", y, x)
+	}
+	return y, nil
+}
+
+// converged checks step relatively for |x| > 1 and absolutely otherwise
+func converged(dx, x, precision float64) bool {
+	return math.Abs(dx) <= precision*math.Max(math.Abs(x), 1)
+}
+
+func checkBracket(fa, fb, a, b float64) error {
+	if fa*fb > 0 {
+		return fmt.Errorf("root is not bracketed: f(%g) = %g, f(%g) = %g", a, fa, b, fb)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package root
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func getSolvers() []Solver {
+	return []Solver{
+		NewBrentSolver(1e-10, 100),
+		NewIllinoisSolver(1e-10, 100),
+		NewSafeNewtonSolver(nil, 1e-7, 1e-10, 100),
+		NewSafeNewtonSolver(func(x float64) (float64, error) {
+			return 3*x*x - 2, nil
+		}, 0, 1e-10, 100),
+	}
+}
+
+func TestSolvers_Cubic(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		return x*x*x - 2*x - 5, nil
+	}
+	for _, solver := range getSolvers() {
+		var report, err = solver.Solve(f, 2, 3)
+		assert.Nil(t, err, report.String())
+		assert.True(t, report.Converged)
+		assert.InDelta(t, 2.0945514815423265, report.X, 1e-8, report.String())
+		assert.True(t, report.IterNum < 20, report.String())
+	}
+}
+
+func TestSolvers_ReversedInterval(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		return math.Cos(x) - x, nil
+	}
+	for _, solver := range getSolvers()[:3] {
+		var report, err = solver.Solve(f, 1, 0)
+		assert.Nil(t, err, report.String())
+		assert.InDelta(t, 0.7390851332151607, report.X, 1e-8, report.String())
+	}
+}
+
+func TestSolvers_NotBracketed(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		return x*x + 1, nil
+	}
+	for _, solver := range getSolvers() {
+		var _, err = solver.Solve(f, -1, 1)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSolvers_FuncError(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		if x > 0.5 {
+			return 0, errors.New("out of range")
+		}
+		return x - 0.7, nil
+	}
+	for _, solver := range getSolvers() {
+		var _, err = solver.Solve(f, 0, 1)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestFixedPointFunc_DivergingIteration(t *testing.T) {
+	// contraction factor of g is 3, so plain fixed point iteration diverges
+	var g = func(x float64) (float64, error) {
+		return 3*x - 2, nil
+	}
+	var _, iterErr = common.SolveIteratively(g, 1.5, 1e-8, 1, 100)
+	assert.NotNil(t, iterErr)
+
+	var f = FixedPointFunc(g)
+	var a, b, bracketErr = Bracket(f, 1.5, 1.6, 1.6, 50)
+	assert.Nil(t, bracketErr)
+	assert.True(t, a <= 1 && 1 <= b)
+
+	var report, err = NewBrentSolver(1e-10, 100).Solve(f, a, b)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1, report.X, 1e-9)
+}
+
+func TestBracket(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		return x - 100, nil
+	}
+	var a, b, err = Bracket(f, 0, 1, 1.6, 50)
+	assert.Nil(t, err)
+	assert.True(t, a <= 100 && 100 <= b)
+
+	_, _, err = Bracket(func(x float64) (float64, error) {
+		return x*x + 1, nil
+	}, 0, 1, 1.6, 20)
+	assert.NotNil(t, err)
+}
+
+func TestBracketWithin(t *testing.T) {
+	var f = func(x float64) (float64, error) {
+		return math.Sin(x), nil
+	}
+	var a, b, err = BracketWithin(f, 2, 4, 11)
+	assert.Nil(t, err)
+	assert.True(t, a <= math.Pi && math.Pi <= b)
+	assert.InDelta(t, 0.2, b-a, 1e-12)
+
+	_, _, err = BracketWithin(f, 0.5, 2, 11)
+	assert.NotNil(t, err)
+}
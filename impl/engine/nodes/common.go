@@ -21,6 +21,8 @@ const (
 	TemperatureOutputTag = "temperatureOutput"
 	MassRateInputTag     = "massRateInput"
 	MassRateOutputTag    = "massRateOutput"
+	SteamInputTag        = "steamInput"
+	SteamOutputTag       = "steamOutput"
 )
 
 type EnthalpyChannel interface {
@@ -41,7 +43,7 @@ func EnthalpyDiff(ch EnthalpyChannel) float64 {
 
 	tMin := math.Min(it, ot)
 
-	return mro*(ot-tMin)*gases.CpMean(oGas, tMin, ot, DefaultN) - mri*(it-tMin)*gases.CpMean(iGas, tMin, it, DefaultN)
+	return mro*(oGas.H(ot)-oGas.H(tMin)) - mri*(iGas.H(it)-iGas.H(tMin))
 }
 
 type RPMChannel interface {
@@ -89,6 +91,17 @@ type ComplexGasSink interface {
 	MassRateSink
 }
 
+// SteamSource issues water or steam; steam port carries states.SteamPortState
+type SteamSource interface {
+	SteamOutput() graph.Port
+	MassRateSource
+}
+
+type SteamSink interface {
+	SteamInput() graph.Port
+	MassRateSink
+}
+
 type MassRateChannel interface {
 	MassRateInput() graph.Port
 	MassRateOutput() graph.Port
@@ -213,6 +226,13 @@ func LinkComplexOutToIn(node1 ComplexGasSource, node2 ComplexGasSink) {
 	)
 }
 
+func LinkSteamOutToIn(source SteamSource, sink SteamSink) {
+	graph.LinkAll(
+		[]graph.Port{source.SteamOutput(), source.MassRateOutput()},
+		[]graph.Port{sink.SteamInput(), sink.MassRateInput()},
+	)
+}
+
 func LinkComplexOutToOut(node1 ComplexGasSource, node2 ComplexGasSource) {
 	graph.LinkAll(
 		[]graph.Port{node1.GasOutput(), node1.TemperatureOutput(), node1.PressureOutput(), node1.MassRateOutput()},
@@ -17,7 +17,7 @@ type GasGeneratorNode interface {
 
 func NewGasGeneratorNode(
 	compressorEtaAd, piStag float64,
-	fuel fuel.GasFuel, tgStag, tFuel, sigmaBurn, etaBurn, initAlpha, t0 float64,
+	fuel fuel.Fuel, tgStag, tFuel, sigmaBurn, etaBurn, initAlpha, t0 float64,
 	etaT, lambdaOut float64,
 	leakMassRateFunc, coolMasRateRel, inflowMassRateRel func(constructive.TurbineNode) float64,
 	etaM float64,
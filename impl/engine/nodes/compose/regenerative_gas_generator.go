@@ -22,7 +22,7 @@ type RegenerativeGasGeneratorNode interface {
 
 func NewRegenerativeGasGeneratorNode(
 	compressorEtaAd, piStag float64,
-	fuel fuel.GasFuel, tgStag, tFuel, sigmaBurn, etaBurn, initAlpha, t0 float64,
+	fuel fuel.Fuel, tgStag, tFuel, sigmaBurn, etaBurn, initAlpha, t0 float64,
 	etaT, lambdaOut float64,
 	leakMassRateFunc, coolMasRateRel, inflowMassRateRel func(constructive.TurbineNode) float64,
 	sigmaRegenerator float64,
@@ -0,0 +1,99 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/fuel"
+)
+
+// AfterburnerNode is a reheat burner behind the turbine. When it is lit (wet mode) fuel is burnt
+// with the afterburner combustion efficiency so that outlet temperature reaches the maximum one (TgStag).
+// When it is off (dry mode) gas passes it without fuel; pressure loss of dry mode is the one
+// of flameholders and the liner, wet mode loss additionally includes the loss of heat addition
+type AfterburnerNode interface {
+	TemperatureDefinedBurner
+	Lit() bool
+	SetLit(lit bool)
+	SigmaDry() float64
+	SigmaWet() float64
+}
+
+func NewAfterburnerNode(
+	fuel fuel.Fuel, tMax, tFuel, sigmaDry, sigmaWet, etaBurn, initAlpha, t0, precision, relaxCoef float64, iterLimit int,
+) AfterburnerNode {
+	var result = &afterburnerNode{
+		burnerNode: burnerNode{
+			tgStag:    tMax,
+			sigma:     sigmaWet,
+			initAlpha: initAlpha,
+			precision: precision,
+			relaxCoef: relaxCoef,
+			iterLimit: iterLimit,
+		},
+		sigmaDry: sigmaDry,
+		lit:      true,
+	}
+	result.baseBurner = newBaseBurner(result, fuel, etaBurn, tFuel, t0, precision)
+	return result
+}
+
+type afterburnerNode struct {
+	burnerNode
+	sigmaDry float64
+	lit      bool
+}
+
+func (node *afterburnerNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "Afterburner")
+}
+
+func (node *afterburnerNode) Lit() bool {
+	return node.lit
+}
+
+func (node *afterburnerNode) SetLit(lit bool) {
+	node.lit = lit
+}
+
+func (node *afterburnerNode) Sigma() float64 {
+	if node.lit {
+		return node.sigma
+	}
+	return node.sigmaDry
+}
+
+func (node *afterburnerNode) SigmaDry() float64 {
+	return node.sigmaDry
+}
+
+func (node *afterburnerNode) SigmaWet() float64 {
+	return node.sigma
+}
+
+func (node *afterburnerNode) Process() error {
+	if node.lit {
+		if node.tStagIn() >= node.tgStag {
+			return fmt.Errorf(
+				"afterburner inlet temperature %f is not less than max temperature %f", node.tStagIn(), node.tgStag,
+			)
+		}
+		return node.burnerNode.Process()
+	}
+
+	node.alpha = math.Inf(1)
+	node.fuelMassRateRel = 0
+	node.emissions = nil
+	node.emissionErrors = nil
+	graph.SetAll(
+		[]graph.PortState{
+			node.gasInput.GetState(), node.temperatureInput.GetState(),
+			states.NewPressurePortState(node.pStagIn() * node.sigmaDry), node.massRateInput.GetState(),
+		},
+		[]graph.Port{node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput},
+	)
+	return nil
+}
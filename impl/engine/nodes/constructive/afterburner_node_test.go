@@ -0,0 +1,81 @@
+package constructive
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	abTMax     = 2000
+	abTIn      = 1000
+	abPIn      = 3e5
+	abSigmaDry = 0.98
+	abSigmaWet = 0.94
+)
+
+func TestAfterburnerNode_Wet(t *testing.T) {
+	var ab = getTestAfterburner(0.95)
+	assert.Nil(t, ab.Process())
+
+	assert.InDelta(t, abTMax, ab.TStagOut(), 1e-9)
+	assert.InDelta(t, abPIn*abSigmaWet, ab.PStagOut(), 1e-9)
+	assert.InDelta(t, abSigmaWet, ab.Sigma(), 1e-12)
+
+	var fuelRateRel = ab.FuelRateRel()
+	assert.True(t, fuelRateRel > 0.01 && fuelRateRel < 0.1, "%f", fuelRateRel)
+	assert.InDelta(t, 1+fuelRateRel, ab.MassRateOutput().GetState().Value().(float64), 1e-12)
+	// vitiated gas still contains enough oxygen
+	assert.True(t, ab.Alpha() > 1, "%f", ab.Alpha())
+	assert.NotEqual(t, gases.GetAir(), ab.GasOutput().GetState().Value())
+}
+
+func TestAfterburnerNode_Efficiency(t *testing.T) {
+	var efficient = getTestAfterburner(0.95)
+	var poor = getTestAfterburner(0.85)
+	assert.Nil(t, efficient.Process())
+	assert.Nil(t, poor.Process())
+
+	assert.True(t, poor.FuelRateRel() > efficient.FuelRateRel())
+}
+
+func TestAfterburnerNode_Dry(t *testing.T) {
+	var ab = getTestAfterburner(0.95)
+	ab.SetLit(false)
+	assert.Nil(t, ab.Process())
+
+	assert.False(t, ab.Lit())
+	assert.Equal(t, 0., ab.FuelRateRel())
+	assert.InDelta(t, abTIn, ab.TStagOut(), 1e-12)
+	assert.InDelta(t, abPIn*abSigmaDry, ab.PStagOut(), 1e-9)
+	assert.InDelta(t, abSigmaDry, ab.Sigma(), 1e-12)
+	assert.InDelta(t, 1, ab.MassRateOutput().GetState().Value().(float64), 1e-12)
+	assert.Equal(t, ab.GasInput().GetState().Value(), ab.GasOutput().GetState().Value())
+}
+
+func TestAfterburnerNode_MaxTemperatureTooLow(t *testing.T) {
+	var ab = getTestAfterburner(0.95)
+	ab.SetTgStag(abTIn - 100)
+	assert.NotNil(t, ab.Process())
+}
+
+func getTestAfterburner(eta float64) AfterburnerNode {
+	var ab = NewAfterburnerNode(
+		fuel.GetCH4(), abTMax, tFuel, abSigmaDry, abSigmaWet, eta, 3, t0, 1e-9, 1, nodes.DefaultN,
+	)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(fuel.GetCH4().GetCombustionGas(gases.GetAir(), 3)),
+			states.NewTemperaturePortState(abTIn),
+			states.NewPressurePortState(abPIn),
+			states.NewMassRatePortState(1),
+		},
+		[]graph.Port{ab.GasInput(), ab.TemperatureInput(), ab.PressureInput(), ab.MassRateInput()},
+	)
+	return ab
+}
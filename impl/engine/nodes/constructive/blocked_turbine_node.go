@@ -2,7 +2,6 @@ package constructive
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
@@ -11,6 +10,8 @@ import (
 	"github.com/Sovianum/turbocycle/material/gases"
 )
 
+const realGasIterLimit = 100
+
 type BlockedTurbineNode interface {
 	StaticTurbineNode
 	nodes.PowerSink
@@ -100,7 +101,10 @@ func (node *blockedTurbineNode) Process() error {
 		return err
 	}
 
-	var piTStag = node.piTStag(tStagOut, node.etaT)
+	piTStag, err := node.piTStag(tStagOut, node.etaT)
+	if err != nil {
+		return err
+	}
 	var pStagOut = node.pStagIn() / piTStag
 
 	l := node.leakMassRateFunc(node)
@@ -132,7 +136,7 @@ func (node *blockedTurbineNode) CoolMassRateRel() float64 {
 }
 
 func (node *blockedTurbineNode) PiTStag() float64 {
-	return node.piTStag(node.tStagOut(), node.etaT)
+	return node.pStagIn() / node.pStagOut()
 }
 
 func (node *blockedTurbineNode) PowerInput() graph.Port {
@@ -144,32 +148,31 @@ func (node *blockedTurbineNode) MassRateInput() graph.Port {
 }
 
 // here it is assumed that pressure drop is calculated by stagnation parameters
-func (node *blockedTurbineNode) piTStag(tStagOut, etaT float64) float64 {
-	var k = gases.KMean(node.inputGas(), node.tStagIn(), tStagOut, nodes.DefaultN)
-	var cp = gases.CpMean(node.inputGas(), node.tStagIn(), tStagOut, nodes.DefaultN)
-
-	var labour = node.turbineLabour()
-	return math.Pow(
-		1-labour/(cp*node.tStagIn()*etaT),
-		k/(1-k),
-	)
+func (node *blockedTurbineNode) piTStag(tStagOut, etaT float64) (float64, error) {
+	return gases.ExpansionPressureRatioP(node.inputGas(), node.tStagIn(), node.pStagIn(), tStagOut, etaT)
 }
 
 func (node *blockedTurbineNode) getTStagOut() (float64, error) {
-	var t0, err = node.getNewTtStag(0.8 * node.tStagIn()) // TODO move 0.8 out of code
-	if err != nil {
-		return 0, err
+	var gas = node.inputGas()
+	var labour = node.turbineLabour()
+	var hOut = gases.HP(gas, node.tStagIn(), node.pStagIn()) - labour
+	var tGuess = node.tStagIn() - labour/gas.Cp(node.tStagIn())
+
+	var tStagOut, err = gases.TFromH(gas, hOut, tGuess)
+	if _, ok := gas.(gases.RealGas); ok && err == nil {
+		// enthalpy of real gas depends on the outlet pressure which depends on the outlet temperature
+		tStagOut, err = solveFixedPoint(func(t float64) (float64, error) {
+			var piTStag, piErr = node.piTStag(t, node.etaT)
+			if piErr != nil {
+				return 0, piErr
+			}
+			return gases.TFromHP(gas, hOut, node.pStagIn()/piTStag, t)
+		}, tStagOut, node.precision, 1, realGasIterLimit)
 	}
-	return common.SolveIteratively(node.getNewTtStag, t0, node.precision, 1, nodes.DefaultN)
-}
-
-func (node *blockedTurbineNode) getNewTtStag(currTtStag float64) (float64, error) {
-	var cp = gases.CpMean(node.inputGas(), node.tStagIn(), currTtStag, nodes.DefaultN)
-	var tTStag = node.tStagIn() - node.turbineLabour()/cp
-	if math.IsNaN(tTStag) {
-		return 0, fmt.Errorf("nan obtained while calculating TtStag")
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate TtStag: %v", err)
 	}
-	return tTStag, nil
+	return tStagOut, nil
 }
 
 func (node *blockedTurbineNode) turbineLabour() float64 {
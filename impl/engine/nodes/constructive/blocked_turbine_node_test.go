@@ -73,6 +73,25 @@ func TestBlockedTurbineNode_Process(t *testing.T) {
 	)
 }
 
+func TestBlockedTurbineNode_Process_InvalidExpansion(t *testing.T) {
+	// labour can not be obtained with such a low efficiency at any pressure ratio
+	var turbine = NewSimpleBlockedTurbineNode(0.05, lambdaOut, 0, 0, 0, 0.05)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(fuel.GetCH4().GetCombustionGas(gases.GetAir(), alphaT)),
+			states.NewPressurePortState(pBlockedT),
+			states.NewTemperaturePortState(tBlockedT),
+			states.NewMassRatePortState(1),
+			states.NewPowerPortState(-lBlockedT),
+		},
+		[]graph.Port{
+			turbine.GasInput(), turbine.PressureInput(), turbine.TemperatureInput(),
+			turbine.MassRateInput(), turbine.PowerInput(),
+		},
+	)
+	assert.NotNil(t, turbine.Process())
+}
+
 func getTestBlockedTurbine() BlockedTurbineNode {
 	return NewBlockedTurbineNode(
 		etaT, lambdaOut, 0.05,
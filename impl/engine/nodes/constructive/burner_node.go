@@ -6,7 +6,9 @@ import (
 	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/emission"
 	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/equilibrium"
 	"github.com/Sovianum/turbocycle/material/fuel"
 	"github.com/Sovianum/turbocycle/material/gases"
 )
@@ -26,11 +28,31 @@ type BurnerNode interface {
 
 	Alpha() float64
 	FuelRateRel() float64
-	Fuel() fuel.GasFuel
+	Fuel() fuel.Fuel
 	Eta() float64
 	Sigma() float64
 	T0() float64
 	TFuel() float64
+
+	// EquilibriumSolver is used to calculate combustion products taking dissociation
+	// into account (e.g. to get adiabatic flame temperature). If it is nil, combustion is complete
+	EquilibriumSolver() equilibrium.Solver
+	SetEquilibriumSolver(solver equilibrium.Solver)
+
+	// ResidenceTime is residence time of the gas in the burner (s) used by emission correlations
+	ResidenceTime() float64
+	SetResidenceTime(residenceTime float64)
+	AddEmissionCorrelation(correlation emission.Correlation)
+	EmissionCorrelations() []emission.Correlation
+	// Emission returns emission of the pollutant calculated by the last call of Process
+	Emission(pollutant string) (emission.Emission, error)
+}
+
+// TemperatureDefinedBurner is a burner with prescribed outlet temperature
+type TemperatureDefinedBurner interface {
+	BurnerNode
+	TgStag() float64
+	SetTgStag(tgStag float64)
 }
 
 // while calculating labour function takes massRateRel into account
@@ -40,7 +62,7 @@ func FuelMassRate(node BurnerNode) float64 {
 }
 
 func NewBurnerNode(
-	fuel fuel.GasFuel, tgStag, tFuel, sigma, etaBurn, initAlpha, t0, precision, relaxCoef float64, iterLimit int,
+	fuel fuel.Fuel, tgStag, tFuel, sigma, etaBurn, initAlpha, t0, precision, relaxCoef float64, iterLimit int,
 ) BurnerNode {
 	var result = &burnerNode{
 		tgStag:    tgStag,
@@ -58,14 +80,15 @@ func NewBurnerNode(
 type burnerNode struct {
 	*baseBurner
 
-	outletGas gases.Gas
-	tgStag    float64
-	sigma     float64
-	initAlpha float64
-	alpha     float64
-	precision float64
-	relaxCoef float64
-	iterLimit int
+	outletGas       gases.Gas
+	tgStag          float64
+	sigma           float64
+	initAlpha       float64
+	alpha           float64
+	fuelMassRateRel float64
+	precision       float64
+	relaxCoef       float64
+	iterLimit       int
 }
 
 func (node *burnerNode) GetName() string {
@@ -76,20 +99,44 @@ func (node *burnerNode) Sigma() float64 {
 	return node.sigma
 }
 
+func (node *burnerNode) TgStag() float64 {
+	return node.tgStag
+}
+
+func (node *burnerNode) SetTgStag(tgStag float64) {
+	node.tgStag = tgStag
+}
+
+func (node *burnerNode) ResidenceTime() float64 {
+	return node.residenceTime
+}
+
 func (node *burnerNode) Alpha() float64 {
 	return node.alpha
 }
 
 func (node *burnerNode) FuelRateRel() float64 {
-	return node.getFuelMassRateRel(node.alpha)
+	return node.fuelMassRateRel
 }
 
 func (node *burnerNode) Process() error {
-	var fuelMassRateRel, alpha, err = node.getFuelParameters(node.initAlpha)
+	var initAlpha = node.initAlpha
+	if node.equilibriumSolver != nil {
+		// alpha of complete combustion is close to the equilibrium one, so that
+		// bracket search started from it does not leave the region of positive alpha
+		var _, completeAlpha, err = node.getFuelParameters(initAlpha, nil)
+		if err != nil {
+			return err
+		}
+		initAlpha = completeAlpha
+	}
+
+	var fuelMassRateRel, alpha, err = node.getFuelParameters(initAlpha, node.equilibriumSolver)
 	if err != nil {
 		return err
 	}
 	node.alpha = alpha
+	node.fuelMassRateRel = fuelMassRateRel
 
 	gasOut := node.outletGas
 	tStagOut := node.tgStag
@@ -104,40 +151,59 @@ func (node *burnerNode) Process() error {
 		[]graph.Port{node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput},
 	)
 
+	node.updateEmissions(node.ResidenceTime(), fuelMassRateRel)
 	return nil
 }
 
-func (node *burnerNode) getFuelParameters(initAlpha float64) (float64, float64, error) {
-	alpha, err := common.SolveIterativelyWithValidation(node.getNextAlpha, func(alpha float64) error {
-		if alpha < 0 {
-			return fmt.Errorf("invalid alpha: %f", alpha)
-		}
-		return nil
+// getFuelParameters returns fuel mass rate and alpha. Combustion is complete if solver is nil
+func (node *burnerNode) getFuelParameters(initAlpha float64, solver equilibrium.Solver) (float64, float64, error) {
+	alpha, err := solveFixedPoint(func(currAlpha float64) (float64, error) {
+		return node.getNextAlpha(currAlpha, solver)
 	}, initAlpha, node.precision, node.relaxCoef, node.iterLimit)
 	if err != nil {
 		return 0, 0, err
 	}
+	if alpha < 0 {
+		return 0, 0, fmt.Errorf("invalid alpha: %f", alpha)
+	}
 
-	var fuelMassRateRel = node.getFuelMassRateRel(alpha)
+	var fuelMassRateRel, fuelErr = node.getFuelMassRateRel(alpha, solver)
+	if fuelErr != nil {
+		return 0, 0, fuelErr
+	}
 	return fuelMassRateRel, alpha, nil
 }
 
-func (node *burnerNode) getNextAlpha(currAlpha float64) (float64, error) {
+func (node *burnerNode) getNextAlpha(currAlpha float64, solver equilibrium.Solver) (float64, error) {
 	var gasMassTheory = node.fuel.GasMassTheory(node.inletGas())
-	return 1 / (node.getFuelMassRateRel(currAlpha) * gasMassTheory), nil
+	var fuelMassRateRel, err = node.getFuelMassRateRel(currAlpha, solver)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / (fuelMassRateRel * gasMassTheory), nil
 }
 
-func (node *burnerNode) getFuelMassRateRel(currAlpha float64) float64 {
-	node.outletGas = node.fuel.GetCombustionGas(node.inletGas(), currAlpha)
+func (node *burnerNode) getFuelMassRateRel(currAlpha float64, solver equilibrium.Solver) (float64, error) {
+	var gasMassTheory = node.fuel.GasMassTheory(node.inletGas())
+	var outletGas, dissociationHeat, err = node.combustionGas(
+		solver, 1/(currAlpha*gasMassTheory), currAlpha, node.tgStag, node.pStagIn()*node.sigma,
+	)
+	if err != nil {
+		return 0, err
+	}
+	node.outletGas = outletGas
+
+	// dissociation heat is the part of chemical energy remaining in the outlet gas
+	hOut := gases.CpMean(node.outletGas, node.tgStag, node.t0, nodes.DefaultN)*(node.tgStag-node.t0) + dissociationHeat
 
-	num1 := gases.CpMean(node.outletGas, node.tgStag, node.t0, nodes.DefaultN) * (node.tgStag - node.t0)
+	num1 := hOut
 	num2 := -gases.CpMean(node.inletGas(), node.tStagIn(), node.t0, nodes.DefaultN) * (node.tStagIn() - node.t0)
 
 	denom1 := node.fuel.QLower() * node.etaBurn
-	denom2 := -gases.CpMean(node.outletGas, node.tgStag, node.t0, nodes.DefaultN) * (node.tgStag - node.t0)
+	denom2 := -hOut
 	denom3 := fuel.CpMean(node.fuel, node.tFuel, node.t0, nodes.DefaultN) * (node.tFuel - node.t0)
 
-	return (num1 + num2) / (denom1 + denom2 + denom3)
+	return (num1 + num2) / (denom1 + denom2 + denom3), nil
 }
 
 func (node *burnerNode) inletGas() gases.Gas {
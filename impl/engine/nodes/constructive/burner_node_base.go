@@ -1,14 +1,18 @@
 package constructive
 
 import (
+	"fmt"
+
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/emission"
 	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/equilibrium"
 	"github.com/Sovianum/turbocycle/material/fuel"
 	"github.com/Sovianum/turbocycle/material/gases"
 )
 
-func newBaseBurner(node graph.Node, fuel fuel.GasFuel, etaBurn, tFuel, t0, precision float64) *baseBurner {
+func newBaseBurner(node graph.Node, fuel fuel.Fuel, etaBurn, tFuel, t0, precision float64) *baseBurner {
 	var result = &baseBurner{
 		fuel:      fuel,
 		etaBurn:   etaBurn,
@@ -44,11 +48,18 @@ type baseBurner struct {
 	gasOutput         graph.Port
 	massRateOutput    graph.Port
 
-	fuel      fuel.GasFuel
+	fuel      fuel.Fuel
 	tFuel     float64
 	etaBurn   float64
 	t0        float64
 	precision float64
+
+	equilibriumSolver equilibrium.Solver
+
+	residenceTime        float64
+	emissionCorrelations []emission.Correlation
+	emissions            map[string]emission.Emission
+	emissionErrors       map[string]error
 }
 
 func (node *baseBurner) PressureOutput() graph.Port {
@@ -118,7 +129,7 @@ func (node *baseBurner) GetUpdatePorts() ([]graph.Port, error) {
 	}, nil
 }
 
-func (node *baseBurner) Fuel() fuel.GasFuel {
+func (node *baseBurner) Fuel() fuel.Fuel {
 	return node.fuel
 }
 
@@ -134,10 +145,90 @@ func (node *baseBurner) TFuel() float64 {
 	return node.tFuel
 }
 
+func (node *baseBurner) EquilibriumSolver() equilibrium.Solver {
+	return node.equilibriumSolver
+}
+
+func (node *baseBurner) SetEquilibriumSolver(solver equilibrium.Solver) {
+	node.equilibriumSolver = solver
+}
+
+func (node *baseBurner) SetResidenceTime(residenceTime float64) {
+	node.residenceTime = residenceTime
+}
+
+func (node *baseBurner) AddEmissionCorrelation(correlation emission.Correlation) {
+	node.emissionCorrelations = append(node.emissionCorrelations, correlation)
+}
+
+func (node *baseBurner) EmissionCorrelations() []emission.Correlation {
+	return node.emissionCorrelations
+}
+
+func (node *baseBurner) Emission(pollutant string) (emission.Emission, error) {
+	if err, ok := node.emissionErrors[pollutant]; ok {
+		return emission.Emission{}, err
+	}
+	var result, ok = node.emissions[pollutant]
+	if !ok {
+		return emission.Emission{}, fmt.Errorf("emission of %s is not calculated", pollutant)
+	}
+	return result, nil
+}
+
+// updateEmissions evaluates emission correlations at the state of the ports.
+// Emissions are diagnostics, so errors of correlations are returned by Emission and do not fail the burner
+func (node *baseBurner) updateEmissions(residenceTime, fuelRateRel float64) {
+	var conditions = emission.Conditions{
+		PStagIn:       node.pStagIn(),
+		TStagIn:       node.tStagIn(),
+		TStagOut:      node.tStagOut(),
+		ResidenceTime: residenceTime,
+		FuelRateRel:   fuelRateRel,
+		Eta:           node.etaBurn,
+		Fuel:          node.fuel,
+		GasOut:        node.gasOutput.GetState().(states.GasPortState).Gas,
+	}
+
+	node.emissions = make(map[string]emission.Emission, len(node.emissionCorrelations))
+	node.emissionErrors = make(map[string]error)
+	for _, correlation := range node.emissionCorrelations {
+		var result, err = emission.Evaluate(correlation, conditions)
+		if err != nil {
+			node.emissionErrors[correlation.Pollutant()] = fmt.Errorf("%s emission: %v", correlation.Pollutant(), err)
+			continue
+		}
+		node.emissions[correlation.Pollutant()] = result
+	}
+}
+
 func (node *baseBurner) inletGas() gases.Gas {
 	return node.gasInput.GetState().(states.GasPortState).Gas
 }
 
+// combustionGas returns products of burning fuelMassRateRel kg of fuel in unit mass of
+// inlet gas and their dissociation heat (J / kg). If solver is nil combustion is complete
+// and products do not depend on temperature and pressure
+func (node *baseBurner) combustionGas(
+	solver equilibrium.Solver, fuelMassRateRel, alpha, t, p float64,
+) (gases.Gas, float64, error) {
+	if solver == nil {
+		return node.fuel.GetCombustionGas(node.inletGas(), alpha), 0, nil
+	}
+
+	var gas, err = equilibrium.CombustionGas(
+		solver, node.inletGas(), node.fuel, fuelMassRateRel, t, p,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	dissociationHeat, err := equilibrium.DissociationHeat(gas)
+	if err != nil {
+		return nil, 0, err
+	}
+	return gas, dissociationHeat, nil
+}
+
 func (node *baseBurner) tStagIn() float64 {
 	return node.temperatureInput.GetState().(states.TemperaturePortState).TStag
 }
@@ -38,11 +38,16 @@ func NewParametricBurnerFromProto(
 			pBurn.GasOutput(), pBurn.TemperatureOutput(), pBurn.PressureOutput(), pBurn.MassRateOutput(),
 		},
 	)
+	pBurn.SetEquilibriumSolver(b.EquilibriumSolver())
+	pBurn.SetResidenceTime(b.ResidenceTime())
+	for _, correlation := range b.EmissionCorrelations() {
+		pBurn.AddEmissionCorrelation(correlation)
+	}
 	return pBurn
 }
 
 func NewParametricBurnerNode(
-	fuel fuel.GasFuel, tFuel, t0, etaBurn,
+	fuel fuel.Fuel, tFuel, t0, etaBurn,
 	lambdaIn0, pStagIn0, tStagIn0, massRateIn0, fuelMassRateRel0,
 	precision, relaxCoef float64, iterLimit int, sigmaFunc func(lambda float64) float64,
 ) ParametricBurnerNode {
@@ -69,6 +74,7 @@ type parametricBurnerNode struct {
 	*baseBurner
 
 	fuelMassRateRel float64
+	outletGas       gases.Gas
 
 	lambdaIn0   float64
 	pStagIn0    float64
@@ -90,6 +96,14 @@ func (node *parametricBurnerNode) Sigma() float64 {
 	return node.sigmaFunc(node.lambdaIn())
 }
 
+// ResidenceTime is scaled from the design point value set by SetResidenceTime
+// as inlet density divided by mass rate
+func (node *parametricBurnerNode) ResidenceTime() float64 {
+	var massRate = node.massRateInput.GetState().(states.MassRatePortState).MassRate
+	var densityFactor = node.pStagIn() / node.pStagIn0 * node.tStagIn0 / node.tStagIn()
+	return node.residenceTime * densityFactor * node.massRateIn0 / massRate
+}
+
 func (node *parametricBurnerNode) Alpha() float64 {
 	return node.alpha()
 }
@@ -115,12 +129,13 @@ func (node *parametricBurnerNode) Process() error {
 
 	graph.SetAll(
 		[]graph.PortState{
-			states.NewGasPortState(node.outletGas()), states.NewTemperaturePortState(tStagOut),
+			states.NewGasPortState(node.outletGas), states.NewTemperaturePortState(tStagOut),
 			states.NewPressurePortState(pStagOut), states.NewMassRatePortState(massRateOut),
 		},
 		[]graph.Port{node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput},
 	)
 
+	node.updateEmissions(node.ResidenceTime(), node.fuelMassRateRel)
 	return nil
 }
 
@@ -136,14 +151,23 @@ func (node *parametricBurnerNode) lambdaIn() float64 {
 
 func (node *parametricBurnerNode) tGas() (float64, error) {
 	alphaFunc := func(alpha float64) float64 {
-		if alpha <= 1 {
+		if alpha <= 1 && node.equilibriumSolver == nil {
 			return alpha
 		}
-		return 1
+		return 1 // incomplete combustion is accounted by dissociation heat of equilibrium products
 	}
 
+	pStagOut := node.pStagIn() * node.sigmaFunc(node.lambdaIn())
+
 	iterFunc := func(tGas float64) (float64, error) {
-		cpGas := gases.CpMean(node.outletGas(), tGas, node.t0, nodes.DefaultN)
+		outletGas, dissociationHeat, err := node.combustionGas(
+			node.equilibriumSolver, node.fuelMassRateRel, node.alpha(), tGas, pStagOut,
+		)
+		if err != nil {
+			return 0, err
+		}
+		node.outletGas = outletGas
+		cpGas := gases.CpMean(outletGas, tGas, node.t0, nodes.DefaultN)
 
 		tInput := node.tStagIn()
 		cpInput := gases.CpMean(node.inletGas(), tInput, node.t0, nodes.DefaultN)
@@ -152,18 +176,24 @@ func (node *parametricBurnerNode) tGas() (float64, error) {
 
 		enom1 := cpInput * (tInput - node.t0)
 		enom2 := node.fuelMassRateRel * node.fuel.QLower() * node.etaBurn * alphaFunc(alpha)
-		enom3 := node.fuelMassRateRel * node.fuel.Cp(node.t0) * (node.tFuel - node.t0)
+		enom3 := node.fuelMassRateRel * fuel.CpMean(node.fuel, node.tFuel, node.t0, nodes.DefaultN) * (node.tFuel - node.t0)
+
+		enom4 := -dissociationHeat * (node.fuelMassRateRel + 1)
 
 		denom := cpGas * (node.fuelMassRateRel + 1)
 
-		return (enom1+enom2+enom3)/denom + node.t0, nil
+		return (enom1+enom2+enom3+enom4)/denom + node.t0, nil
 	}
 
-	return common.SolveIteratively(iterFunc, node.tStagIn(), node.precision, node.relaxCoef, node.iterLimit)
-}
-
-func (node *parametricBurnerNode) outletGas() gases.Gas {
-	return node.fuel.GetCombustionGas(node.inletGas(), node.alpha())
+	var tGas, err = solveFixedPoint(iterFunc, node.tStagIn(), node.precision, node.relaxCoef, node.iterLimit)
+	if err != nil {
+		return 0, err
+	}
+	// outlet gas must correspond to the solution, not to the last probe of the solver
+	if _, err := iterFunc(tGas); err != nil {
+		return 0, err
+	}
+	return tGas, nil
 }
 
 func (node *parametricBurnerNode) inletGas() gases.Gas {
@@ -1,13 +1,16 @@
 package constructive
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbocycle/core/math/solvers/newton"
 	"github.com/Sovianum/turbocycle/core/math/variator"
 	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/emission"
 	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/equilibrium"
 	"github.com/Sovianum/turbocycle/material/fuel"
 	"github.com/Sovianum/turbocycle/material/gases"
 	"github.com/stretchr/testify/assert"
@@ -151,6 +154,146 @@ func TestParametricBurnerNode_Consistency(t *testing.T) {
 	)
 }
 
+func TestParametricBurnerNode_Consistency_Equilibrium(t *testing.T) {
+	proto := getTestBurner().(TemperatureDefinedBurner)
+	proto.SetTgStag(2200)
+	proto.SetEquilibriumSolver(equilibrium.NewCombustionSolver())
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gases.GetAir()),
+			states.NewTemperaturePortState(tStagIn0),
+			states.NewPressurePortState(pStagIn0),
+			states.NewMassRatePortState(1),
+		},
+		[]graph.Port{
+			proto.GasInput(),
+			proto.TemperatureInput(),
+			proto.PressureInput(),
+			proto.MassRateInput(),
+		},
+	)
+	assert.Nil(t, proto.Process())
+	// complete combustion guess of alpha must not reset the equilibrium mode
+	assert.NotNil(t, proto.EquilibriumSolver())
+
+	b := NewParametricBurnerFromProto(proto, lambdaIn0, massRateIn0, 1e-5, 1, nodes.DefaultN)
+	assert.Nil(t, b.Process())
+
+	assert.InDelta(t, proto.TStagOut(), b.TStagOut(), 1e-2)
+	assert.InDelta(t, proto.FuelRateRel(), b.FuelRateRel(), 1e-6)
+
+	// the same fuel rate gives higher temperature if dissociation is neglected
+	b.SetEquilibriumSolver(nil)
+	assert.Nil(t, b.Process())
+	assert.True(t, b.TStagOut() > proto.TStagOut(), "%f", b.TStagOut())
+}
+
+func TestParametricBurnerNode_AdiabaticFlameTemperature(t *testing.T) {
+	var air = gases.GetAir()
+	var ch4 = fuel.GetCH4()
+	var tIn = gases.TRef
+	var b = NewParametricBurnerNode(
+		ch4, tIn, tIn, 1, lambdaIn0,
+		1e5, tIn, 1, 1/ch4.GasMassTheory(air), 1e-6, 1, 100,
+		func(lambda float64) float64 {
+			return 1
+		},
+	)
+	b.SetEquilibriumSolver(equilibrium.NewCombustionSolver())
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(air), states.NewTemperaturePortState(tIn),
+			states.NewPressurePortState(1e5), states.NewMassRatePortState(1),
+		},
+		[]graph.Port{b.GasInput(), b.TemperatureInput(), b.PressureInput(), b.MassRateInput()},
+	)
+	assert.Nil(t, b.Process())
+
+	// stoichiometric methane-air flame at normal conditions (NASA CEA)
+	assert.InDelta(t, 2226, b.TStagOut(), 40)
+}
+
+func TestParametricBurnerNode_Emissions(t *testing.T) {
+	proto := getTestBurner()
+	proto.SetResidenceTime(5e-3)
+	proto.AddEmissionCorrelation(emission.NewLefebvreNOx(20, pStagIn0, tStagIn0, tgStag, 5e-3))
+	proto.AddEmissionCorrelation(emission.NewEfficiencyCO(1))
+
+	var setInlet = func(b BurnerNode, tStagIn, pStagIn, massRate float64) {
+		graph.SetAll(
+			[]graph.PortState{
+				states.NewGasPortState(gases.GetAir()), states.NewTemperaturePortState(tStagIn),
+				states.NewPressurePortState(pStagIn), states.NewMassRatePortState(massRate),
+			},
+			[]graph.Port{b.GasInput(), b.TemperatureInput(), b.PressureInput(), b.MassRateInput()},
+		)
+	}
+	setInlet(proto, tStagIn0, pStagIn0, massRateIn0)
+	assert.Nil(t, proto.Process())
+
+	protoNOx, err := proto.Emission(emission.NOx)
+	assert.Nil(t, err)
+	assert.InDelta(t, 20, protoNOx.EmissionIndex, 1e-9)
+	assert.True(t, protoNOx.PPM15 > 0)
+	protoCO, err := proto.Emission(emission.CO)
+	assert.Nil(t, err)
+	assert.True(t, protoCO.EmissionIndex > 0)
+
+	_, err = proto.Emission("SO2")
+	assert.NotNil(t, err)
+
+	b := NewParametricBurnerFromProto(proto, lambdaIn0, massRateIn0, 1e-5, 1, nodes.DefaultN)
+	assert.Nil(t, b.Process())
+	nox, err := b.Emission(emission.NOx)
+	assert.Nil(t, err)
+	assert.InDelta(t, protoNOx.EmissionIndex, nox.EmissionIndex, 1e-3)
+	assert.InDelta(t, protoNOx.PPM15, nox.PPM15, 1e-2)
+
+	// residence time grows with density at the same mass rate
+	setInlet(b, tStagIn0, 2*pStagIn0, massRateIn0)
+	assert.Nil(t, b.Process())
+	assert.InDelta(t, 2*5e-3, b.ResidenceTime(), 1e-9)
+	noxHigh, _ := b.Emission(emission.NOx)
+	assert.True(t, noxHigh.EmissionIndex > nox.EmissionIndex)
+}
+
+func TestBurnerNode_EmissionError(t *testing.T) {
+	var b = getTestBurner()
+	b.AddEmissionCorrelation(failingCorrelation{})
+	b.AddEmissionCorrelation(emission.NewEfficiencyCO(1))
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gases.GetAir()), states.NewTemperaturePortState(tStagIn0),
+			states.NewPressurePortState(pStagIn0), states.NewMassRatePortState(massRateIn0),
+		},
+		[]graph.Port{b.GasInput(), b.TemperatureInput(), b.PressureInput(), b.MassRateInput()},
+	)
+
+	// failed diagnostics do not fail the thermodynamic solution
+	assert.Nil(t, b.Process())
+	assert.True(t, b.FuelRateRel() > 0)
+
+	var _, err = b.Emission(emission.NOx)
+	assert.NotNil(t, err)
+	co, err := b.Emission(emission.CO)
+	assert.Nil(t, err)
+	assert.True(t, co.EmissionIndex > 0)
+}
+
+type failingCorrelation struct{}
+
+func (failingCorrelation) Pollutant() string {
+	return emission.NOx
+}
+
+func (failingCorrelation) MolarMass() float64 {
+	return 46e-3
+}
+
+func (failingCorrelation) EmissionIndex(conditions emission.Conditions) (float64, error) {
+	return 0, errors.New("correlation is not applicable")
+}
+
 func getTestParametricBurner(sigmaFunc func(lambda float64) float64) ParametricBurnerNode {
 	return NewParametricBurnerNode(
 		fuel.GetCH4(), tFuel, t0, etaBurn, lambdaIn0,
@@ -51,7 +51,7 @@ func TestBurnerNode_Process(t *testing.T) {
 		fmt.Sprintf("Expected p_stag_out %f, got %f", expectedPOut, bn.PStagOut()),
 	)
 
-	var cpGas = gases.CpMean(bn.GasInput().GetState().(states.GasPortState).Gas, t0, tgStag, nodes.DefaultN)
+	var cpGas = gases.CpMean(bn.GasOutput().GetState().(states.GasPortState).Gas, t0, tgStag, nodes.DefaultN)
 	var cpAir = gases.CpMean(gases.GetAir(), t0, tInBurn, nodes.DefaultN)
 	var cpFuel = fuel.CpMean(fuel.GetCH4(), t0, tFuel, nodes.DefaultN)
 	var enom = cpGas*(tgStag-t0) - cpAir*(tInBurn-t0)
@@ -79,3 +79,85 @@ func getTestBurner() BurnerNode {
 		fuel.GetCH4(), tgStag, tFuel, sigmaBurn, etaBurn, 3.5, t0, 1e-5, 1, nodes.DefaultN,
 	)
 }
+
+func TestBurnerNode_Process_Relaxed(t *testing.T) {
+	var solve = func(relaxCoef float64) (BurnerNode, error) {
+		var bn = NewBurnerNode(
+			fuel.GetCH4(), tgStag, tFuel, sigmaBurn, etaBurn, 10, t0, 1e-5, relaxCoef, nodes.DefaultN,
+		)
+		graph.SetAll(
+			[]graph.PortState{
+				states.NewGasPortState(gases.GetAir()),
+				states.NewTemperaturePortState(tInBurn),
+				states.NewPressurePortState(pInBurn),
+				states.NewMassRatePortState(1),
+			},
+			[]graph.Port{bn.GasInput(), bn.TemperatureInput(), bn.PressureInput(), bn.MassRateInput()},
+		)
+		return bn, bn.Process()
+	}
+
+	// relaxation changes the path to the solution, not the solution itself
+	var plain, plainErr = solve(1)
+	var relaxed, relaxedErr = solve(0.3)
+	assert.Nil(t, plainErr)
+	assert.Nil(t, relaxedErr)
+	assert.InDelta(t, plain.Alpha(), relaxed.Alpha(), 1e-5*plain.Alpha())
+	assert.InDelta(t, plain.FuelRateRel(), relaxed.FuelRateRel(), 1e-5*plain.FuelRateRel())
+
+	// zero step can not bracket the solution
+	var _, zeroErr = solve(0)
+	assert.NotNil(t, zeroErr)
+}
+
+func TestBurnerNode_Process_LiquidFuel(t *testing.T) {
+	var bn = NewBurnerNode(
+		fuel.GetJetA(), tgStag, tFuel, sigmaBurn, etaBurn, 3.5, t0, 1e-5, 1, nodes.DefaultN,
+	)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gases.GetAir()),
+			states.NewTemperaturePortState(tInBurn),
+			states.NewPressurePortState(pInBurn),
+			states.NewMassRatePortState(1),
+		},
+		[]graph.Port{
+			bn.GasInput(), bn.TemperatureInput(), bn.PressureInput(), bn.MassRateInput(),
+		},
+	)
+	assert.Nil(t, bn.Process())
+
+	var ch4Burner = getTestBurner()
+	graph.CopyAll(
+		[]graph.Port{bn.GasInput(), bn.TemperatureInput(), bn.PressureInput(), bn.MassRateInput()},
+		[]graph.Port{ch4Burner.GasInput(), ch4Burner.TemperatureInput(), ch4Burner.PressureInput(), ch4Burner.MassRateInput()},
+	)
+	assert.Nil(t, ch4Burner.Process())
+
+	// kerosene has lower heating value than methane, so more fuel is needed
+	var ratio = bn.FuelRateRel() / ch4Burner.FuelRateRel()
+	assert.InDelta(t, fuel.GetCH4().QLower()/fuel.GetJetA().QLower(), ratio, 0.05)
+}
+
+func TestBurnerNode_Process_HydrogenBlend(t *testing.T) {
+	var blend, err = fuel.NewH2CH4BlendByVolume(0.3)
+	assert.Nil(t, err)
+
+	var bn = NewBurnerNode(
+		blend, tgStag, tFuel, sigmaBurn, etaBurn, 3.5, t0, 1e-5, 1, nodes.DefaultN,
+	)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gases.GetAir()),
+			states.NewTemperaturePortState(tInBurn),
+			states.NewPressurePortState(pInBurn),
+			states.NewMassRatePortState(1),
+		},
+		[]graph.Port{
+			bn.GasInput(), bn.TemperatureInput(), bn.PressureInput(), bn.MassRateInput(),
+		},
+	)
+	assert.Nil(t, bn.Process())
+	assert.True(t, bn.Alpha() > 1)
+	assert.InDelta(t, tgStag, bn.TStagOut(), 1e-6)
+}
@@ -2,7 +2,6 @@ package constructive
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
@@ -48,6 +47,7 @@ type compressorNode struct {
 	etaPol    float64 // politropic efficiency
 	precision float64
 	piStag    float64
+	eta       float64 // adiabatic efficiency of the last processed state
 }
 
 // while calculating labour function takes massRateRel into account
@@ -77,7 +77,7 @@ func (node *compressorNode) PiStag() float64 {
 }
 
 func (node *compressorNode) Eta() float64 {
-	return node.etaAd(node.tStagOut())
+	return node.eta
 }
 
 func (node *compressorNode) EtaPol() float64 {
@@ -93,21 +93,14 @@ func (node *compressorNode) Process() error {
 		return fmt.Errorf("invalid piStag = %f", node.piStag)
 	}
 
-	var tStagOut float64
-	var err error
-	iterFunc := func(etaAdCurr float64) (float64, error) {
-		tStagOut, err = node.getTStagOut(node.tStagIn(), node.piStag, etaAdCurr)
-		if err != nil {
-			return 0, err
-		}
-		return node.etaAd(tStagOut), nil
-	}
-
-	_, etaErr := common.SolveIteratively(iterFunc, node.etaAd(node.tStagIn()), node.precision/100, 1, 1000)
-	if etaErr != nil {
-		return etaErr
+	var tStagOut, err = gases.PolytropicCompressionTP(node.gas(), node.tStagIn(), node.pStagIn(), node.pStagIn()*node.piStag, node.etaPol)
+	if err != nil {
+		return err
 	}
 	var pStagOut = node.pStagIn() * node.piStag
+	if node.eta, err = node.etaAd(tStagOut); err != nil {
+		return err
+	}
 
 	graph.SetAll(
 		[]graph.PortState{
@@ -128,11 +121,6 @@ func (node *compressorNode) MassRateInput() graph.Port {
 	return node.massRateInput
 }
 
-func (node *compressorNode) etaAd(tStagOut float64) float64 {
-	var k = gases.KMean(node.gas(), node.tStagIn(), tStagOut, nodes.DefaultN)
-
-	var enom = math.Pow(node.piStag, (k-1)/k) - 1
-	var denom = math.Pow(node.piStag, (k-1)/(k*node.etaPol)) - 1
-
-	return enom / denom
+func (node *compressorNode) etaAd(tStagOut float64) (float64, error) {
+	return gases.AdiabaticEfficiencyP(node.gas(), node.tStagIn(), node.pStagIn(), tStagOut, node.pStagIn()*node.piStag)
 }
@@ -1,9 +1,6 @@
 package constructive
 
 import (
-	"math"
-
-	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbocycle/impl/engine/nodes"
 	"github.com/Sovianum/turbocycle/impl/engine/states"
@@ -125,32 +122,12 @@ func (node *baseCompressor) LSpecific() float64 {
 }
 
 func (node *baseCompressor) lSpecific() float64 {
-	var cpMean = gases.CpMean(node.gas(), node.tStagIn(), node.tStagOut(), nodes.DefaultN)
-	return cpMean * (node.tStagOut() - node.tStagIn())
-}
-
-func (node *baseCompressor) getTStagOut(tStagOutInit, piStag, etaAd float64) (float64, error) {
-	var k = gases.K(node.gas(), node.tStagIn())
-	iterFunc := func(t float64) (float64, error) {
-		//k = gases.KMean(node.gas(), node.tStagIn(), t, turbine.DefaultN)
-		x := math.Pow(piStag, (k-1)/k)
-		return node.tStagIn() * (1 + (x-1)/etaAd), nil
-	}
-	k0 := 1.4
-	x0 := math.Pow(piStag, (k0-1)/k0)
-
-	t, e := common.SolveIteratively(iterFunc, node.tStagIn()*(1+(x0-1)/etaAd), node.precision, 1, 1000)
-	return t, e
-}
-
-func (node *baseCompressor) tStagOutNewFunc(tStagOutCurr, piStag, etaAd float64) float64 {
-	var x = node.xFunc(tStagOutCurr, piStag)
-	return node.tStagIn() * (1 + (x-1)/etaAd)
+	var gas = node.gas()
+	return gases.HP(gas, node.tStagOut(), node.pStagOut()) - gases.HP(gas, node.tStagIn(), node.pStagIn())
 }
 
-func (node *baseCompressor) xFunc(tStagOut, piStag float64) float64 {
-	var k = gases.KMean(node.gas(), node.tStagIn(), tStagOut, nodes.DefaultN)
-	return math.Pow(piStag, (k-1)/k)
+func (node *baseCompressor) getTStagOut(tStagIn, piStag, etaAd float64) (float64, error) {
+	return gases.CompressionTP(node.gas(), tStagIn, node.pStagIn(), node.pStagIn()*piStag, etaAd)
 }
 
 func (node *baseCompressor) tStagIn() float64 {
@@ -0,0 +1,171 @@
+package emission
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/equilibrium"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+const (
+	NOx = "NOx"
+	CO  = "CO"
+
+	// NOx emission index is conventionally given as NO2 mass
+	NOxMolarMass = 46.0055e-3
+	COMolarMass  = 28.0101e-3
+
+	// reference oxygen content of dry exhaust the concentrations are corrected to
+	ReferenceO2 = 0.15
+	airO2       = 0.2095
+)
+
+// Conditions describe operating point of a burner
+type Conditions struct {
+	PStagIn float64
+	TStagIn float64
+	// TStagOut is outlet temperature of the burner (T4)
+	TStagOut float64
+	// ResidenceTime is residence time of the gas in the burner, s
+	ResidenceTime float64
+	// FuelRateRel is fuel mass rate per unit mass rate of the inlet gas
+	FuelRateRel float64
+	Eta         float64
+	Fuel        fuel.Fuel
+	GasOut      gases.Gas
+}
+
+// Correlation estimates emission of one pollutant
+type Correlation interface {
+	Pollutant() string
+	// MolarMass of the pollutant is used to convert emission index to concentration, kg / mol
+	MolarMass() float64
+	// EmissionIndex is in g of pollutant per kg of fuel
+	EmissionIndex(conditions Conditions) (float64, error)
+}
+
+// Emission describes emission of a pollutant
+type Emission struct {
+	// EmissionIndex is in g of pollutant per kg of fuel
+	EmissionIndex float64
+	// PPM15 is volume concentration in dry exhaust corrected to 15 % of oxygen, ppm
+	PPM15 float64
+}
+
+func NewCorrelation(
+	pollutant string, molarMass float64, emissionIndexFunc func(conditions Conditions) (float64, error),
+) Correlation {
+	return &correlation{
+		pollutant:         pollutant,
+		molarMass:         molarMass,
+		emissionIndexFunc: emissionIndexFunc,
+	}
+}
+
+// Evaluate calculates emission index of the correlation and converts it to concentration
+func Evaluate(correlation Correlation, conditions Conditions) (Emission, error) {
+	var ei, err = correlation.EmissionIndex(conditions)
+	if err != nil {
+		return Emission{}, err
+	}
+	ppm, err := PPM15(ei, correlation.MolarMass(), conditions.FuelRateRel, conditions.GasOut)
+	if err != nil {
+		return Emission{}, err
+	}
+	return Emission{EmissionIndex: ei, PPM15: ppm}, nil
+}
+
+// PPM15 converts emission index ei (g / kg of fuel) of pollutant with molar mass (kg / mol)
+// to volume concentration (ppm) in dry exhaust gas corrected to 15 % of oxygen.
+// Exhaust gas is produced by burning fuelRateRel kg of fuel in unit mass of inlet gas
+func PPM15(ei, molarMass, fuelRateRel float64, gas gases.Gas) (float64, error) {
+	var elements, err = equilibrium.Elements(gas)
+	if err != nil {
+		return 0, err
+	}
+
+	// moles per unit mass of exhaust; hydrogen is supposed to be bound in water
+	var total = 1 / gases.MolarMass(gas)
+	var dry = total - elements["H"]/2
+	var o2 = gas.OxygenMassFraction() / species.MustGet(species.OxygenName).MolarMass()
+	var pollutant = ei * 1e-3 * fuelRateRel / (1 + fuelRateRel) / molarMass
+	if dry <= 0 {
+		return 0, fmt.Errorf("gas %s does not contain dry components", gas)
+	}
+
+	var o2Dry = o2 / dry
+	if o2Dry >= airO2 {
+		return 0, fmt.Errorf("oxygen content %f of dry exhaust is not less than in air", o2Dry)
+	}
+	return pollutant / dry * 1e6 * (airO2 - ReferenceO2) / (airO2 - o2Dry), nil
+}
+
+// NewLefebvreNOx returns Lefebvre-type NOx correlation
+//
+//	EI ~ p3^0.25 * tau * T3 * exp(0.01 * T4) / T4,
+//
+// which follows from the correlation of Lefebvre for conventional combustors
+// (EI ~ p3^1.25 * V * exp(0.01 * T) / (m * T)) if combustor volume V and air mass rate m
+// are expressed via residence time tau at inlet density. The correlation is calibrated by
+// emission index eiRef at reference point pRef, tInRef, tOutRef, residenceTimeRef
+func NewLefebvreNOx(eiRef, pRef, tInRef, tOutRef, residenceTimeRef float64) Correlation {
+	var shape = func(c Conditions) float64 {
+		return math.Pow(c.PStagIn, 0.25) * c.ResidenceTime * c.TStagIn * math.Exp(0.01*c.TStagOut) / c.TStagOut
+	}
+	var factor = eiRef / shape(Conditions{
+		PStagIn: pRef, TStagIn: tInRef, TStagOut: tOutRef, ResidenceTime: residenceTimeRef,
+	})
+
+	return NewCorrelation(NOx, NOxMolarMass, func(c Conditions) (float64, error) {
+		if c.ResidenceTime <= 0 {
+			return 0, fmt.Errorf("invalid residence time %f", c.ResidenceTime)
+		}
+		return factor * shape(c), nil
+	})
+}
+
+// NewEfficiencyCO returns CO correlation which attributes coShare of the chemical
+// energy lost due to combustion inefficiency to CO (the rest is attributed to unburnt hydrocarbons)
+func NewEfficiencyCO(coShare float64) Correlation {
+	var co = species.MustGet(species.CarbonMonoxideName)
+	var co2 = species.MustGet(species.CarbonDioxideName)
+	// heat released by oxidation of CO to CO2, J / kg of CO
+	var qCO = (co.HFormation()*co.MolarMass() - co2.HFormation()*co2.MolarMass()) / co.MolarMass()
+
+	return NewCorrelation(CO, COMolarMass, func(c Conditions) (float64, error) {
+		return coShare * (1 - c.Eta) * c.Fuel.QLower() / qCO * 1e3, nil
+	})
+}
+
+// NewEquilibriumCO returns CO correlation which takes CO content of the outlet gas.
+// It is meaningful only if the burner calculates equilibrium products
+func NewEquilibriumCO() Correlation {
+	return NewCorrelation(CO, COMolarMass, func(c Conditions) (float64, error) {
+		var gas = gases.Flatten(c.GasOut)
+		if c.FuelRateRel <= 0 {
+			return 0, nil
+		}
+		return gas.MassFraction(species.CarbonMonoxideName) * (1 + c.FuelRateRel) / c.FuelRateRel * 1e3, nil
+	})
+}
+
+type correlation struct {
+	pollutant         string
+	molarMass         float64
+	emissionIndexFunc func(conditions Conditions) (float64, error)
+}
+
+func (c *correlation) Pollutant() string {
+	return c.pollutant
+}
+
+func (c *correlation) MolarMass() float64 {
+	return c.molarMass
+}
+
+func (c *correlation) EmissionIndex(conditions Conditions) (float64, error) {
+	return c.emissionIndexFunc(conditions)
+}
@@ -0,0 +1,72 @@
+package emission
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/material/equilibrium"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPPM15_DilutionInvariance(t *testing.T) {
+	var air = gases.GetAir()
+	var ch4 = fuel.GetCH4()
+	var ei = 10.
+
+	var ppm = func(alpha float64) float64 {
+		var fuelRateRel = 1 / (alpha * ch4.GasMassTheory(air))
+		var result, err = PPM15(ei, NOxMolarMass, fuelRateRel, ch4.GetCombustionGas(air, alpha))
+		assert.Nil(t, err)
+		return result
+	}
+
+	// correction to reference oxygen content excludes dilution by excess air
+	var ppm2, ppm4 = ppm(2), ppm(4)
+	assert.InDelta(t, ppm2, ppm4, 1e-2*ppm2)
+	assert.True(t, ppm2 > 0)
+
+	var _, err = PPM15(ei, NOxMolarMass, 0, air)
+	assert.NotNil(t, err)
+}
+
+func TestLefebvreNOx(t *testing.T) {
+	var correlation = NewLefebvreNOx(20, 2e6, 700, 1600, 5e-3)
+	var conditions = Conditions{PStagIn: 2e6, TStagIn: 700, TStagOut: 1600, ResidenceTime: 5e-3}
+
+	var ei, err = correlation.EmissionIndex(conditions)
+	assert.Nil(t, err)
+	assert.InDelta(t, 20, ei, 1e-9)
+	assert.Equal(t, NOx, correlation.Pollutant())
+
+	var hot = conditions
+	hot.TStagOut += 100
+	eiHot, _ := correlation.EmissionIndex(hot)
+	assert.InDelta(t, 20*2.71828*1600/1700, eiHot, 1e-3)
+
+	var long = conditions
+	long.ResidenceTime *= 2
+	eiLong, _ := correlation.EmissionIndex(long)
+	assert.InDelta(t, 40, eiLong, 1e-9)
+
+	conditions.ResidenceTime = 0
+	_, err = correlation.EmissionIndex(conditions)
+	assert.NotNil(t, err)
+}
+
+func TestCO(t *testing.T) {
+	var air = gases.GetAir()
+	var ch4 = fuel.GetCH4()
+
+	var ei, err = NewEfficiencyCO(1).EmissionIndex(Conditions{Eta: 0.999, Fuel: ch4})
+	assert.Nil(t, err)
+	// heating value of CO is about 10.1 MJ / kg
+	assert.InDelta(t, 1e-3*ch4.QLower()/10.1e6*1e3, ei, 0.05)
+
+	var fuelRateRel = 1 / ch4.GasMassTheory(air)
+	gas, err := equilibrium.CombustionGas(equilibrium.NewCombustionSolver(), air, ch4, fuelRateRel, 2200, 1e5)
+	assert.Nil(t, err)
+	eiEquilibrium, err := NewEquilibriumCO().EmissionIndex(Conditions{FuelRateRel: fuelRateRel, GasOut: gas})
+	assert.Nil(t, err)
+	assert.True(t, eiEquilibrium > 10, "%f", eiEquilibrium)
+}
@@ -0,0 +1,46 @@
+package constructive
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+)
+
+const (
+	fixedPointBracketGrowFactor = 1.6
+
+	// fixed point precision limits the step which for contracting functions is much larger than the error,
+	// while Brent method precision limits bracket width, so it is tightened to get comparable accuracy
+	fixedPointPrecisionFactor = 1e-3
+)
+
+// solveFixedPoint finds x satisfying x = g(x) with Brent method.
+// Unlike plain fixed point iteration it converges even if g is not a contraction.
+// Bracket search starts from the interval between x0 and the relaxed step x0 + relaxCoef * (g(x0) - x0),
+// so that relaxCoef < 1 keeps the first probes close to x0 when g overshoots. relaxCoef must be in (0, 1]
+func solveFixedPoint(g root.Func, x0, precision, relaxCoef float64, iterLimit int) (float64, error) {
+	if relaxCoef <= 0 || relaxCoef > 1 {
+		return 0, fmt.Errorf("relaxation coefficient %f is out of range (0, 1]", relaxCoef)
+	}
+	var gx0, err = g(x0)
+	if err != nil {
+		return 0, err
+	}
+	if common.Converged(x0, gx0, precision*fixedPointPrecisionFactor) {
+		return gx0, nil
+	}
+	var x1 = x0 + relaxCoef*(gx0-x0)
+
+	var f = root.FixedPointFunc(g)
+	var a, b, bracketErr = root.Bracket(f, x0, x1, fixedPointBracketGrowFactor, iterLimit)
+	if bracketErr != nil {
+		return 0, bracketErr
+	}
+
+	var report, solveErr = root.NewBrentSolver(precision*fixedPointPrecisionFactor, iterLimit).Solve(f, a, b)
+	if solveErr != nil {
+		return 0, solveErr
+	}
+	return report.X, nil
+}
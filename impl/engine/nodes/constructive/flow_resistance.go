@@ -0,0 +1,111 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common/gdf"
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+const (
+	resistancePrecision = 1e-10
+	resistanceIterLimit = 100
+)
+
+// FlowResistance describes relation between mass rate of a flow passage
+// and stagnation pressures upstream and downstream of it
+type FlowResistance interface {
+	// POut returns stagnation pressure downstream of the passage passing massRate
+	POut(gas gases.Gas, tIn, pIn, massRate float64) (float64, error)
+	// MassRate returns mass rate passed by the passage between pressures pIn and pOut
+	MassRate(gas gases.Gas, tIn, pIn, pOut float64) (float64, error)
+}
+
+// NewOrificeResistance returns resistance of an orifice with effective area cdArea
+// (discharge coefficient times geometric area). Dynamic head of the jet is supposed to be lost,
+// so downstream stagnation pressure equals static pressure of the jet. Orifice chokes
+// when the pressure ratio falls below critical
+func NewOrificeResistance(cdArea float64) FlowResistance {
+	return &orificeResistance{cdArea: cdArea}
+}
+
+type orificeResistance struct {
+	cdArea float64
+}
+
+func (r *orificeResistance) POut(gas gases.Gas, tIn, pIn, massRate float64) (float64, error) {
+	var k = gases.K(gas, tIn)
+	var q = massRate * math.Sqrt(tIn) / (gdf.Q(1, k, gas.R()) * pIn * r.cdArea)
+	if q > 1 {
+		return 0, fmt.Errorf("orifice with effective area %f is choked by mass rate %f", r.cdArea, massRate)
+	}
+	var lambda, err = gdf.LambdaFromQ(q, k, gdf.Subsonic)
+	if err != nil {
+		return 0, err
+	}
+	return pIn * gdf.Pi(lambda, k), nil
+}
+
+func (r *orificeResistance) MassRate(gas gases.Gas, tIn, pIn, pOut float64) (float64, error) {
+	if pOut > pIn {
+		return 0, fmt.Errorf("back pressure %f exceeds inlet pressure %f", pOut, pIn)
+	}
+	var k = gases.K(gas, tIn)
+	var lambda, err = gdf.LambdaFromPi(math.Max(pOut/pIn, gdf.PiCrit(k)), k)
+	if err != nil {
+		return 0, err
+	}
+	return r.cdArea * gdf.Q(lambda, k, gas.R()) * pIn / math.Sqrt(tIn), nil
+}
+
+// NewKFactorResistance returns resistance with pressure loss dp = K * rho * c^2 / 2, where
+// velocity c is related to the reference area and density rho to the inlet state.
+// Loss coefficient K is given as a function of mass rate
+func NewKFactorResistance(area float64, kFunc func(massRate float64) float64) FlowResistance {
+	return &kFactorResistance{area: area, kFunc: kFunc}
+}
+
+type kFactorResistance struct {
+	area  float64
+	kFunc func(massRate float64) float64
+}
+
+func (r *kFactorResistance) POut(gas gases.Gas, tIn, pIn, massRate float64) (float64, error) {
+	var rho = pIn / (gas.R() * tIn)
+	var c = massRate / (rho * r.area)
+	var pOut = pIn - r.kFunc(massRate)*rho*c*c/2
+	if pOut <= 0 {
+		return 0, fmt.Errorf("pressure loss exceeds inlet pressure %f at mass rate %f", pIn, massRate)
+	}
+	return pOut, nil
+}
+
+func (r *kFactorResistance) MassRate(gas gases.Gas, tIn, pIn, pOut float64) (float64, error) {
+	if pOut > pIn {
+		return 0, fmt.Errorf("back pressure %f exceeds inlet pressure %f", pOut, pIn)
+	}
+	var rho = pIn / (gas.R() * tIn)
+	var residual = func(massRate float64) (float64, error) {
+		var c = massRate / (rho * r.area)
+		return r.kFunc(massRate)*rho*c*c/2 - (pIn - pOut), nil
+	}
+
+	// initial guess uses loss coefficient at zero mass rate
+	var b = r.area * math.Sqrt(2*rho*(pIn-pOut)/r.kFunc(0))
+	for i := 0; ; i++ {
+		if value, _ := residual(b); value >= 0 {
+			break
+		}
+		if i == resistanceIterLimit {
+			return 0, fmt.Errorf("failed to find mass rate through resistance")
+		}
+		b *= 2
+	}
+	var report, err = root.NewBrentSolver(resistancePrecision, resistanceIterLimit).Solve(residual, 0, b)
+	if err != nil {
+		return 0, err
+	}
+	return report.X, nil
+}
@@ -1,8 +1,6 @@
 package constructive
 
 import (
-	"math"
-
 	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/common/gdf"
 	"github.com/Sovianum/turbocycle/core/graph"
@@ -210,7 +208,8 @@ func (node *freeTurbineNode) GasOutput() graph.Port {
 }
 
 func (node *freeTurbineNode) lSpecific() float64 {
-	return gases.CpMean(node.inputGas(), node.tStagIn(), node.tStagOut(), nodes.DefaultN) * (node.tStagIn() - node.tStagOut())
+	var gas = node.inputGas()
+	return gases.HP(gas, node.tStagIn(), node.pStagIn()) - gases.HP(gas, node.tStagOut(), node.pStagOut())
 }
 
 func (node *freeTurbineNode) tStatOut() float64 {
@@ -227,21 +226,8 @@ func (node *freeTurbineNode) pStatOut() float64 {
 }
 
 func (node *freeTurbineNode) getTStagOut() (float64, error) {
-	iterFunc := func(tCurr float64) (float64, error) {
-		return node.tStagOutNext(node.pStagIn(), node.pStagOut(), node.tStagIn(), tCurr), nil
-	}
-	tStagOut, err := common.SolveIteratively(iterFunc, node.tStagIn(), node.precision/100, 1, nodes.DefaultN)
-	return tStagOut, err
-}
-
-func (node *freeTurbineNode) tStagOutNext(pStagIn, pStagOut, tStagIn, tStagOutCurr float64) float64 {
-	k := gases.KMean(node.inputGas(), tStagIn, tStagOutCurr, nodes.DefaultN)
-	piTStag := pStagIn / pStagOut
 	// todo piT := piTStag / gdf.Pi(node.lambdaOut, gases.K(node.InputGas(), tStagOutCurr)) was before
-	piT := piTStag
-	x := math.Pow(piT, (1-k)/k)
-
-	return tStagIn * (1 - (1-x)*node.etaT)
+	return gases.ExpansionTP(node.inputGas(), node.tStagIn(), node.pStagIn(), node.pStagOut(), node.etaT)
 }
 
 func (node *freeTurbineNode) piTStag() float64 {
@@ -89,7 +89,7 @@ func (node *gasCombiner) GetUpdatePorts() ([]graph.Port, error) {
 	}, nil
 }
 
-// pressure is taken from main input
+// pressure is taken from main input; heat capacities of real gases are taken at this pressure
 func (node *gasCombiner) Process() error {
 	mainGas := node.gInput.GetState().Value().(gases.Gas)
 	mainT := node.tInput.GetState().Value().(float64)
@@ -99,21 +99,25 @@ func (node *gasCombiner) Process() error {
 	extraT := node.tEInput.GetState().Value().(float64)
 	extraMR := node.mrEInput.GetState().Value().(float64)
 
+	pOut := node.pInput.GetState().Value().(float64)
 	mainFraction := mainMR / (mainMR + extraMR)
 	extraFraction := extraMR / (mainMR + extraMR)
 
-	oGas := gases.NewMixture(
+	oGas, err := gases.Mix(
 		[]gases.Gas{mainGas, extraGas},
 		[]float64{mainFraction, extraFraction},
 	)
+	if err != nil {
+		return err
+	}
 
 	eqSys := math.NewEquationSystem(func(tOutVec *mat.VecDense) (*mat.VecDense, error) {
 		tOut := tOutVec.At(0, 0)
 
-		cpMain := gases.CpMean(mainGas, mainT, tOut, nodes.DefaultN)
+		cpMain := gases.CpMeanP(mainGas, mainT, tOut, pOut, nodes.DefaultN)
 		mainHeat := mainMR * cpMain * (tOut - mainT)
 
-		cpExtra := gases.CpMean(extraGas, extraT, tOut, nodes.DefaultN)
+		cpExtra := gases.CpMeanP(extraGas, extraT, tOut, pOut, nodes.DefaultN)
 		extraHeat := extraMR * cpExtra * (tOut - extraT)
 
 		return mat.NewVecDense(1, []float64{mainHeat + extraHeat}), nil
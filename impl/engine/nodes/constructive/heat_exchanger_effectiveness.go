@@ -0,0 +1,78 @@
+package constructive
+
+import (
+	"math"
+)
+
+// EffectivenessFunc returns effectiveness of the heat exchanger given number of transfer units
+// ntu = UA / cMin and heat capacity rate ratio cr = cMin / cMax
+type EffectivenessFunc func(ntu, cr float64) float64
+
+func CounterFlowEffectiveness(ntu, cr float64) float64 {
+	if cr == 1 {
+		return ntu / (1 + ntu)
+	}
+	var e = math.Exp(-ntu * (1 - cr))
+	return (1 - e) / (1 - cr*e)
+}
+
+func ParallelFlowEffectiveness(ntu, cr float64) float64 {
+	return (1 - math.Exp(-ntu*(1+cr))) / (1 + cr)
+}
+
+// CrossFlowUnmixedEffectiveness is effectiveness of single pass crossflow exchanger
+// with both streams unmixed (approximate correlation)
+func CrossFlowUnmixedEffectiveness(ntu, cr float64) float64 {
+	if cr == 0 {
+		return 1 - math.Exp(-ntu)
+	}
+	return 1 - math.Exp(math.Pow(ntu, 0.22)/cr*(math.Exp(-cr*math.Pow(ntu, 0.78))-1))
+}
+
+// CrossFlowMixedEffectiveness is effectiveness of single pass crossflow exchanger with both streams mixed
+func CrossFlowMixedEffectiveness(ntu, cr float64) float64 {
+	if cr == 0 {
+		return 1 - math.Exp(-ntu)
+	}
+	return 1 / (1/(1-math.Exp(-ntu)) + cr/(1-math.Exp(-cr*ntu)) - 1/ntu)
+}
+
+// CrossFlowCMaxMixedEffectiveness is effectiveness of single pass crossflow exchanger
+// with the stream of greater heat capacity rate mixed and the other one unmixed
+func CrossFlowCMaxMixedEffectiveness(ntu, cr float64) float64 {
+	if cr == 0 {
+		return 1 - math.Exp(-ntu)
+	}
+	return (1 - math.Exp(-cr*(1-math.Exp(-ntu)))) / cr
+}
+
+// CrossFlowCMinMixedEffectiveness is effectiveness of single pass crossflow exchanger
+// with the stream of lower heat capacity rate mixed and the other one unmixed
+func CrossFlowCMinMixedEffectiveness(ntu, cr float64) float64 {
+	if cr == 0 {
+		return 1 - math.Exp(-ntu)
+	}
+	return 1 - math.Exp(-(1-math.Exp(-cr*ntu))/cr)
+}
+
+// ShellAndTubeEffectiveness is effectiveness of exchanger with one shell pass
+// and even number of tube passes
+func ShellAndTubeEffectiveness(ntu, cr float64) float64 {
+	var s = math.Sqrt(1 + cr*cr)
+	var e = math.Exp(-ntu * s)
+	return 2 / (1 + cr + s*(1+e)/(1-e))
+}
+
+// MultiPass returns effectiveness of exchanger made of identical passes connected in overall
+// counterflow arrangement. Each pass gets equal share of transfer units and has effectiveness passFunc
+func MultiPass(passFunc EffectivenessFunc, passes int) EffectivenessFunc {
+	return func(ntu, cr float64) float64 {
+		var n = float64(passes)
+		var passEffectiveness = passFunc(ntu/n, cr)
+		if cr == 1 {
+			return n * passEffectiveness / (1 + (n-1)*passEffectiveness)
+		}
+		var x = math.Pow((1-passEffectiveness*cr)/(1-passEffectiveness), n)
+		return (x - 1) / (x - cr)
+	}
+}
@@ -0,0 +1,45 @@
+package constructive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveness_Limits(t *testing.T) {
+	var funcs = map[string]EffectivenessFunc{
+		"counter":         CounterFlowEffectiveness,
+		"parallel":        ParallelFlowEffectiveness,
+		"crossUnmixed":    CrossFlowUnmixedEffectiveness,
+		"crossMixed":      CrossFlowMixedEffectiveness,
+		"crossCMaxMixed":  CrossFlowCMaxMixedEffectiveness,
+		"crossCMinMixed":  CrossFlowCMinMixedEffectiveness,
+		"shellAndTube":    ShellAndTubeEffectiveness,
+		"counterMulti":    MultiPass(CounterFlowEffectiveness, 3),
+		"shellAndTubeTwo": MultiPass(ShellAndTubeEffectiveness, 2),
+	}
+	for name, f := range funcs {
+		// all the arrangements are equivalent when one of the streams does not change its temperature
+		assert.InDelta(t, 1-math.Exp(-1.5), f(1.5, 0), 1e-2, name)
+
+		for _, cr := range []float64{0.25, 0.5, 1} {
+			var eps = f(2, cr)
+			assert.True(t, eps > 0 && eps < 1, "%s: %f", name, eps)
+			assert.True(t, f(3, cr) > eps, name)
+			assert.True(t, eps <= CounterFlowEffectiveness(2, cr)+1e-12, name)
+			assert.True(t, eps >= ParallelFlowEffectiveness(2, cr)-1e-2, name)
+		}
+	}
+}
+
+func TestEffectiveness_Values(t *testing.T) {
+	assert.InDelta(t, 2./3, CounterFlowEffectiveness(2, 1), 1e-12)
+	assert.InDelta(t, (1-math.Exp(-4))/2, ParallelFlowEffectiveness(2, 1), 1e-12)
+	assert.InDelta(t, CounterFlowEffectiveness(2, 0.5), MultiPass(CounterFlowEffectiveness, 4)(2, 0.5), 1e-12)
+	assert.InDelta(t, CounterFlowEffectiveness(2, 1), MultiPass(CounterFlowEffectiveness, 4)(2, 1), 1e-12)
+	// counterflow passes approach counterflow exchanger as their number grows
+	var single = ShellAndTubeEffectiveness(3, 0.8)
+	var multi = MultiPass(ShellAndTubeEffectiveness, 4)(3, 0.8)
+	assert.True(t, multi > single && multi < CounterFlowEffectiveness(3, 0.8))
+}
@@ -0,0 +1,156 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// HeatExchangerNode is a two stream heat exchanger calculated by effectiveness - NTU method.
+// Cold stream is the coolant one for intercoolers and aftercoolers
+// and the compressed air one for recuperators
+type HeatExchangerNode interface {
+	graph.Node
+
+	HotInput() nodes.ComplexGasSink
+	HotOutput() nodes.ComplexGasSource
+
+	ColdInput() nodes.ComplexGasSink
+	ColdOutput() nodes.ComplexGasSource
+
+	EffectivenessFunc() EffectivenessFunc
+	// UA is product of overall heat transfer coefficient and heat transfer area, W / K
+	UA() float64
+	NTU() float64
+	Effectiveness() float64
+	// HeatRate is heat transferred from the hot stream to the cold one, W
+	HeatRate() float64
+	SigmaHot() float64
+	SigmaCold() float64
+}
+
+func NewHeatExchangerNode(
+	ua, sigmaHot, sigmaCold float64, effectivenessFunc EffectivenessFunc, precision float64, iterLimit int,
+) HeatExchangerNode {
+	var result = &heatExchangerNode{
+		ua:                ua,
+		sigmaHot:          sigmaHot,
+		sigmaCold:         sigmaCold,
+		effectivenessFunc: effectivenessFunc,
+		precision:         precision,
+		iterLimit:         iterLimit,
+	}
+	result.baseRegenerator = newBaseRegenerator(result)
+	return result
+}
+
+type heatExchangerNode struct {
+	*baseRegenerator
+
+	ua            float64
+	ntu           float64
+	effectiveness float64
+	heatRate      float64
+	sigmaHot      float64
+	sigmaCold     float64
+
+	effectivenessFunc EffectivenessFunc
+	precision         float64
+	iterLimit         int
+}
+
+func (node *heatExchangerNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "HeatExchanger")
+}
+
+func (node *heatExchangerNode) EffectivenessFunc() EffectivenessFunc {
+	return node.effectivenessFunc
+}
+
+func (node *heatExchangerNode) UA() float64 {
+	return node.ua
+}
+
+func (node *heatExchangerNode) NTU() float64 {
+	return node.ntu
+}
+
+func (node *heatExchangerNode) Effectiveness() float64 {
+	return node.effectiveness
+}
+
+func (node *heatExchangerNode) HeatRate() float64 {
+	return node.heatRate
+}
+
+func (node *heatExchangerNode) SigmaHot() float64 {
+	return node.sigmaHot
+}
+
+func (node *heatExchangerNode) SigmaCold() float64 {
+	return node.sigmaCold
+}
+
+func (node *heatExchangerNode) Process() error {
+	var ua = node.ua
+	return node.exchange(func(tHotOut, tColdOut float64) float64 {
+		return ua
+	})
+}
+
+// exchange calculates outlet temperatures of the streams. Heat capacity rates and UA
+// depend on the outlet temperatures, so that they are found iteratively
+func (node *heatExchangerNode) exchange(uaFunc func(tHotOut, tColdOut float64) float64) error {
+	var tHotIn, tColdIn = node.tStagHotIn(), node.tStagColdIn()
+	var hotGas = node.hotGasInput.GetState().(states.GasPortState).Gas
+	var coldGas = node.coldGasInput.GetState().(states.GasPortState).Gas
+	var hotMassRate = node.hotMassRateInput.GetState().(states.MassRatePortState).MassRate
+	var coldMassRate = node.coldMassRateInput.GetState().(states.MassRatePortState).MassRate
+	var hotPressure = node.hotPressureInput.GetState().(states.PressurePortState).PStag
+	var coldPressure = node.coldPressureInput.GetState().(states.PressurePortState).PStag
+
+	var tHotOut, tColdOut = tHotIn, tColdIn
+	var converged = false
+	for i := 0; i != node.iterLimit && !converged; i++ {
+		node.ua = uaFunc(tHotOut, tColdOut)
+		var hotCapacity = hotMassRate * gases.CpMeanP(hotGas, tHotIn, tHotOut, hotPressure, nodes.DefaultN)
+		var coldCapacity = coldMassRate * gases.CpMeanP(coldGas, tColdIn, tColdOut, coldPressure, nodes.DefaultN)
+		var minCapacity = math.Min(hotCapacity, coldCapacity)
+		var maxCapacity = math.Max(hotCapacity, coldCapacity)
+
+		node.ntu = node.ua / minCapacity
+		node.effectiveness = node.effectivenessFunc(node.ntu, minCapacity/maxCapacity)
+		node.heatRate = node.effectiveness * minCapacity * (tHotIn - tColdIn)
+
+		var tHotOutNew = tHotIn - node.heatRate/hotCapacity
+		var tColdOutNew = tColdIn + node.heatRate/coldCapacity
+		converged = common.Converged(tHotOut, tHotOutNew, node.precision) &&
+			common.Converged(tColdOut, tColdOutNew, node.precision)
+		tHotOut, tColdOut = tHotOutNew, tColdOutNew
+	}
+	if !converged {
+		return fmt.Errorf("heat exchanger failed to converge in %d iterations", node.iterLimit)
+	}
+
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewTemperaturePortState(tHotOut), states.NewTemperaturePortState(tColdOut),
+			states.NewPressurePortState(hotPressure * node.sigmaHot),
+			states.NewPressurePortState(coldPressure * node.sigmaCold),
+			node.hotMassRateInput.GetState(), node.coldMassRateInput.GetState(),
+			node.hotGasInput.GetState(), node.coldGasInput.GetState(),
+		},
+		[]graph.Port{
+			node.hotTemperatureOutput, node.coldTemperatureOutput,
+			node.hotPressureOutput, node.coldPressureOutput,
+			node.hotMassRateOutput, node.coldMassRateOutput,
+			node.hotGasOutput, node.coldGasOutput,
+		},
+	)
+	return nil
+}
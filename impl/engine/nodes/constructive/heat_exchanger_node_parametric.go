@@ -0,0 +1,115 @@
+package constructive
+
+import (
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+)
+
+// NewParametricHeatExchangerNodeFromProto returns heat exchanger with the geometry of the solved proto.
+// Film conductance hA of each side scales with its Reynolds number as Re^reExponent,
+// hotResistanceShare is the share of the hot side in the design thermal resistance 1 / UA.
+// Pressure loss of each side scales with square of the corrected mass rate
+func NewParametricHeatExchangerNodeFromProto(
+	proto HeatExchangerNode, hotResistanceShare, reExponent, precision float64, iterLimit int,
+) HeatExchangerNode {
+	var hotIn, coldIn = proto.HotInput(), proto.ColdInput()
+	var hotOut, coldOut = proto.HotOutput(), proto.ColdOutput()
+	var hotGas = hotIn.GasInput().GetState().(states.GasPortState).Gas
+	var coldGas = coldIn.GasInput().GetState().(states.GasPortState).Gas
+	var tHotIn = hotIn.TemperatureInput().GetState().(states.TemperaturePortState).TStag
+	var tColdIn = coldIn.TemperatureInput().GetState().(states.TemperaturePortState).TStag
+	var tHotOut = hotOut.TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	var tColdOut = coldOut.TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	var hotMassRate = hotIn.MassRateInput().GetState().(states.MassRatePortState).MassRate
+	var coldMassRate = coldIn.MassRateInput().GetState().(states.MassRatePortState).MassRate
+
+	var result = &parametricHeatExchangerNode{
+		heatExchangerNode: heatExchangerNode{
+			ua:                proto.UA(),
+			sigmaHot:          proto.SigmaHot(),
+			sigmaCold:         proto.SigmaCold(),
+			effectivenessFunc: proto.EffectivenessFunc(),
+			precision:         precision,
+			iterLimit:         iterLimit,
+		},
+		hotConductance0:  proto.UA() / hotResistanceShare,
+		coldConductance0: proto.UA() / (1 - hotResistanceShare),
+		reExponent:       reExponent,
+
+		hotReFactor0:  hotMassRate / hotGas.Mu((tHotIn+tHotOut)/2),
+		coldReFactor0: coldMassRate / coldGas.Mu((tColdIn+tColdOut)/2),
+
+		sigmaHot0:  proto.SigmaHot(),
+		sigmaCold0: proto.SigmaCold(),
+		hotFlow0:   correctedMassRate(hotIn),
+		coldFlow0:  correctedMassRate(coldIn),
+	}
+	result.baseRegenerator = newBaseRegenerator(result)
+
+	graph.CopyAll(
+		[]graph.Port{
+			hotIn.GasInput(), hotIn.TemperatureInput(), hotIn.PressureInput(), hotIn.MassRateInput(),
+			coldIn.GasInput(), coldIn.TemperatureInput(), coldIn.PressureInput(), coldIn.MassRateInput(),
+		},
+		[]graph.Port{
+			result.hotGasInput, result.hotTemperatureInput, result.hotPressureInput, result.hotMassRateInput,
+			result.coldGasInput, result.coldTemperatureInput, result.coldPressureInput, result.coldMassRateInput,
+		},
+	)
+	return result
+}
+
+type parametricHeatExchangerNode struct {
+	heatExchangerNode
+
+	hotConductance0  float64
+	coldConductance0 float64
+	reExponent       float64
+
+	// hotReFactor0 and coldReFactor0 are design values of massRate / mu, which is
+	// proportional to the Reynolds number for the fixed geometry
+	hotReFactor0  float64
+	coldReFactor0 float64
+
+	sigmaHot0  float64
+	sigmaCold0 float64
+	hotFlow0   float64
+	coldFlow0  float64
+}
+
+func (node *parametricHeatExchangerNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "ParametricHeatExchanger")
+}
+
+func (node *parametricHeatExchangerNode) Process() error {
+	var hotFlowRel = correctedMassRate(node.HotInput()) / node.hotFlow0
+	var coldFlowRel = correctedMassRate(node.ColdInput()) / node.coldFlow0
+	node.sigmaHot = 1 - (1-node.sigmaHot0)*hotFlowRel*hotFlowRel
+	node.sigmaCold = 1 - (1-node.sigmaCold0)*coldFlowRel*coldFlowRel
+
+	var hotGas = node.hotGasInput.GetState().(states.GasPortState).Gas
+	var coldGas = node.coldGasInput.GetState().(states.GasPortState).Gas
+	var hotMassRate = node.hotMassRateInput.GetState().(states.MassRatePortState).MassRate
+	var coldMassRate = node.coldMassRateInput.GetState().(states.MassRatePortState).MassRate
+
+	return node.exchange(func(tHotOut, tColdOut float64) float64 {
+		var hotReRel = hotMassRate / hotGas.Mu((node.tStagHotIn()+tHotOut)/2) / node.hotReFactor0
+		var coldReRel = coldMassRate / coldGas.Mu((node.tStagColdIn()+tColdOut)/2) / node.coldReFactor0
+
+		var hotConductance = node.hotConductance0 * math.Pow(hotReRel, node.reExponent)
+		var coldConductance = node.coldConductance0 * math.Pow(coldReRel, node.reExponent)
+		return 1 / (1/hotConductance + 1/coldConductance)
+	})
+}
+
+// correctedMassRate returns massRate * sqrt(T) / p of the stream entering the sink
+func correctedMassRate(sink nodes.ComplexGasSink) float64 {
+	var massRate = sink.MassRateInput().GetState().(states.MassRatePortState).MassRate
+	var t = sink.TemperatureInput().GetState().(states.TemperaturePortState).TStag
+	var p = sink.PressureInput().GetState().(states.PressurePortState).PStag
+	return massRate * math.Sqrt(t) / p
+}
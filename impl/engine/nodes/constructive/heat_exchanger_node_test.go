@@ -0,0 +1,118 @@
+package constructive
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hxUA        = 2000
+	hxSigmaHot  = 0.97
+	hxSigmaCold = 0.98
+	hxTHot      = 800
+	hxTCold     = 450
+	hxPHot      = 1.1e5
+	hxPCold     = 8e5
+)
+
+func TestHeatExchangerNode_Process(t *testing.T) {
+	var hx = getTestHeatExchanger(CounterFlowEffectiveness, 1, 1)
+	assert.Nil(t, hx.Process())
+
+	var tHotOut = hx.HotOutput().TemperatureOutput().GetState().Value().(float64)
+	var tColdOut = hx.ColdOutput().TemperatureOutput().GetState().Value().(float64)
+	assert.True(t, tHotOut < hxTHot && tHotOut > hxTCold, "%f", tHotOut)
+	assert.True(t, tColdOut > hxTCold && tColdOut < hxTHot, "%f", tColdOut)
+
+	// energy balance
+	var air = gases.GetAir()
+	var hotHeat = gases.CpMean(air, tHotOut, hxTHot, nodes.DefaultN) * (hxTHot - tHotOut)
+	var coldHeat = gases.CpMean(air, hxTCold, tColdOut, nodes.DefaultN) * (tColdOut - hxTCold)
+	assert.InDelta(t, hx.HeatRate(), hotHeat, 1e-3*hotHeat)
+	assert.InDelta(t, hx.HeatRate(), coldHeat, 1e-3*coldHeat)
+
+	assert.InDelta(t, hxUA, hx.UA(), 1e-12)
+	assert.InDelta(t, CounterFlowEffectiveness(hx.NTU(), 1), hx.Effectiveness(), 1e-2)
+	assert.InDelta(t, hxPHot*hxSigmaHot, hx.HotOutput().PressureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, hxPCold*hxSigmaCold, hx.ColdOutput().PressureOutput().GetState().Value().(float64), 1e-9)
+	assert.Equal(t, 1., hx.HotOutput().MassRateOutput().GetState().Value())
+}
+
+func TestHeatExchangerNode_Arrangement(t *testing.T) {
+	var heatRate = func(f EffectivenessFunc) float64 {
+		var hx = getTestHeatExchanger(f, 1, 1)
+		assert.Nil(t, hx.Process())
+		return hx.HeatRate()
+	}
+	var counter = heatRate(CounterFlowEffectiveness)
+	var cross = heatRate(CrossFlowUnmixedEffectiveness)
+	var parallel = heatRate(ParallelFlowEffectiveness)
+	assert.True(t, counter > cross && cross > parallel, "%f %f %f", counter, cross, parallel)
+}
+
+func TestHeatExchangerNode_Intercooler(t *testing.T) {
+	// coolant stream of high capacity rate keeps its temperature
+	var hx = getTestHeatExchanger(CrossFlowCMaxMixedEffectiveness, 1, 50)
+	assert.Nil(t, hx.Process())
+
+	var tColdOut = hx.ColdOutput().TemperatureOutput().GetState().Value().(float64)
+	assert.True(t, tColdOut-hxTCold < 10, "%f", tColdOut)
+	var tHotOut = hx.HotOutput().TemperatureOutput().GetState().Value().(float64)
+	assert.InDelta(t, hxTHot-hx.Effectiveness()*(hxTHot-hxTCold), tHotOut, 1)
+}
+
+func TestParametricHeatExchangerNode_Design(t *testing.T) {
+	var proto = getTestHeatExchanger(CounterFlowEffectiveness, 1, 1.2)
+	assert.Nil(t, proto.Process())
+
+	var hx = NewParametricHeatExchangerNodeFromProto(proto, 0.5, 0.8, 1e-9, 100)
+	assert.Nil(t, hx.Process())
+	assert.InDelta(t, proto.UA(), hx.UA(), 1e-9*proto.UA())
+	assert.InDelta(t, proto.HeatRate(), hx.HeatRate(), 1e-6*proto.HeatRate())
+	assert.InDelta(t, proto.SigmaHot(), hx.SigmaHot(), 1e-12)
+	assert.InDelta(t, proto.SigmaCold(), hx.SigmaCold(), 1e-12)
+}
+
+func TestParametricHeatExchangerNode_OffDesign(t *testing.T) {
+	var proto = getTestHeatExchanger(CounterFlowEffectiveness, 1, 1.2)
+	assert.Nil(t, proto.Process())
+
+	var hx = NewParametricHeatExchangerNodeFromProto(proto, 0.5, 0.8, 1e-9, 100)
+	hx.HotInput().MassRateInput().SetState(states.NewMassRatePortState(0.5))
+	hx.ColdInput().MassRateInput().SetState(states.NewMassRatePortState(0.6))
+	assert.Nil(t, hx.Process())
+
+	// lower Reynolds numbers reduce heat transfer, but transfer units per unit flow grow
+	assert.True(t, hx.UA() < proto.UA())
+	assert.True(t, hx.UA() > 0.5*proto.UA())
+	assert.True(t, hx.NTU() > proto.NTU())
+	assert.True(t, hx.Effectiveness() > proto.Effectiveness())
+	assert.True(t, hx.HeatRate() < proto.HeatRate())
+
+	assert.InDelta(t, 1-(1-hxSigmaHot)*0.25, hx.SigmaHot(), 1e-12)
+	assert.InDelta(t, 1-(1-hxSigmaCold)*0.25, hx.SigmaCold(), 1e-12)
+}
+
+func getTestHeatExchanger(f EffectivenessFunc, hotMassRate, coldMassRate float64) HeatExchangerNode {
+	var hx = NewHeatExchangerNode(hxUA, hxSigmaHot, hxSigmaCold, f, 1e-9, 100)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gases.GetAir()), states.NewTemperaturePortState(hxTHot),
+			states.NewPressurePortState(hxPHot), states.NewMassRatePortState(hotMassRate),
+			states.NewGasPortState(gases.GetAir()), states.NewTemperaturePortState(hxTCold),
+			states.NewPressurePortState(hxPCold), states.NewMassRatePortState(coldMassRate),
+		},
+		[]graph.Port{
+			hx.HotInput().GasInput(), hx.HotInput().TemperatureInput(),
+			hx.HotInput().PressureInput(), hx.HotInput().MassRateInput(),
+			hx.ColdInput().GasInput(), hx.ColdInput().TemperatureInput(),
+			hx.ColdInput().PressureInput(), hx.ColdInput().MassRateInput(),
+		},
+	)
+	return hx
+}
@@ -0,0 +1,346 @@
+package constructive
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/helper"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/water"
+)
+
+// HRSGLevel is a pressure level of the heat recovery steam generator
+type HRSGLevel struct {
+	// P is drum pressure, Pa
+	P float64
+	// TSteam is temperature of the superheated steam, K
+	TSteam float64
+}
+
+// HRSGSections describes superheater, evaporator and economiser of a pressure level.
+// Economiser heat includes heat of water going to the higher pressure levels
+type HRSGSections struct {
+	SuperheaterHeat float64
+	EvaporatorHeat  float64
+	EconomiserHeat  float64
+
+	// TGasSuperheater, TGasEvaporator and TGasEconomiser are gas temperatures downstream the sections
+	TGasSuperheater float64
+	TGasEvaporator  float64
+	TGasEconomiser  float64
+}
+
+// HRSGNode is a heat recovery steam generator with one or several pressure levels.
+// Exhaust gas passes superheater, evaporator and economiser of each level in the order of
+// decreasing pressure. Steam mass rate of a level is defined by the pinch temperature difference
+// between gas leaving the evaporator and the saturated water; water leaves the economiser
+// subcooled by the approach temperature difference. Economisers are cascaded: feed water of all levels
+// passes the economiser of the lowest pressure level, then the part of it going to the higher levels
+// passes the economiser of the next level and so on. Gas output carries the stack gas
+type HRSGNode interface {
+	graph.Node
+	nodes.ComplexGasChannel
+
+	Levels() []HRSGLevel
+	SteamOutput(level int) nodes.SteamSource
+	SteamMassRate(level int) float64
+	Sections(level int) HRSGSections
+
+	TFeed() float64
+	Pinch() float64
+	Approach() float64
+	Sigma() float64
+
+	StackTemperature() float64
+	// HeatRate is heat recovered from the gas, W
+	HeatRate() float64
+}
+
+// NewHRSGNode returns HRSG with levels sorted by decreasing pressure. Feed water of temperature tFeed
+// is pumped to the pressure of every level, sigma is the gas side pressure loss
+func NewHRSGNode(levels []HRSGLevel, tFeed, pinch, approach, sigma float64) HRSGNode {
+	var result = &hrsgNode{
+		levels:   levels,
+		tFeed:    tFeed,
+		pinch:    pinch,
+		approach: approach,
+		sigma:    sigma,
+
+		steamMassRates: make([]float64, len(levels)),
+		sections:       make([]HRSGSections, len(levels)),
+	}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.gasInput, &result.temperatureInput, &result.pressureInput, &result.massRateInput,
+			&result.gasOutput, &result.temperatureOutput, &result.pressureOutput, &result.massRateOutput,
+		},
+		[]string{
+			nodes.GasInputTag, nodes.TemperatureInputTag, nodes.PressureInputTag, nodes.MassRateInputTag,
+			nodes.GasOutputTag, nodes.TemperatureOutputTag, nodes.PressureOutputTag, nodes.MassRateOutputTag,
+		},
+	)
+	for i := range levels {
+		result.steamOutputs = append(
+			result.steamOutputs, graph.NewAttachedPortWithTag(result, fmt.Sprintf("steamOutput%d", i)),
+		)
+		result.steamMassRateOutputs = append(
+			result.steamMassRateOutputs, graph.NewAttachedPortWithTag(result, fmt.Sprintf("steamMassRateOutput%d", i)),
+		)
+	}
+	return result
+}
+
+type hrsgNode struct {
+	graph.BaseNode
+
+	gasInput         graph.Port
+	temperatureInput graph.Port
+	pressureInput    graph.Port
+	massRateInput    graph.Port
+
+	gasOutput         graph.Port
+	temperatureOutput graph.Port
+	pressureOutput    graph.Port
+	massRateOutput    graph.Port
+
+	steamOutputs         []graph.Port
+	steamMassRateOutputs []graph.Port
+
+	levels   []HRSGLevel
+	tFeed    float64
+	pinch    float64
+	approach float64
+	sigma    float64
+
+	steamMassRates []float64
+	sections       []HRSGSections
+	heatRate       float64
+}
+
+func (node *hrsgNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "HRSG")
+}
+
+func (node *hrsgNode) GasInput() graph.Port {
+	return node.gasInput
+}
+
+func (node *hrsgNode) TemperatureInput() graph.Port {
+	return node.temperatureInput
+}
+
+func (node *hrsgNode) PressureInput() graph.Port {
+	return node.pressureInput
+}
+
+func (node *hrsgNode) MassRateInput() graph.Port {
+	return node.massRateInput
+}
+
+func (node *hrsgNode) GasOutput() graph.Port {
+	return node.gasOutput
+}
+
+func (node *hrsgNode) TemperatureOutput() graph.Port {
+	return node.temperatureOutput
+}
+
+func (node *hrsgNode) PressureOutput() graph.Port {
+	return node.pressureOutput
+}
+
+func (node *hrsgNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *hrsgNode) Levels() []HRSGLevel {
+	return node.levels
+}
+
+func (node *hrsgNode) SteamOutput(level int) nodes.SteamSource {
+	return helper.NewPseudoSteamSource(node.steamOutputs[level], node.steamMassRateOutputs[level])
+}
+
+func (node *hrsgNode) SteamMassRate(level int) float64 {
+	return node.steamMassRates[level]
+}
+
+func (node *hrsgNode) Sections(level int) HRSGSections {
+	return node.sections[level]
+}
+
+func (node *hrsgNode) TFeed() float64 {
+	return node.tFeed
+}
+
+func (node *hrsgNode) Pinch() float64 {
+	return node.pinch
+}
+
+func (node *hrsgNode) Approach() float64 {
+	return node.approach
+}
+
+func (node *hrsgNode) Sigma() float64 {
+	return node.sigma
+}
+
+func (node *hrsgNode) StackTemperature() float64 {
+	return node.temperatureOutput.GetState().(states.TemperaturePortState).TStag
+}
+
+func (node *hrsgNode) HeatRate() float64 {
+	return node.heatRate
+}
+
+func (node *hrsgNode) GetPorts() []graph.Port {
+	var result = []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput,
+	}
+	var updatePorts, _ = node.GetUpdatePorts()
+	return append(result, updatePorts...)
+}
+
+func (node *hrsgNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput,
+	}, nil
+}
+
+func (node *hrsgNode) GetUpdatePorts() ([]graph.Port, error) {
+	var result = []graph.Port{
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput,
+	}
+	result = append(result, node.steamOutputs...)
+	return append(result, node.steamMassRateOutputs...), nil
+}
+
+func (node *hrsgNode) Process() error {
+	var gas = node.gasInput.GetState().(states.GasPortState).Gas
+	var tGasIn = node.temperatureInput.GetState().(states.TemperaturePortState).TStag
+	var gasMassRate = node.massRateInput.GetState().(states.MassRatePortState).MassRate
+
+	var tSats = make([]float64, len(node.levels))
+	for i, level := range node.levels {
+		if i > 0 && level.P >= node.levels[i-1].P {
+			return fmt.Errorf("hrsg pressure levels must be sorted by decreasing pressure")
+		}
+		var tSat, err = water.SaturationTemperature(level.P)
+		if err != nil {
+			return err
+		}
+		tSats[i] = tSat
+	}
+
+	var tGas = tGasIn
+	for i := range node.levels {
+		var tWaterIn = node.tFeed
+		if i < len(node.levels)-1 {
+			tWaterIn = tSats[i+1] - node.approach
+		}
+		if err := node.processLevel(gas, gasMassRate, tGas, tSats[i], tWaterIn, i); err != nil {
+			return fmt.Errorf("hrsg level %d: %s", i, err.Error())
+		}
+		tGas = node.sections[i].TGasEconomiser
+	}
+
+	node.heatRate = gasMassRate * (gas.H(tGasIn) - gas.H(tGas))
+	graph.SetAll(
+		[]graph.PortState{
+			node.gasInput.GetState(), states.NewTemperaturePortState(tGas),
+			states.NewPressurePortState(node.pressureInput.GetState().(states.PressurePortState).PStag * node.sigma),
+			node.massRateInput.GetState(),
+		},
+		[]graph.Port{node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput},
+	)
+	return nil
+}
+
+// processLevel calculates sections of the i-th level with gas entering its superheater at tGasIn.
+// Economiser of the level heats water of the level and of all the higher pressure ones
+// from tWaterIn (outlet temperature of the next level economiser) to tSat - approach
+func (node *hrsgNode) processLevel(gas gases.Gas, gasMassRate, tGasIn, tSat, tWaterIn float64, i int) error {
+	var level = node.levels[i]
+	var result HRSGSections
+	if level.TSteam <= tSat {
+		return fmt.Errorf("steam temperature %f is not above saturation temperature %f", level.TSteam, tSat)
+	}
+	if level.TSteam >= tGasIn {
+		return fmt.Errorf("steam temperature %f is not below gas temperature %f", level.TSteam, tGasIn)
+	}
+	// for the lowest pressure level tWaterIn is the feed temperature
+	if tWaterIn >= tSat-node.approach {
+		return fmt.Errorf(
+			"inlet water temperature %f is not below economiser outlet temperature %f", tWaterIn, tSat-node.approach,
+		)
+	}
+	result.TGasEvaporator = tSat + node.pinch
+	if result.TGasEvaporator >= tGasIn {
+		return fmt.Errorf("gas temperature %f is too low for the pinch point %f", tGasIn, result.TGasEvaporator)
+	}
+
+	var economiserOut, err = water.PT(level.P, tSat-node.approach)
+	if err != nil {
+		return err
+	}
+	vapour, err := water.SaturatedVapour(level.P)
+	if err != nil {
+		return err
+	}
+	steam, err := water.PT(level.P, level.TSteam)
+	if err != nil {
+		return err
+	}
+
+	// superheater and evaporator take heat of gas down to the pinch point
+	var steamMassRate = gasMassRate * (gas.H(tGasIn) - gas.H(result.TGasEvaporator)) / (steam.H - economiserOut.H)
+	node.steamMassRates[i] = steamMassRate
+	result.SuperheaterHeat = steamMassRate * (steam.H - vapour.H)
+	result.EvaporatorHeat = steamMassRate * (vapour.H - economiserOut.H)
+	for j := 0; j <= i; j++ {
+		var heat float64
+		if heat, err = node.waterHeat(node.levels[j].P, tWaterIn, tSat-node.approach); err != nil {
+			return err
+		}
+		result.EconomiserHeat += node.steamMassRates[j] * heat
+	}
+
+	if result.TGasSuperheater, err = gases.TFromH(
+		gas, gas.H(tGasIn)-result.SuperheaterHeat/gasMassRate, tGasIn,
+	); err != nil {
+		return err
+	}
+	if result.TGasEconomiser, err = gases.TFromH(
+		gas, gas.H(result.TGasEvaporator)-result.EconomiserHeat/gasMassRate, result.TGasEvaporator,
+	); err != nil {
+		return err
+	}
+	if result.TGasEconomiser <= tWaterIn {
+		return fmt.Errorf(
+			"economiser gas temperature %f is below inlet water temperature %f", result.TGasEconomiser, tWaterIn,
+		)
+	}
+
+	node.sections[i] = result
+	graph.SetAll(
+		[]graph.PortState{states.NewSteamPortState(level.P, steam.H), states.NewMassRatePortState(steamMassRate)},
+		[]graph.Port{node.steamOutputs[i], node.steamMassRateOutputs[i]},
+	)
+	return nil
+}
+
+func (node *hrsgNode) waterHeat(p, tIn, tOut float64) (float64, error) {
+	var waterIn, err = water.PT(p, tIn)
+	if err != nil {
+		return 0, err
+	}
+	waterOut, err := water.PT(p, tOut)
+	if err != nil {
+		return 0, err
+	}
+	return waterOut.H - waterIn.H, nil
+}
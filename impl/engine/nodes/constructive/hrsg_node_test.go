@@ -0,0 +1,101 @@
+package constructive
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/water"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hrsgTGas     = 850
+	hrsgPGas     = 1.05e5
+	hrsgMassRate = 100
+	hrsgTFeed    = 320
+	hrsgPinch    = 10
+	hrsgApproach = 5
+	hrsgSigma    = 0.97
+)
+
+func TestHRSGNode_SinglePressure(t *testing.T) {
+	var hrsg = getTestHRSG([]HRSGLevel{{P: 6e6, TSteam: 800}})
+	assert.Nil(t, hrsg.Process())
+
+	var tSat, _ = water.SaturationTemperature(6e6)
+	var sections = hrsg.Sections(0)
+	assert.InDelta(t, tSat+hrsgPinch, sections.TGasEvaporator, 1e-9)
+	assert.True(t, sections.TGasSuperheater < hrsgTGas)
+	assert.True(t, sections.TGasSuperheater > sections.TGasEvaporator)
+	assert.InDelta(t, sections.TGasEconomiser, hrsg.StackTemperature(), 1e-12)
+	assert.True(t, hrsg.StackTemperature() > hrsgTFeed)
+
+	// heat taken from gas is absorbed by water
+	var absorbed = sections.SuperheaterHeat + sections.EvaporatorHeat + sections.EconomiserHeat
+	assert.InDelta(t, hrsg.HeatRate(), absorbed, 1e-6*absorbed)
+	assert.InDelta(t, absorbed, steamHeat(hrsg), 1e-6*absorbed)
+	assert.InDelta(t, hrsg.SteamMassRate(0), hrsg.SteamOutput(0).MassRateOutput().GetState().Value().(float64), 1e-12)
+
+	assert.InDelta(t, hrsgPGas*hrsgSigma, hrsg.PressureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, hrsgMassRate, hrsg.MassRateOutput().GetState().Value().(float64), 1e-12)
+}
+
+func TestHRSGNode_MultiPressure(t *testing.T) {
+	var single = getTestHRSG([]HRSGLevel{{P: 4e6, TSteam: 800}})
+	var dual = getTestHRSG([]HRSGLevel{{P: 4e6, TSteam: 800}, {P: 5e5, TSteam: 460}})
+	var triple = getTestHRSG([]HRSGLevel{{P: 4e6, TSteam: 800}, {P: 1e6, TSteam: 475}, {P: 2e5, TSteam: 420}})
+	assert.Nil(t, single.Process())
+	assert.Nil(t, dual.Process())
+	assert.Nil(t, triple.Process())
+
+	assert.InDelta(t, single.SteamMassRate(0), dual.SteamMassRate(0), 1e-9)
+	assert.True(t, dual.StackTemperature() < single.StackTemperature())
+	assert.True(t, triple.StackTemperature() < dual.StackTemperature())
+	assert.True(t, dual.HeatRate() > single.HeatRate())
+	for i := range triple.Levels() {
+		assert.True(t, triple.SteamMassRate(i) > 0, "%d", i)
+	}
+	assert.InDelta(t, triple.HeatRate(), steamHeat(triple), 1e-6*triple.HeatRate())
+}
+
+func TestHRSGNode_Errors(t *testing.T) {
+	// steam is not superheated
+	assert.NotNil(t, getTestHRSG([]HRSGLevel{{P: 6e6, TSteam: 500}}).Process())
+	// steam is hotter than gas
+	assert.NotNil(t, getTestHRSG([]HRSGLevel{{P: 6e6, TSteam: 900}}).Process())
+	// levels are not sorted
+	assert.NotNil(t, getTestHRSG([]HRSGLevel{{P: 6e5, TSteam: 500}, {P: 6e6, TSteam: 800}}).Process())
+	// feed water is not subcooled by the approach at the lowest pressure level
+	var tSat, _ = water.SaturationTemperature(1e4)
+	var hrsg = getTestHRSG([]HRSGLevel{{P: 6e6, TSteam: 800}, {P: 1e4, TSteam: 400}})
+	assert.True(t, tSat-hrsgApproach < hrsgTFeed)
+	assert.NotNil(t, hrsg.Process())
+}
+
+// steamHeat returns heat absorbed by water heated from the feed temperature to the steam of each level
+func steamHeat(hrsg HRSGNode) float64 {
+	var result float64
+	for i, level := range hrsg.Levels() {
+		var feed, _ = water.PT(level.P, hrsgTFeed)
+		var steam = hrsg.SteamOutput(i).SteamOutput().GetState().(states.SteamPortState)
+		result += hrsg.SteamMassRate(i) * (steam.H - feed.H)
+	}
+	return result
+}
+
+func getTestHRSG(levels []HRSGLevel) HRSGNode {
+	var hrsg = NewHRSGNode(levels, hrsgTFeed, hrsgPinch, hrsgApproach, hrsgSigma)
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(fuel.GetCH4().GetCombustionGas(gases.GetAir(), 3)),
+			states.NewTemperaturePortState(hrsgTGas),
+			states.NewPressurePortState(hrsgPGas),
+			states.NewMassRatePortState(hrsgMassRate),
+		},
+		[]graph.Port{hrsg.GasInput(), hrsg.TemperatureInput(), hrsg.PressureInput(), hrsg.MassRateInput()},
+	)
+	return hrsg
+}
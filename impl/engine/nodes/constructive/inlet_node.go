@@ -0,0 +1,324 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+const (
+	velocityInputTag = "velocityInput"
+	ramDragOutputTag = "ramDragOutput"
+
+	inletPrecision = 1e-10
+	inletIterLimit = 1000
+)
+
+// InletRecovery returns ram total pressure recovery of the inlet at flight Mach number
+type InletRecovery func(mach float64) float64
+
+// ConstantRecovery returns recovery which does not depend on flight Mach number
+func ConstantRecovery(sigma float64) InletRecovery {
+	return func(mach float64) float64 {
+		return sigma
+	}
+}
+
+// MilSpecRecovery returns recovery of MIL-E-5008B standard scaled by subsonic recovery sigmaSubsonic
+func MilSpecRecovery(sigmaSubsonic float64) InletRecovery {
+	return func(mach float64) float64 {
+		switch {
+		case mach <= 1:
+			return sigmaSubsonic
+		case mach <= 5:
+			return sigmaSubsonic * (1 - 0.075*math.Pow(mach-1, 1.35))
+		default:
+			return sigmaSubsonic * 800 / (math.Pow(mach, 4) + 935)
+		}
+	}
+}
+
+// InletNode decelerates free stream to the compressor face. Inlet gets stagnation parameters
+// of the free stream (which already include ram temperature rise) and flight velocity.
+// Total pressure is multiplied by the product of ram recovery (function of flight Mach number)
+// and sigma of the internal duct. Ram drag is the momentum of the captured free stream
+type InletNode interface {
+	graph.Node
+	nodes.ComplexGasChannel
+	VelocityInput() graph.Port
+	RamDragOutput() graph.Port
+
+	Recovery() InletRecovery
+	// Sigma is total pressure ratio of the inlet including ram recovery
+	Sigma() float64
+	RamRecovery() float64
+	DuctSigma() float64
+	Mach() float64
+	RamDrag() float64
+	// RamTemperatureRise is difference between stagnation and static temperature of the free stream
+	RamTemperatureRise() float64
+	// NormMassRate is corrected mass rate G * sqrt(T*) / p* at the outlet
+	NormMassRate() float64
+}
+
+func NewInletNode(recovery InletRecovery, ductSigma float64) InletNode {
+	var result = &inletNode{
+		recovery:  recovery,
+		ductSigma: ductSigma,
+	}
+	result.attachPorts(result)
+	return result
+}
+
+// NewParametricInletNodeFromProto returns off-design inlet with design point of proto.
+// Proto must be processed before the call
+func NewParametricInletNodeFromProto(proto InletNode) InletNode {
+	return NewParametricInletNode(proto.Recovery(), proto.DuctSigma(), proto.NormMassRate())
+}
+
+// NewParametricInletNode returns inlet with duct pressure loss 1 - sigma scaled with the square of the
+// corrected outlet mass rate relative to its design value normMassRate0 (i.e. loss is proportional
+// to the dynamic pressure at the compressor face). Ram recovery keeps depending on flight Mach number
+func NewParametricInletNode(recovery InletRecovery, ductSigma0, normMassRate0 float64) InletNode {
+	var result = &parametricInletNode{
+		inletNode: inletNode{
+			recovery:  recovery,
+			ductSigma: ductSigma0,
+		},
+		ductSigma0:    ductSigma0,
+		normMassRate0: normMassRate0,
+	}
+	result.attachPorts(result)
+	return result
+}
+
+type inletNode struct {
+	graph.BaseNode
+
+	gasInput         graph.Port
+	temperatureInput graph.Port
+	pressureInput    graph.Port
+	massRateInput    graph.Port
+	velocityInput    graph.Port
+
+	gasOutput         graph.Port
+	temperatureOutput graph.Port
+	pressureOutput    graph.Port
+	massRateOutput    graph.Port
+	ramDragOutput     graph.Port
+
+	recovery  InletRecovery
+	ductSigma float64
+
+	ramRecovery        float64
+	mach               float64
+	ramDrag            float64
+	ramTemperatureRise float64
+	normMassRate       float64
+}
+
+func (node *inletNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "Inlet")
+}
+
+func (node *inletNode) Process() error {
+	return node.process(func(pStagRam float64) (float64, error) {
+		return node.ductSigma, nil
+	})
+}
+
+func (node *inletNode) Recovery() InletRecovery {
+	return node.recovery
+}
+
+func (node *inletNode) Sigma() float64 {
+	return node.ramRecovery * node.ductSigma
+}
+
+func (node *inletNode) RamRecovery() float64 {
+	return node.ramRecovery
+}
+
+func (node *inletNode) DuctSigma() float64 {
+	return node.ductSigma
+}
+
+func (node *inletNode) Mach() float64 {
+	return node.mach
+}
+
+func (node *inletNode) RamDrag() float64 {
+	return node.ramDrag
+}
+
+func (node *inletNode) RamTemperatureRise() float64 {
+	return node.ramTemperatureRise
+}
+
+func (node *inletNode) NormMassRate() float64 {
+	return node.normMassRate
+}
+
+func (node *inletNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput, node.velocityInput,
+	}, nil
+}
+
+func (node *inletNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.ramDragOutput,
+	}, nil
+}
+
+func (node *inletNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput, node.velocityInput,
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.ramDragOutput,
+	}
+}
+
+func (node *inletNode) GasInput() graph.Port {
+	return node.gasInput
+}
+
+func (node *inletNode) TemperatureInput() graph.Port {
+	return node.temperatureInput
+}
+
+func (node *inletNode) PressureInput() graph.Port {
+	return node.pressureInput
+}
+
+func (node *inletNode) MassRateInput() graph.Port {
+	return node.massRateInput
+}
+
+func (node *inletNode) VelocityInput() graph.Port {
+	return node.velocityInput
+}
+
+func (node *inletNode) GasOutput() graph.Port {
+	return node.gasOutput
+}
+
+func (node *inletNode) TemperatureOutput() graph.Port {
+	return node.temperatureOutput
+}
+
+func (node *inletNode) PressureOutput() graph.Port {
+	return node.pressureOutput
+}
+
+func (node *inletNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *inletNode) RamDragOutput() graph.Port {
+	return node.ramDragOutput
+}
+
+func (node *inletNode) attachPorts(owner graph.Node) {
+	graph.AttachAllWithTags(
+		owner,
+		[]*graph.Port{
+			&node.gasInput, &node.temperatureInput, &node.pressureInput, &node.massRateInput,
+			&node.velocityInput,
+			&node.gasOutput, &node.temperatureOutput, &node.pressureOutput, &node.massRateOutput,
+			&node.ramDragOutput,
+		},
+		[]string{
+			nodes.GasInputTag, nodes.TemperatureInputTag, nodes.PressureInputTag, nodes.MassRateInputTag,
+			velocityInputTag,
+			nodes.GasOutputTag, nodes.TemperatureOutputTag, nodes.PressureOutputTag, nodes.MassRateOutputTag,
+			ramDragOutputTag,
+		},
+	)
+}
+
+// process sets outputs of the inlet. ductSigmaFunc returns sigma of the duct
+// given stagnation pressure after ram recovery
+func (node *inletNode) process(ductSigmaFunc func(pStagRam float64) (float64, error)) error {
+	var gas = node.gasInput.GetState().Value().(gases.Gas)
+	var tStag = node.temperatureInput.GetState().Value().(float64)
+	var pStag = node.pressureInput.GetState().Value().(float64)
+	var massRate = node.massRateInput.GetState().Value().(float64)
+	var velocity = node.velocityInput.GetState().Value().(float64)
+
+	if velocity < 0 {
+		return fmt.Errorf("invalid flight velocity %f", velocity)
+	}
+	var tStatic = tStag
+	var err error
+	if velocity > 0 {
+		if tStatic, err = gases.TFromH(gas, gas.H(tStag)-velocity*velocity/2, tStag); err != nil {
+			return err
+		}
+	}
+	node.ramTemperatureRise = tStag - tStatic
+	node.mach = velocity / math.Sqrt(gases.K(gas, tStatic)*gas.R()*tStatic)
+	node.ramDrag = massRate * velocity
+	node.ramRecovery = node.recovery(node.mach)
+
+	var pStagRam = pStag * node.ramRecovery
+	if node.ductSigma, err = ductSigmaFunc(pStagRam); err != nil {
+		return err
+	}
+	var pStagOut = pStagRam * node.ductSigma
+	node.normMassRate = massRate * math.Sqrt(tStag) / pStagOut
+
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gas),
+			states.NewTemperaturePortState(tStag),
+			states.NewPressurePortState(pStagOut),
+			states.NewMassRatePortState(massRate),
+			graph.NewNumberPortState(node.ramDrag),
+		},
+		[]graph.Port{
+			node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.ramDragOutput,
+		},
+	)
+	return nil
+}
+
+type parametricInletNode struct {
+	inletNode
+
+	ductSigma0    float64
+	normMassRate0 float64
+}
+
+func (node *parametricInletNode) Process() error {
+	var tStag = node.temperatureInput.GetState().Value().(float64)
+	var massRate = node.massRateInput.GetState().Value().(float64)
+
+	return node.process(func(pStagRam float64) (float64, error) {
+		// outlet corrected mass rate depends on duct sigma itself, so the loss
+		// equation 1 - sigma = (1 - sigma0) * (G * sqrt(T*) / (sigma * p*ram) / n0)^2 is solved for sigma
+		var lossCoef = (1 - node.ductSigma0) * math.Pow(massRate*math.Sqrt(tStag)/(pStagRam*node.normMassRate0), 2)
+		return node.solveSigma(lossCoef)
+	})
+}
+
+// solveSigma returns root of sigma^3 - sigma^2 + lossCoef = 0 closest to unity
+func (node *parametricInletNode) solveSigma(lossCoef float64) (float64, error) {
+	// the greatest root exists while lossCoef does not exceed the maximum of sigma^2 * (1 - sigma) at sigma = 2 / 3
+	if lossCoef > 4./27 {
+		return 0, fmt.Errorf("inlet duct is choked: loss coefficient %f is too high", lossCoef)
+	}
+	var sigma = 1.
+	for i := 0; i != inletIterLimit; i++ {
+		var next = 1 - lossCoef/(sigma*sigma)
+		if math.Abs(next-sigma) < inletPrecision {
+			return next, nil
+		}
+		sigma = next
+	}
+	return 0, fmt.Errorf("failed to converge inlet duct sigma in %d iterations", inletIterLimit)
+}
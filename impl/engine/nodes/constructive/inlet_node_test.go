@@ -0,0 +1,111 @@
+package constructive
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/suite"
+)
+
+type InletNodeTestSuite struct {
+	suite.Suite
+	gas gases.Gas
+}
+
+func (s *InletNodeTestSuite) SetupTest() {
+	s.gas = gases.GetAir()
+}
+
+func (s *InletNodeTestSuite) TestRecoveries() {
+	s.InDelta(0.97, ConstantRecovery(0.97)(2.5), 1e-12)
+
+	var milSpec = MilSpecRecovery(1)
+	s.InDelta(1, milSpec(0.8), 1e-12)
+	s.InDelta(1, milSpec(1), 1e-12)
+	s.InDelta(1-0.075, milSpec(2), 1e-12)
+	s.InDelta(800./(1296+935), milSpec(6), 1e-12)
+	s.InDelta(0.98*(1-0.075), MilSpecRecovery(0.98)(2), 1e-12)
+}
+
+func (s *InletNodeTestSuite) TestStatic() {
+	var inlet = NewInletNode(MilSpecRecovery(0.98), 0.99)
+	setComplexInput(inlet, s.gas, 288, 1e5, 50)
+	inlet.VelocityInput().SetState(graph.NewNumberPortState(0))
+	s.Require().Nil(inlet.Process())
+
+	s.InDelta(0, inlet.Mach(), 1e-12)
+	s.InDelta(0, inlet.RamDrag(), 1e-12)
+	s.InDelta(0.98*0.99, inlet.Sigma(), 1e-12)
+	s.InDelta(1e5*0.98*0.99, inlet.PressureOutput().GetState().Value().(float64), 1e-6)
+	s.InDelta(288, inlet.TemperatureOutput().GetState().Value().(float64), 1e-12)
+}
+
+func (s *InletNodeTestSuite) TestFlightCondition() {
+	var flight = source.NewFlightConditionSourceNode(s.gas, 15000, 2, 0, 40)
+	var inlet = NewInletNode(MilSpecRecovery(1), 0.98)
+	graph.LinkAll(
+		[]graph.Port{
+			flight.GasOutput(), flight.TemperatureOutput(), flight.PressureOutput(), flight.MassRateOutput(),
+			flight.VelocityOutput(),
+		},
+		[]graph.Port{
+			inlet.GasInput(), inlet.TemperatureInput(), inlet.PressureInput(), inlet.MassRateInput(),
+			inlet.VelocityInput(),
+		},
+	)
+	s.Require().Nil(flight.Process())
+	s.Require().Nil(inlet.Process())
+
+	s.InDelta(2, inlet.Mach(), 1e-3)
+	s.InDelta(1-0.075, inlet.RamRecovery(), 1e-3)
+	s.InDelta(40*flight.Velocity(), inlet.RamDrag(), 1e-9)
+	s.InDelta(inlet.RamDrag(), inlet.RamDragOutput().GetState().Value().(float64), 1e-9)
+	s.InDelta(flight.Velocity()*flight.Velocity()/(2*1005), inlet.RamTemperatureRise(), 3)
+
+	var pStag = flight.PressureOutput().GetState().Value().(float64)
+	s.InDelta(pStag*inlet.Sigma(), inlet.PressureOutput().GetState().Value().(float64), 1e-6)
+}
+
+func (s *InletNodeTestSuite) TestParametric_DesignPoint() {
+	var inlet = NewInletNode(ConstantRecovery(1), 0.97)
+	s.setInputs(inlet, 288, 1e5, 50, 100)
+	s.Require().Nil(inlet.Process())
+
+	var parametric = NewParametricInletNodeFromProto(inlet)
+	s.setInputs(parametric, 288, 1e5, 50, 100)
+	s.Require().Nil(parametric.Process())
+	s.InDelta(inlet.DuctSigma(), parametric.DuctSigma(), 1e-9)
+	s.InDelta(inlet.NormMassRate(), parametric.NormMassRate(), 1e-6)
+}
+
+func (s *InletNodeTestSuite) TestParametric_OffDesign() {
+	var inlet = NewInletNode(ConstantRecovery(1), 0.97)
+	s.setInputs(inlet, 288, 1e5, 50, 100)
+	s.Require().Nil(inlet.Process())
+	var parametric = NewParametricInletNodeFromProto(inlet)
+
+	s.setInputs(parametric, 288, 1e5, 35, 100)
+	s.Require().Nil(parametric.Process())
+	var sigma = parametric.DuctSigma()
+	s.True(sigma > 0.97)
+	var relNormMassRate = parametric.NormMassRate() / inlet.NormMassRate()
+	s.InDelta(1-0.03*relNormMassRate*relNormMassRate, sigma, 1e-9)
+
+	s.setInputs(parametric, 288, 1e5, 60, 100)
+	s.Require().Nil(parametric.Process())
+	s.True(parametric.DuctSigma() < 0.97)
+
+	s.setInputs(parametric, 288, 1e5, 500, 100)
+	s.NotNil(parametric.Process())
+}
+
+func (s *InletNodeTestSuite) setInputs(inlet InletNode, t, p, massRate, velocity float64) {
+	setComplexInput(inlet, s.gas, t, p, massRate)
+	inlet.VelocityInput().SetState(graph.NewNumberPortState(velocity))
+}
+
+func TestInletNodeTestSuite(t *testing.T) {
+	suite.Run(t, new(InletNodeTestSuite))
+}
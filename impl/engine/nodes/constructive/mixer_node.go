@@ -0,0 +1,320 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/common/gdf"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/helper"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// MixerNode mixes two flows in a constant-area duct. Outlet state follows from
+// conservation of mass, energy and momentum (impulse function) of the flows,
+// so that mixed total pressure accounts for velocities of the inlet flows.
+// Friction on the duct walls is neglected
+type MixerNode interface {
+	graph.Node
+	MainInput() nodes.ComplexGasSink
+	ExtraInput() nodes.ComplexGasSink
+	Output() nodes.ComplexGasSource
+	// StaticPressureResidualOutput carries relative difference of static pressures of the inlet flows
+	// (pMain - pExtra) / pMain, which is zeroed by the equation system of off-design schemes
+	StaticPressureResidualOutput() graph.Port
+
+	MainArea() float64
+	ExtraArea() float64
+	// LambdaMainIn, LambdaExtraIn and LambdaOut are velocity coefficients of the inlet and outlet flows
+	LambdaMainIn() float64
+	LambdaExtraIn() float64
+	LambdaOut() float64
+	// PStaticMainIn and PStaticExtraIn are static pressures of the inlet flows.
+	// Their equality is usually required at the design point of the mixer (see NewDesignMixerNode)
+	PStaticMainIn() float64
+	PStaticExtraIn() float64
+	// Choked tells if the last call of Process failed because flow could not pass the mixer
+	Choked() bool
+}
+
+const staticPressureResidualOutputTag = "staticPressureResidualOutput"
+
+func NewMixerNode(mainArea, extraArea float64) MixerNode {
+	result := &mixerNode{
+		mainArea:  mainArea,
+		extraArea: extraArea,
+	}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.gInput, &result.tInput, &result.pInput, &result.mrInput,
+			&result.gEInput, &result.tEInput, &result.pEInput, &result.mrEInput,
+			&result.gOutput, &result.tOutput, &result.pOutput, &result.mrOutput,
+			&result.residualOutput,
+		},
+		[]string{
+			"gInput", "tInput", "pInput", "mrInput",
+			"gEInput", "tEInput", "pEInput", "mrEInput",
+			"gOutput", "tOutput", "pOutput", "mrOutput",
+			staticPressureResidualOutputTag,
+		},
+	)
+	return result
+}
+
+// NewDesignMixerNode returns mixer which inlet areas are calculated by Process from the inlet flows:
+// main flow enters the mixer with velocity coefficient lambdaMainIn and area of the extra flow
+// is such that static pressures of the flows are equal. Areas of the last call of Process
+// are to be used by the off-design mixer (NewMixerNode)
+func NewDesignMixerNode(lambdaMainIn float64) MixerNode {
+	var result = NewMixerNode(0, 0).(*mixerNode)
+	result.design = true
+	result.lambdaMainDesign = lambdaMainIn
+	return result
+}
+
+type mixerNode struct {
+	graph.BaseNode
+
+	mainArea  float64
+	extraArea float64
+
+	design           bool
+	lambdaMainDesign float64
+
+	lambdaMainIn   float64
+	lambdaExtraIn  float64
+	lambdaOut      float64
+	pStaticMainIn  float64
+	pStaticExtraIn float64
+	choked         bool
+
+	tInput  graph.Port
+	pInput  graph.Port
+	gInput  graph.Port
+	mrInput graph.Port
+
+	tEInput  graph.Port
+	pEInput  graph.Port
+	gEInput  graph.Port
+	mrEInput graph.Port
+
+	tOutput  graph.Port
+	pOutput  graph.Port
+	gOutput  graph.Port
+	mrOutput graph.Port
+
+	residualOutput graph.Port
+}
+
+// mixerFlow describes flow in one section of the mixer
+type mixerFlow struct {
+	gas      gases.Gas
+	tStag    float64
+	pStag    float64
+	massRate float64
+	area     float64
+}
+
+func (node *mixerNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "Mixer")
+}
+
+func (node *mixerNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+		node.gEInput, node.tEInput, node.pEInput, node.mrEInput,
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+		node.residualOutput,
+	}
+}
+
+func (node *mixerNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+		node.gEInput, node.tEInput, node.pEInput, node.mrEInput,
+	}, nil
+}
+
+func (node *mixerNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+		node.residualOutput,
+	}, nil
+}
+
+func (node *mixerNode) Process() error {
+	node.choked = false
+
+	main := mixerFlow{
+		gas:      node.gInput.GetState().Value().(gases.Gas),
+		tStag:    node.tInput.GetState().Value().(float64),
+		pStag:    node.pInput.GetState().Value().(float64),
+		massRate: node.mrInput.GetState().Value().(float64),
+		area:     node.mainArea,
+	}
+	extra := mixerFlow{
+		gas:      node.gEInput.GetState().Value().(gases.Gas),
+		tStag:    node.tEInput.GetState().Value().(float64),
+		pStag:    node.pEInput.GetState().Value().(float64),
+		massRate: node.mrEInput.GetState().Value().(float64),
+		area:     node.extraArea,
+	}
+
+	var err error
+	if node.design {
+		if err = node.setInletAreas(&main, &extra); err != nil {
+			return err
+		}
+	}
+	if node.lambdaMainIn, err = node.inletLambda(main); err != nil {
+		return err
+	}
+	if node.lambdaExtraIn, err = node.inletLambda(extra); err != nil {
+		return err
+	}
+	node.pStaticMainIn = main.pStag * gdf.Pi(node.lambdaMainIn, gases.K(main.gas, main.tStag))
+	node.pStaticExtraIn = extra.pStag * gdf.Pi(node.lambdaExtraIn, gases.K(extra.gas, extra.tStag))
+	node.residualOutput.SetState(graph.NewNumberPortState(
+		(node.pStaticMainIn - node.pStaticExtraIn) / node.pStaticMainIn,
+	))
+
+	massRateOut := main.massRate + extra.massRate
+	gasOut, err := gases.Mix(
+		[]gases.Gas{main.gas, extra.gas},
+		[]float64{main.massRate / massRateOut, extra.massRate / massRateOut},
+	)
+	if err != nil {
+		return err
+	}
+	hOut := (main.massRate*main.gas.H(main.tStag) + extra.massRate*extra.gas.H(extra.tStag)) / massRateOut
+	tOut, err := gases.TFromH(gasOut, hOut, (main.massRate*main.tStag+extra.massRate*extra.tStag)/massRateOut)
+	if err != nil {
+		return err
+	}
+	kOut := gases.K(gasOut, tOut)
+
+	// impulse functions of the flows are summed since wall pressure forces are absent
+	impulseOut := impulse(main, node.lambdaMainIn) + impulse(extra, node.lambdaExtraIn)
+	z := impulseOut * 2 * kOut / ((kOut + 1) * massRateOut * gdf.ACrit(kOut, gasOut.R(), tOut))
+	if z < 2 {
+		node.choked = true
+		return fmt.Errorf("mixed flow is choked: impulse function z = %f is less than critical", z)
+	}
+	node.lambdaOut = (z - math.Sqrt(z*z-4)) / 2
+
+	areaOut := main.area + extra.area
+	pOut := massRateOut * math.Sqrt(tOut) / (gdf.Q(node.lambdaOut, kOut, gasOut.R()) * areaOut)
+
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gasOut),
+			states.NewTemperaturePortState(tOut),
+			states.NewPressurePortState(pOut),
+			states.NewMassRatePortState(massRateOut),
+		},
+		[]graph.Port{
+			node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+		},
+	)
+	return nil
+}
+
+func (node *mixerNode) MainInput() nodes.ComplexGasSink {
+	return helper.NewPseudoComplexGasSink(
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+	)
+}
+
+func (node *mixerNode) ExtraInput() nodes.ComplexGasSink {
+	return helper.NewPseudoComplexGasSink(
+		node.gEInput, node.tEInput, node.pEInput, node.mrEInput,
+	)
+}
+
+func (node *mixerNode) Output() nodes.ComplexGasSource {
+	return helper.NewPseudoComplexGasSource(
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+	)
+}
+
+func (node *mixerNode) StaticPressureResidualOutput() graph.Port {
+	return node.residualOutput
+}
+
+func (node *mixerNode) MainArea() float64 {
+	return node.mainArea
+}
+
+func (node *mixerNode) ExtraArea() float64 {
+	return node.extraArea
+}
+
+func (node *mixerNode) LambdaMainIn() float64 {
+	return node.lambdaMainIn
+}
+
+func (node *mixerNode) LambdaExtraIn() float64 {
+	return node.lambdaExtraIn
+}
+
+func (node *mixerNode) LambdaOut() float64 {
+	return node.lambdaOut
+}
+
+func (node *mixerNode) PStaticMainIn() float64 {
+	return node.pStaticMainIn
+}
+
+func (node *mixerNode) PStaticExtraIn() float64 {
+	return node.pStaticExtraIn
+}
+
+func (node *mixerNode) Choked() bool {
+	return node.choked
+}
+
+// inletLambda returns subsonic velocity coefficient of the inlet flow
+// setInletAreas sizes inlets of the design mixer, so that static pressures of the flows are equal
+func (node *mixerNode) setInletAreas(main, extra *mixerFlow) error {
+	kMain := gases.K(main.gas, main.tStag)
+	pStatic := main.pStag * gdf.Pi(node.lambdaMainDesign, kMain)
+	kExtra := gases.K(extra.gas, extra.tStag)
+	if pStatic >= extra.pStag || pStatic < extra.pStag*gdf.PiCrit(kExtra) {
+		return fmt.Errorf(
+			"extra flow of stagnation pressure %f can not enter mixer subsonically at static pressure %f of the main flow",
+			extra.pStag, pStatic,
+		)
+	}
+
+	main.area = gdf.FlowArea(main.massRate, main.pStag, main.tStag, node.lambdaMainDesign, kMain, main.gas.R())
+	extraArea, err := gdf.ExitArea(extra.massRate, extra.pStag, extra.tStag, pStatic, kExtra, extra.gas.R())
+	if err != nil {
+		return err
+	}
+	extra.area = extraArea
+	node.mainArea, node.extraArea = main.area, extra.area
+	return nil
+}
+
+func (node *mixerNode) inletLambda(flow mixerFlow) (float64, error) {
+	k := gases.K(flow.gas, flow.tStag)
+	q := flow.massRate * math.Sqrt(flow.tStag) / (gdf.Q(1, k, flow.gas.R()) * flow.pStag * flow.area)
+	if q > 1 {
+		node.choked = true
+		return 0, fmt.Errorf("inlet area %f is not enough to pass mass rate %f", flow.area, flow.massRate)
+	}
+	return gdf.LambdaFromQ(q, k, gdf.Subsonic)
+}
+
+// impulse returns impulse function p * F + m * c of the flow
+func impulse(flow mixerFlow, lambda float64) float64 {
+	if lambda == 0 {
+		return flow.pStag * flow.area
+	}
+	k := gases.K(flow.gas, flow.tStag)
+	return (k + 1) / (2 * k) * flow.massRate * gdf.ACrit(k, flow.gas.R(), flow.tStag) * (lambda + 1/lambda)
+}
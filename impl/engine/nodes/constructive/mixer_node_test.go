@@ -0,0 +1,140 @@
+package constructive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common/gdf"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/suite"
+)
+
+type MixerNodeTestSuite struct {
+	suite.Suite
+	gas gases.Gas
+	k   float64
+}
+
+func (s *MixerNodeTestSuite) SetupTest() {
+	s.gas = gases.TestGas{CpVal: 1005, RVal: 287}
+	s.k = gases.K(s.gas, 0)
+}
+
+func (s *MixerNodeTestSuite) TestEqualFlows() {
+	var mixer = NewMixerNode(0.5, 0.25)
+	s.setInputs(mixer, 800, 3e5, 40, 800, 3e5, 20)
+	s.Require().Nil(mixer.Process())
+
+	// mixing of identical flows is lossless
+	s.InDelta(mixer.LambdaMainIn(), mixer.LambdaExtraIn(), 1e-9)
+	s.InDelta(mixer.LambdaMainIn(), mixer.LambdaOut(), 1e-9)
+	s.InDelta(3e5, s.pOut(mixer), 1e-3)
+	s.InDelta(800, s.tOut(mixer), 1e-6)
+	s.InDelta(60, mixer.Output().MassRateOutput().GetState().Value().(float64), 1e-9)
+	s.InDelta(0, mixer.StaticPressureResidualOutput().GetState().Value().(float64), 1e-9)
+	s.False(mixer.Choked())
+}
+
+func (s *MixerNodeTestSuite) TestConservation() {
+	var mixer = NewMixerNode(0.3, 0.5)
+	s.setInputs(mixer, 1100, 2.6e5, 30, 400, 2.5e5, 60)
+	s.Require().Nil(mixer.Process())
+
+	var tOut = s.tOut(mixer)
+	var pOut = s.pOut(mixer)
+	s.InDelta((30*1100+60*400)/90., tOut, 1e-6)
+
+	// impulse is conserved
+	var impulseFunc = func(massRate, t, lambda float64) float64 {
+		return (s.k + 1) / (2 * s.k) * massRate * gdf.ACrit(s.k, s.gas.R(), t) * (lambda + 1/lambda)
+	}
+	var impulseIn = impulseFunc(30, 1100, mixer.LambdaMainIn()) + impulseFunc(60, 400, mixer.LambdaExtraIn())
+	s.InDelta(impulseIn, impulseFunc(90, tOut, mixer.LambdaOut()), 1e-6*impulseIn)
+
+	// continuity
+	var massRate = gdf.Q(mixer.LambdaOut(), s.k, s.gas.R()) * pOut * 0.8 / math.Sqrt(tOut)
+	s.InDelta(90, massRate, 1e-6)
+
+	// mixing is irreversible
+	var entropy = func(massRate, t, p float64) float64 {
+		return massRate * (s.gas.Cp(t)*math.Log(t) - s.gas.R()*math.Log(p))
+	}
+	s.True(entropy(90, tOut, pOut) > entropy(30, 1100, 2.6e5)+entropy(60, 400, 2.5e5))
+	s.True(pOut < 2.6e5, "%f", pOut)
+}
+
+func (s *MixerNodeTestSuite) TestEjector() {
+	// high velocity primary flow entrains low pressure secondary flow
+	var mixer = NewMixerNode(0.011, 0.2)
+	s.setInputs(mixer, 300, 2e5, 5, 300, 1e5, 5)
+	s.Require().Nil(mixer.Process())
+
+	s.True(mixer.LambdaMainIn() > 0.8)
+	s.True(s.pOut(mixer) > 1e5, "%f", s.pOut(mixer))
+	s.True(s.pOut(mixer) < 2e5, "%f", s.pOut(mixer))
+}
+
+func (s *MixerNodeTestSuite) TestChoking() {
+	var mixer = NewMixerNode(0.01, 0.5)
+	s.setInputs(mixer, 800, 3e5, 40, 800, 3e5, 20)
+	s.NotNil(mixer.Process())
+	s.True(mixer.Choked())
+
+	mixer = NewMixerNode(0.5, 0.25)
+	s.setInputs(mixer, 800, 3e5, 40, 800, 3e5, 20)
+	s.Nil(mixer.Process())
+	s.False(mixer.Choked())
+}
+
+func (s *MixerNodeTestSuite) TestDesign() {
+	var design = NewDesignMixerNode(0.4)
+	s.setInputs(design, 1100, 2.6e5, 30, 400, 2.5e5, 60)
+	s.Require().Nil(design.Process())
+
+	// extra inlet is sized for equal static pressures
+	s.InDelta(0.4, design.LambdaMainIn(), 1e-9)
+	s.InDelta(design.PStaticMainIn(), design.PStaticExtraIn(), 1e-6*design.PStaticMainIn())
+	s.InDelta(0, design.StaticPressureResidualOutput().GetState().Value().(float64), 1e-9)
+
+	// off-design mixer of the design areas reproduces the design point
+	var mixer = NewMixerNode(design.MainArea(), design.ExtraArea())
+	s.setInputs(mixer, 1100, 2.6e5, 30, 400, 2.5e5, 60)
+	s.Require().Nil(mixer.Process())
+	s.InDelta(s.pOut(design), s.pOut(mixer), 1e-6*s.pOut(design))
+	s.InDelta(design.LambdaExtraIn(), mixer.LambdaExtraIn(), 1e-9)
+
+	// extra flow can not reach static pressure of the main flow
+	s.setInputs(design, 1100, 2.6e5, 30, 400, 2e5, 60)
+	s.NotNil(design.Process())
+}
+
+func (s *MixerNodeTestSuite) setInputs(mixer MixerNode, t, p, massRate, tExtra, pExtra, massRateExtra float64) {
+	var main = mixer.MainInput()
+	var extra = mixer.ExtraInput()
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(s.gas), states.NewTemperaturePortState(t),
+			states.NewPressurePortState(p), states.NewMassRatePortState(massRate),
+			states.NewGasPortState(s.gas), states.NewTemperaturePortState(tExtra),
+			states.NewPressurePortState(pExtra), states.NewMassRatePortState(massRateExtra),
+		},
+		[]graph.Port{
+			main.GasInput(), main.TemperatureInput(), main.PressureInput(), main.MassRateInput(),
+			extra.GasInput(), extra.TemperatureInput(), extra.PressureInput(), extra.MassRateInput(),
+		},
+	)
+}
+
+func (s *MixerNodeTestSuite) tOut(mixer MixerNode) float64 {
+	return mixer.Output().TemperatureOutput().GetState().Value().(float64)
+}
+
+func (s *MixerNodeTestSuite) pOut(mixer MixerNode) float64 {
+	return mixer.Output().PressureOutput().GetState().Value().(float64)
+}
+
+func TestMixerNodeTestSuite(t *testing.T) {
+	suite.Run(t, new(MixerNodeTestSuite))
+}
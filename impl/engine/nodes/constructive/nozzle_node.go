@@ -0,0 +1,456 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/common/gdf"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+type NozzleType int
+
+const (
+	ConvergentNozzle NozzleType = iota
+	ConvergentDivergentNozzle
+)
+
+const (
+	ambientPressureInputTag   = "ambientPressureInput"
+	thrustOutputTag           = "thrustOutput"
+	massRateResidualOutputTag = "massRateResidualOutput"
+
+	nozzlePrecision = 1e-10
+	nozzleIterLimit = 100
+)
+
+func (t NozzleType) String() string {
+	if t == ConvergentDivergentNozzle {
+		return "convergent-divergent"
+	}
+	return "convergent"
+}
+
+// NozzleNode expands gas to the ambient pressure and produces jet thrust.
+// Velocity coefficient phi accounts for friction losses (actual to ideal exit velocity ratio),
+// discharge coefficient mu is the ratio of effective to geometric throat area
+type NozzleNode interface {
+	graph.Node
+	nodes.ComplexGasChannel
+	AmbientPressureInput() graph.Port
+	// ThrustOutput carries gross thrust of the nozzle
+	ThrustOutput() graph.Port
+
+	Type() NozzleType
+	Phi() float64
+	Mu() float64
+
+	// ThroatArea is geometric area of the throat (exit area for convergent nozzle)
+	ThroatArea() float64
+	ExitArea() float64
+
+	Choked() bool
+	LambdaOut() float64
+	PStaticOut() float64
+	TStaticOut() float64
+	COut() float64
+	GrossThrust() float64
+}
+
+// ParametricNozzleNode is a nozzle with fixed geometry. Mass rate it can pass at the
+// inlet conditions generally differs from the inlet mass rate, the relative difference
+// is provided by MassRateResidualOutput to be zeroed by the equation system of the scheme
+type ParametricNozzleNode interface {
+	NozzleNode
+	MassRateResidualOutput() graph.Port
+	// MassRateCapacity is the mass rate which nozzle passes at the inlet conditions
+	MassRateCapacity() float64
+	// SetThroatArea sets throat area of the variable area nozzle. Exit area
+	// of convergent-divergent nozzle is changed so that its area ratio is kept
+	SetThroatArea(throatArea float64)
+}
+
+// NewNozzleNode returns nozzle sized for the inlet conditions. Convergent-divergent
+// nozzle fully expands gas to the ambient pressure; if ambient pressure is above critical,
+// it degenerates to the convergent one
+func NewNozzleNode(nozzleType NozzleType, phi, mu float64) NozzleNode {
+	var result = &nozzleNode{
+		nozzleType: nozzleType,
+		phi:        phi,
+		mu:         mu,
+	}
+	result.attachPorts(result)
+	return result
+}
+
+func NewParametricNozzleNodeFromProto(proto NozzleNode) ParametricNozzleNode {
+	return NewParametricNozzleNode(proto.Type(), proto.Phi(), proto.Mu(), proto.ThroatArea(), proto.ExitArea())
+}
+
+// NewParametricNozzleNode returns nozzle with geometric throat area and exit area.
+// Exit area of convergent nozzle is ignored
+func NewParametricNozzleNode(
+	nozzleType NozzleType, phi, mu, throatArea, exitArea float64,
+) ParametricNozzleNode {
+	if nozzleType == ConvergentNozzle {
+		exitArea = throatArea
+	}
+	var result = &parametricNozzleNode{
+		nozzleNode: nozzleNode{
+			nozzleType: nozzleType,
+			phi:        phi,
+			mu:         mu,
+			throatArea: throatArea,
+			exitArea:   exitArea,
+		},
+	}
+	result.attachPorts(result)
+	result.massRateResidualOutput = graph.NewAttachedPortWithTag(result, massRateResidualOutputTag)
+	return result
+}
+
+type nozzleNode struct {
+	graph.BaseNode
+
+	gasInput             graph.Port
+	temperatureInput     graph.Port
+	pressureInput        graph.Port
+	massRateInput        graph.Port
+	ambientPressureInput graph.Port
+
+	gasOutput         graph.Port
+	temperatureOutput graph.Port
+	pressureOutput    graph.Port
+	massRateOutput    graph.Port
+	thrustOutput      graph.Port
+
+	nozzleType NozzleType
+	phi        float64
+	mu         float64
+
+	throatArea float64
+	exitArea   float64
+
+	choked      bool
+	lambdaOut   float64
+	pStaticOut  float64
+	tStaticOut  float64
+	cOut        float64
+	grossThrust float64
+}
+
+// nozzleFlow describes flow in the exit section of the nozzle
+type nozzleFlow struct {
+	choked bool
+	lambda float64
+	// pStag is stagnation pressure at the exit, it is reduced by shock inside the nozzle
+	pStag float64
+}
+
+func (node *nozzleNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "Nozzle")
+}
+
+func (node *nozzleNode) Process() error {
+	var gas, tStag, pStag, massRate, pAmb = node.inlet()
+	var k = gases.K(gas, tStag)
+
+	if pAmb >= pStag {
+		return fmt.Errorf("ambient pressure %f is not less than nozzle inlet pressure %f", pAmb, pStag)
+	}
+
+	var lambda = 1.
+	var piAmb = pAmb / pStag
+	var choked = piAmb <= gdf.PiCrit(k)
+	if !choked {
+		lambda, _ = gdf.LambdaFromPi(piAmb, k)
+	}
+
+	var massFactor = massRate * math.Sqrt(tStag) / pStag
+	node.throatArea = massFactor / (node.mu * gdf.Q(lambda, k, gas.R()))
+	node.exitArea = node.throatArea
+
+	var flow = nozzleFlow{choked: choked, lambda: lambda, pStag: pStag}
+	if node.nozzleType == ConvergentDivergentNozzle && choked {
+		flow.lambda, _ = gdf.LambdaFromPi(piAmb, k)
+		node.exitArea = massFactor / gdf.Q(flow.lambda, k, gas.R())
+	}
+
+	node.setOutputs(gas, tStag, massRate, pAmb, flow)
+	return nil
+}
+
+func (node *nozzleNode) Type() NozzleType {
+	return node.nozzleType
+}
+
+func (node *nozzleNode) Phi() float64 {
+	return node.phi
+}
+
+func (node *nozzleNode) Mu() float64 {
+	return node.mu
+}
+
+func (node *nozzleNode) ThroatArea() float64 {
+	return node.throatArea
+}
+
+func (node *nozzleNode) ExitArea() float64 {
+	return node.exitArea
+}
+
+func (node *nozzleNode) Choked() bool {
+	return node.choked
+}
+
+func (node *nozzleNode) LambdaOut() float64 {
+	return node.lambdaOut
+}
+
+func (node *nozzleNode) PStaticOut() float64 {
+	return node.pStaticOut
+}
+
+func (node *nozzleNode) TStaticOut() float64 {
+	return node.tStaticOut
+}
+
+func (node *nozzleNode) COut() float64 {
+	return node.cOut
+}
+
+func (node *nozzleNode) GrossThrust() float64 {
+	return node.grossThrust
+}
+
+func (node *nozzleNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput, node.ambientPressureInput,
+	}, nil
+}
+
+func (node *nozzleNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.thrustOutput,
+	}, nil
+}
+
+func (node *nozzleNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.gasInput, node.temperatureInput, node.pressureInput, node.massRateInput, node.ambientPressureInput,
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.thrustOutput,
+	}
+}
+
+func (node *nozzleNode) GasInput() graph.Port {
+	return node.gasInput
+}
+
+func (node *nozzleNode) TemperatureInput() graph.Port {
+	return node.temperatureInput
+}
+
+func (node *nozzleNode) PressureInput() graph.Port {
+	return node.pressureInput
+}
+
+func (node *nozzleNode) MassRateInput() graph.Port {
+	return node.massRateInput
+}
+
+func (node *nozzleNode) AmbientPressureInput() graph.Port {
+	return node.ambientPressureInput
+}
+
+func (node *nozzleNode) GasOutput() graph.Port {
+	return node.gasOutput
+}
+
+func (node *nozzleNode) TemperatureOutput() graph.Port {
+	return node.temperatureOutput
+}
+
+func (node *nozzleNode) PressureOutput() graph.Port {
+	return node.pressureOutput
+}
+
+func (node *nozzleNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *nozzleNode) ThrustOutput() graph.Port {
+	return node.thrustOutput
+}
+
+func (node *nozzleNode) attachPorts(owner graph.Node) {
+	graph.AttachAllWithTags(
+		owner,
+		[]*graph.Port{
+			&node.gasInput, &node.temperatureInput, &node.pressureInput, &node.massRateInput,
+			&node.ambientPressureInput,
+			&node.gasOutput, &node.temperatureOutput, &node.pressureOutput, &node.massRateOutput,
+			&node.thrustOutput,
+		},
+		[]string{
+			nodes.GasInputTag, nodes.TemperatureInputTag, nodes.PressureInputTag, nodes.MassRateInputTag,
+			ambientPressureInputTag,
+			nodes.GasOutputTag, nodes.TemperatureOutputTag, nodes.PressureOutputTag, nodes.MassRateOutputTag,
+			thrustOutputTag,
+		},
+	)
+}
+
+func (node *nozzleNode) inlet() (gas gases.Gas, tStag, pStag, massRate, pAmb float64) {
+	return node.gasInput.GetState().Value().(gases.Gas),
+		node.temperatureInput.GetState().Value().(float64),
+		node.pressureInput.GetState().Value().(float64),
+		node.massRateInput.GetState().Value().(float64),
+		node.ambientPressureInput.GetState().Value().(float64)
+}
+
+// setOutputs calculates exit parameters and thrust of the nozzle for exit flow
+func (node *nozzleNode) setOutputs(gas gases.Gas, tStag, massRate, pAmb float64, flow nozzleFlow) {
+	var k = gases.K(gas, tStag)
+	var cIdeal = flow.lambda * gdf.ACrit(k, gas.R(), tStag)
+
+	node.choked = flow.choked
+	node.lambdaOut = flow.lambda
+	node.pStaticOut = flow.pStag * gdf.Pi(flow.lambda, k)
+	node.cOut = node.phi * cIdeal
+	node.tStaticOut = tStag - node.cOut*node.cOut*(k-1)/(2*k*gas.R())
+	node.grossThrust = massRate*node.cOut + (node.pStaticOut-pAmb)*node.exitArea
+
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gas),
+			states.NewTemperaturePortState(tStag),
+			states.NewPressurePortState(flow.pStag),
+			states.NewMassRatePortState(massRate),
+			graph.NewNumberPortState(node.grossThrust),
+		},
+		[]graph.Port{
+			node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput, node.thrustOutput,
+		},
+	)
+}
+
+type parametricNozzleNode struct {
+	nozzleNode
+
+	massRateResidualOutput graph.Port
+	massRateCapacity       float64
+}
+
+func (node *parametricNozzleNode) Process() error {
+	var gas, tStag, pStag, massRate, pAmb = node.inlet()
+	var k = gases.K(gas, tStag)
+
+	if pAmb >= pStag {
+		return fmt.Errorf("ambient pressure %f is not less than nozzle inlet pressure %f", pAmb, pStag)
+	}
+
+	var flow, err = node.exitFlow(k, pStag, pAmb)
+	if err != nil {
+		return err
+	}
+
+	var throatLambda = 1.
+	if !flow.choked {
+		// subsonic flow has the same mass flow function in the throat and the exit sections
+		var exitArea = node.effectiveThroatArea()
+		if node.nozzleType == ConvergentDivergentNozzle {
+			exitArea = node.exitArea
+		}
+		throatLambda, err = gdf.LambdaFromQ(gdf.QNorm(flow.lambda, k)*exitArea/node.effectiveThroatArea(), k, gdf.Subsonic)
+		if err != nil {
+			return err
+		}
+	}
+	node.massRateCapacity = node.effectiveThroatArea() * gdf.Q(throatLambda, k, gas.R()) * pStag / math.Sqrt(tStag)
+
+	node.setOutputs(gas, tStag, massRate, pAmb, flow)
+	node.massRateResidualOutput.SetState(graph.NewNumberPortState(
+		(massRate - node.massRateCapacity) / node.massRateCapacity,
+	))
+	return nil
+}
+
+func (node *parametricNozzleNode) MassRateResidualOutput() graph.Port {
+	return node.massRateResidualOutput
+}
+
+func (node *parametricNozzleNode) MassRateCapacity() float64 {
+	return node.massRateCapacity
+}
+
+func (node *parametricNozzleNode) SetThroatArea(throatArea float64) {
+	node.exitArea *= throatArea / node.throatArea
+	node.throatArea = throatArea
+}
+
+func (node *parametricNozzleNode) GetUpdatePorts() ([]graph.Port, error) {
+	var ports, _ = node.nozzleNode.GetUpdatePorts()
+	return append(ports, node.massRateResidualOutput), nil
+}
+
+func (node *parametricNozzleNode) GetPorts() []graph.Port {
+	return append(node.nozzleNode.GetPorts(), node.massRateResidualOutput)
+}
+
+func (node *parametricNozzleNode) effectiveThroatArea() float64 {
+	return node.mu * node.throatArea
+}
+
+// exitFlow returns flow in the exit section of the nozzle with fixed geometry
+func (node *parametricNozzleNode) exitFlow(k, pStag, pAmb float64) (nozzleFlow, error) {
+	var piAmb = pAmb / pStag
+	var areaRatio = 1.
+	if node.nozzleType == ConvergentDivergentNozzle {
+		areaRatio = node.exitArea / node.effectiveThroatArea()
+	}
+
+	// ambient pressure ratio at which flow in the throat becomes critical
+	var piChoke = gdf.PiCrit(k)
+	if areaRatio > 1 {
+		var lambdaSub, err = gdf.LambdaFromAreaRatio(areaRatio, k, gdf.Subsonic)
+		if err != nil {
+			return nozzleFlow{}, err
+		}
+		piChoke = gdf.Pi(lambdaSub, k)
+	}
+
+	if piAmb >= piChoke {
+		var lambda, err = gdf.LambdaFromPi(piAmb, k)
+		return nozzleFlow{lambda: lambda, pStag: pStag}, err
+	}
+	if areaRatio <= 1 {
+		return nozzleFlow{choked: true, lambda: 1, pStag: pStag}, nil
+	}
+
+	var lambdaSuper, err = gdf.LambdaFromAreaRatio(areaRatio, k, gdf.Supersonic)
+	if err != nil {
+		return nozzleFlow{}, err
+	}
+	var shock, _ = gdf.NormalShock(gdf.Mach(lambdaSuper, k), k)
+	if piAmb <= gdf.Pi(lambdaSuper, k)*shock.PRatio {
+		// shock (if any) stands outside the nozzle
+		return nozzleFlow{choked: true, lambda: lambdaSuper, pStag: pStag}, nil
+	}
+
+	// shock stands inside the divergent part, so that subsonic exit flow has ambient static pressure.
+	// Mass rate is conserved: pStag * F_throat = pStagExit * q(lambda) * F_exit, pStagExit * pi(lambda) = pAmb
+	var target = pStag / (areaRatio * pAmb)
+	report, err := root.NewBrentSolver(nozzlePrecision, nozzleIterLimit).Solve(func(lambda float64) (float64, error) {
+		return gdf.QNorm(lambda, k)/gdf.Pi(lambda, k) - target, nil
+	}, 0, 1)
+	if err != nil {
+		return nozzleFlow{}, err
+	}
+	return nozzleFlow{choked: true, lambda: report.X, pStag: pAmb / gdf.Pi(report.X, k)}, nil
+}
@@ -0,0 +1,174 @@
+package constructive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common/gdf"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/suite"
+)
+
+type NozzleNodeTestSuite struct {
+	suite.Suite
+	gas gases.Gas
+	k   float64
+}
+
+func (s *NozzleNodeTestSuite) SetupTest() {
+	s.gas = gases.TestGas{CpVal: 1150, RVal: 287}
+	s.k = gases.K(s.gas, 0)
+}
+
+func (s *NozzleNodeTestSuite) TestConvergent_Subcritical() {
+	var nozzle = NewNozzleNode(ConvergentNozzle, 1, 1)
+	s.setInputs(nozzle, 800, 1.5e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+
+	s.False(nozzle.Choked())
+	s.InDelta(1e5, nozzle.PStaticOut(), 1e-6)
+	var cIdeal = math.Sqrt(2 * s.gas.Cp(0) * 800 * (1 - math.Pow(1/1.5, (s.k-1)/s.k)))
+	s.InDelta(cIdeal, nozzle.COut(), 1e-6*cIdeal)
+	s.InDelta(20*cIdeal, nozzle.GrossThrust(), 1e-6*20*cIdeal)
+	s.InDelta(20*cIdeal, nozzle.ThrustOutput().GetState().Value().(float64), 1e-6*20*cIdeal)
+
+	// continuity in the exit section
+	var rho = nozzle.PStaticOut() / (s.gas.R() * nozzle.TStaticOut())
+	s.InDelta(20, rho*nozzle.COut()*nozzle.ExitArea(), 1e-6)
+}
+
+func (s *NozzleNodeTestSuite) TestConvergent_Choked() {
+	var nozzle = NewNozzleNode(ConvergentNozzle, 0.98, 0.95)
+	s.setInputs(nozzle, 900, 4e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+
+	s.True(nozzle.Choked())
+	s.InDelta(1, nozzle.LambdaOut(), 1e-12)
+	s.InDelta(4e5*gdf.PiCrit(s.k), nozzle.PStaticOut(), 1e-6)
+
+	// underexpanded jet produces pressure thrust
+	var momentum = 20 * nozzle.COut()
+	s.True(nozzle.GrossThrust() > momentum)
+	s.InDelta(momentum+(nozzle.PStaticOut()-1e5)*nozzle.ExitArea(), nozzle.GrossThrust(), 1e-6)
+	s.InDelta(gdf.ThroatArea(20, 4e5, 900, s.k, s.gas.R())/0.95, nozzle.ThroatArea(), 1e-9)
+}
+
+func (s *NozzleNodeTestSuite) TestConvergentDivergent() {
+	var convergent = NewNozzleNode(ConvergentNozzle, 1, 1)
+	s.setInputs(convergent, 900, 4e5, 20, 1e5)
+	s.Require().Nil(convergent.Process())
+
+	var nozzle = NewNozzleNode(ConvergentDivergentNozzle, 1, 1)
+	s.setInputs(nozzle, 900, 4e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+
+	s.True(nozzle.LambdaOut() > 1)
+	s.InDelta(1e5, nozzle.PStaticOut(), 1e-6)
+	s.True(nozzle.ExitArea() > nozzle.ThroatArea())
+	s.InDelta(convergent.ThroatArea(), nozzle.ThroatArea(), 1e-9)
+	// full expansion gives maximal thrust
+	s.True(nozzle.GrossThrust() > convergent.GrossThrust())
+}
+
+func (s *NozzleNodeTestSuite) TestParametric_DesignPoint() {
+	for _, nozzleType := range []NozzleType{ConvergentNozzle, ConvergentDivergentNozzle} {
+		var design = NewNozzleNode(nozzleType, 0.98, 0.96)
+		s.setInputs(design, 900, 4e5, 20, 1e5)
+		s.Require().Nil(design.Process())
+
+		var nozzle = NewParametricNozzleNodeFromProto(design)
+		s.setInputs(nozzle, 900, 4e5, 20, 1e5)
+		s.Require().Nil(nozzle.Process())
+
+		s.InDelta(0, nozzle.MassRateResidualOutput().GetState().Value().(float64), 1e-8, nozzleType.String())
+		s.InDelta(design.GrossThrust(), nozzle.GrossThrust(), 1e-6, nozzleType.String())
+		s.InDelta(design.PStaticOut(), nozzle.PStaticOut(), 1e-3, nozzleType.String())
+	}
+}
+
+func (s *NozzleNodeTestSuite) TestParametric_OffDesign() {
+	var nozzle = NewParametricNozzleNode(ConvergentNozzle, 1, 1, 0.05, 0)
+
+	// choked nozzle capacity is proportional to inlet pressure
+	s.setInputs(nozzle, 900, 4e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+	var capacity = nozzle.MassRateCapacity()
+	s.setInputs(nozzle, 900, 6e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+	s.InDelta(1.5*capacity, nozzle.MassRateCapacity(), 1e-9)
+	s.InDelta((20-1.5*capacity)/(1.5*capacity), nozzle.MassRateResidualOutput().GetState().Value().(float64), 1e-9)
+
+	// unchoked nozzle passes less than critical mass rate
+	s.setInputs(nozzle, 900, 1.2e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+	s.False(nozzle.Choked())
+	s.True(nozzle.MassRateCapacity() < capacity*1.2/4)
+}
+
+func (s *NozzleNodeTestSuite) TestParametric_VariableArea() {
+	var nozzle = NewParametricNozzleNode(ConvergentDivergentNozzle, 1, 0.96, 0.05, 0.08)
+	s.setInputs(nozzle, 900, 4e5, 20, 1e5)
+	s.Require().Nil(nozzle.Process())
+	var capacity = nozzle.MassRateCapacity()
+	var lambdaOut = nozzle.LambdaOut()
+
+	nozzle.SetThroatArea(0.06)
+	s.Require().Nil(nozzle.Process())
+	s.InDelta(0.06, nozzle.ThroatArea(), 1e-12)
+	s.InDelta(0.08*0.06/0.05, nozzle.ExitArea(), 1e-12)
+	// choked nozzle capacity is proportional to throat area, exit flow is the same since area ratio is kept
+	s.InDelta(capacity*0.06/0.05, nozzle.MassRateCapacity(), 1e-9)
+	s.InDelta(lambdaOut, nozzle.LambdaOut(), 1e-9)
+}
+
+func (s *NozzleNodeTestSuite) TestParametric_ConvergentDivergentRegimes() {
+	var areaRatio = 2.
+	var nozzle = NewParametricNozzleNode(ConvergentDivergentNozzle, 1, 1, 0.05, 0.05*areaRatio)
+	var lambdaSuper, _ = gdf.LambdaFromAreaRatio(areaRatio, s.k, gdf.Supersonic)
+	var lambdaSub, _ = gdf.LambdaFromAreaRatio(areaRatio, s.k, gdf.Subsonic)
+	var pStag = 10e5
+
+	// design back pressure
+	s.setInputs(nozzle, 900, pStag, 20, pStag*gdf.Pi(lambdaSuper, s.k))
+	s.Require().Nil(nozzle.Process())
+	s.True(nozzle.Choked())
+	s.InDelta(lambdaSuper, nozzle.LambdaOut(), 1e-9)
+	var criticalCapacity = nozzle.MassRateCapacity()
+
+	// shock inside the divergent part
+	var shock, _ = gdf.NormalShock(gdf.Mach(lambdaSuper, s.k), s.k)
+	var pAmb = pStag * (gdf.Pi(lambdaSub, s.k) + gdf.Pi(lambdaSuper, s.k)*shock.PRatio) / 2
+	s.setInputs(nozzle, 900, pStag, 20, pAmb)
+	s.Require().Nil(nozzle.Process())
+	s.True(nozzle.Choked())
+	s.True(nozzle.LambdaOut() < 1)
+	s.InDelta(pAmb, nozzle.PStaticOut(), 1e-3)
+	s.InDelta(criticalCapacity, nozzle.MassRateCapacity(), 1e-9)
+	s.True(nozzle.PressureOutput().GetState().Value().(float64) < pStag)
+
+	// subsonic flow everywhere
+	s.setInputs(nozzle, 900, pStag, 20, pStag*(1+gdf.Pi(lambdaSub, s.k))/2)
+	s.Require().Nil(nozzle.Process())
+	s.False(nozzle.Choked())
+	s.True(nozzle.MassRateCapacity() < criticalCapacity)
+}
+
+func (s *NozzleNodeTestSuite) TestErrors() {
+	var nozzle = NewNozzleNode(ConvergentNozzle, 1, 1)
+	s.setInputs(nozzle, 900, 1e5, 20, 1.1e5)
+	s.NotNil(nozzle.Process())
+}
+
+func (s *NozzleNodeTestSuite) setInputs(nozzle NozzleNode, t, p, massRate, pAmb float64) {
+	setComplexInput(nozzle, s.gas, t, p, massRate)
+	graph.SetAll(
+		[]graph.PortState{states.NewPressurePortState(pAmb)},
+		[]graph.Port{nozzle.AmbientPressureInput()},
+	)
+}
+
+func TestNozzleNodeTestSuite(t *testing.T) {
+	suite.Run(t, new(NozzleNodeTestSuite))
+}
@@ -0,0 +1,221 @@
+package constructive
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/helper"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// PressureSplitter is a gas splitter which branches have flow resistances.
+// Outlet pressure of each branch depends on the mass rate it passes, so that split
+// fraction (ExtraWeight) can be found from the pressures downstream of the branches
+// either by an outer equation system or directly by SolveExtraWeight
+type PressureSplitter interface {
+	GasSplitter
+	// MainResistance is nil if main branch has no pressure loss
+	MainResistance() FlowResistance
+	ExtraResistance() FlowResistance
+	// MainPressureDrop and ExtraPressureDrop are stagnation pressure losses of the branches
+	MainPressureDrop() float64
+	ExtraPressureDrop() float64
+	// SolveExtraWeight sets split fraction at which extra branch discharges to pressure pExtraOut
+	SolveExtraWeight(pExtraOut float64) error
+}
+
+func NewPressureSplitter(weight float64, mainResistance, extraResistance FlowResistance) PressureSplitter {
+	result := &pressureSplitter{
+		weight:          weight,
+		mainResistance:  mainResistance,
+		extraResistance: extraResistance,
+	}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.gInput, &result.tInput, &result.pInput, &result.mrInput,
+			&result.gOutput, &result.tOutput, &result.pOutput, &result.mrOutput,
+			&result.gEOutput, &result.tEOutput, &result.pEOutput, &result.mrEOutput,
+		},
+		[]string{
+			"gInput", "tInput", "pInput", "mrInput",
+			"gOutput", "tOutput", "pOutput", "mrOutput",
+			"gEOutput", "tEOutput", "pEOutput", "mrEOutput",
+		},
+	)
+	return result
+}
+
+type pressureSplitter struct {
+	graph.BaseNode
+
+	weight          float64
+	mainResistance  FlowResistance
+	extraResistance FlowResistance
+
+	mainPressureDrop  float64
+	extraPressureDrop float64
+
+	tInput  graph.Port
+	pInput  graph.Port
+	gInput  graph.Port
+	mrInput graph.Port
+
+	tOutput  graph.Port
+	pOutput  graph.Port
+	gOutput  graph.Port
+	mrOutput graph.Port
+
+	tEOutput  graph.Port
+	pEOutput  graph.Port
+	gEOutput  graph.Port
+	mrEOutput graph.Port
+}
+
+func (node *pressureSplitter) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "PressureSplitter")
+}
+
+func (node *pressureSplitter) Process() error {
+	gas := node.gInput.GetState().Value().(gases.Gas)
+	t := node.tInput.GetState().Value().(float64)
+	p := node.pInput.GetState().Value().(float64)
+	imr := node.mrInput.GetState().Value().(float64)
+
+	mainP, err := node.branchPOut(node.mainResistance, gas, t, p, imr*(1-node.weight))
+	if err != nil {
+		return fmt.Errorf("main branch: %s", err.Error())
+	}
+	extraP, err := node.branchPOut(node.extraResistance, gas, t, p, imr*node.weight)
+	if err != nil {
+		return fmt.Errorf("extra branch: %s", err.Error())
+	}
+	node.mainPressureDrop = p - mainP
+	node.extraPressureDrop = p - extraP
+
+	graph.SetAll(
+		[]graph.PortState{
+			node.gInput.GetState(),
+			node.tInput.GetState(),
+			states.NewPressurePortState(mainP),
+			states.NewMassRatePortState(imr * (1 - node.weight)),
+		},
+		[]graph.Port{
+			node.gOutput,
+			node.tOutput,
+			node.pOutput,
+			node.mrOutput,
+		},
+	)
+
+	graph.SetAll(
+		[]graph.PortState{
+			node.gInput.GetState(),
+			node.tInput.GetState(),
+			states.NewPressurePortState(extraP),
+			states.NewMassRatePortState(imr * node.weight),
+		},
+		[]graph.Port{
+			node.gEOutput,
+			node.tEOutput,
+			node.pEOutput,
+			node.mrEOutput,
+		},
+	)
+	return nil
+}
+
+func (node *pressureSplitter) SolveExtraWeight(pExtraOut float64) error {
+	gas := node.gInput.GetState().Value().(gases.Gas)
+	t := node.tInput.GetState().Value().(float64)
+	p := node.pInput.GetState().Value().(float64)
+	imr := node.mrInput.GetState().Value().(float64)
+
+	if node.extraResistance == nil {
+		return fmt.Errorf("split of the flow without extra branch resistance is not defined by pressure")
+	}
+	massRate, err := node.extraResistance.MassRate(gas, t, p, pExtraOut)
+	if err != nil {
+		return err
+	}
+	if massRate > imr {
+		return fmt.Errorf("extra branch mass rate %f exceeds inlet mass rate %f", massRate, imr)
+	}
+	node.weight = massRate / imr
+	return nil
+}
+
+func (node *pressureSplitter) SetExtraWeight(weight float64) {
+	node.weight = weight
+}
+
+func (node *pressureSplitter) ExtraWeight() float64 {
+	return node.weight
+}
+
+func (node *pressureSplitter) MainResistance() FlowResistance {
+	return node.mainResistance
+}
+
+func (node *pressureSplitter) ExtraResistance() FlowResistance {
+	return node.extraResistance
+}
+
+func (node *pressureSplitter) MainPressureDrop() float64 {
+	return node.mainPressureDrop
+}
+
+func (node *pressureSplitter) ExtraPressureDrop() float64 {
+	return node.extraPressureDrop
+}
+
+func (node *pressureSplitter) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+	}, nil
+}
+
+func (node *pressureSplitter) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+		node.gEOutput, node.tEOutput, node.pEOutput, node.mrEOutput,
+	}, nil
+}
+
+func (node *pressureSplitter) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+		node.gEOutput, node.tEOutput, node.pEOutput, node.mrEOutput,
+	}
+}
+
+func (node *pressureSplitter) Input() nodes.ComplexGasSink {
+	return helper.NewPseudoComplexGasSink(
+		node.gInput, node.tInput, node.pInput, node.mrInput,
+	)
+}
+
+func (node *pressureSplitter) MainOutput() nodes.ComplexGasSource {
+	return helper.NewPseudoComplexGasSource(
+		node.gOutput, node.tOutput, node.pOutput, node.mrOutput,
+	)
+}
+
+func (node *pressureSplitter) ExtraOutput() nodes.ComplexGasSource {
+	return helper.NewPseudoComplexGasSource(
+		node.gEOutput, node.tEOutput, node.pEOutput, node.mrEOutput,
+	)
+}
+
+func (node *pressureSplitter) branchPOut(
+	resistance FlowResistance, gas gases.Gas, t, p, massRate float64,
+) (float64, error) {
+	if resistance == nil {
+		return p, nil
+	}
+	return resistance.POut(gas, t, p, massRate)
+}
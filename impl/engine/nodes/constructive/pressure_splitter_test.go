@@ -0,0 +1,91 @@
+package constructive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrificeResistance(t *testing.T) {
+	var gas = gases.GetAir()
+	var orifice = NewOrificeResistance(1e-3)
+
+	var massRate, err = orifice.MassRate(gas, 700, 2e6, 1.8e6)
+	assert.Nil(t, err)
+	pOut, err := orifice.POut(gas, 700, 2e6, massRate)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.8e6, pOut, 1e-3)
+
+	// incompressible limit
+	var rho = 2e6 / (gas.R() * 700)
+	massRate, err = orifice.MassRate(gas, 700, 2e6, 2e6-1e2)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1e-3*math.Sqrt(2*rho*1e2), massRate, 1e-4*massRate)
+
+	// choked orifice passes the same mass rate at any low back pressure
+	choked, err := orifice.MassRate(gas, 700, 2e6, 0.5e6)
+	assert.Nil(t, err)
+	chokedLow, err := orifice.MassRate(gas, 700, 2e6, 0.1e6)
+	assert.Nil(t, err)
+	assert.InDelta(t, choked, chokedLow, 1e-12)
+	_, err = orifice.POut(gas, 700, 2e6, 1.01*choked)
+	assert.NotNil(t, err)
+}
+
+func TestKFactorResistance(t *testing.T) {
+	var gas = gases.GetAir()
+	var resistance = NewKFactorResistance(0.01, func(massRate float64) float64 {
+		return 1.5 + 0.1*massRate
+	})
+
+	var pOut, err = resistance.POut(gas, 400, 3e5, 2)
+	assert.Nil(t, err)
+	var rho = 3e5 / (gas.R() * 400)
+	var c = 2 / (rho * 0.01)
+	assert.InDelta(t, 3e5-1.7*rho*c*c/2, pOut, 1e-6)
+
+	massRate, err := resistance.MassRate(gas, 400, 3e5, pOut)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2, massRate, 1e-8)
+}
+
+func TestPressureSplitter_Process(t *testing.T) {
+	var gas = gases.GetAir()
+	var splitter = NewPressureSplitter(0.05, nil, NewOrificeResistance(2e-4))
+	setComplexInput(splitter.Input(), gas, 700, 2e6, 10)
+
+	assert.Nil(t, splitter.Process())
+	var mainP = splitter.MainOutput().PressureOutput().GetState().Value().(float64)
+	var extraP = splitter.ExtraOutput().PressureOutput().GetState().Value().(float64)
+	assert.InDelta(t, 2e6, mainP, 1e-9)
+	assert.InDelta(t, 0, splitter.MainPressureDrop(), 1e-9)
+	assert.InDelta(t, 2e6-extraP, splitter.ExtraPressureDrop(), 1e-9)
+	assert.True(t, extraP < 2e6)
+	assert.InDelta(t, 0.5, splitter.ExtraOutput().MassRateOutput().GetState().Value().(float64), 1e-9)
+
+	// bleed flow grows as the downstream pressure of the extra branch decreases
+	assert.Nil(t, splitter.SolveExtraWeight(1.9e6))
+	var weightHigh = splitter.ExtraWeight()
+	assert.Nil(t, splitter.SolveExtraWeight(1.6e6))
+	var weightLow = splitter.ExtraWeight()
+	assert.True(t, weightLow > weightHigh, "%f %f", weightLow, weightHigh)
+
+	assert.Nil(t, splitter.Process())
+	extraP = splitter.ExtraOutput().PressureOutput().GetState().Value().(float64)
+	assert.InDelta(t, 1.6e6, extraP, 1e-3)
+
+	// both branches with resistances
+	splitter = NewPressureSplitter(0.3, NewOrificeResistance(5e-3), NewOrificeResistance(2e-3))
+	setComplexInput(splitter.Input(), gas, 700, 2e6, 10)
+	assert.Nil(t, splitter.Process())
+	assert.True(t, splitter.MainPressureDrop() > 0)
+	assert.True(t, splitter.ExtraPressureDrop() > 0)
+	graph.SetAll(
+		[]graph.PortState{states.NewMassRatePortState(100)}, []graph.Port{splitter.Input().MassRateInput()},
+	)
+	assert.NotNil(t, splitter.Process())
+}
@@ -0,0 +1,111 @@
+package constructive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/realgas"
+	"github.com/stretchr/testify/assert"
+)
+
+// simple recuperated supercritical CO2 cycle without heater
+func TestRealGas_SCO2Cycle(t *testing.T) {
+	var co2 = realgas.GetCO2()
+	var pLow, pHigh = 7.8e6, 20e6
+	var tCompIn, tTurbIn = 308., 823.
+
+	var compressor = NewCompressorNode(0.85, pHigh/pLow, 0.05)
+	setComplexInput(compressor, co2, tCompIn, pLow, 1)
+	assert.Nil(t, compressor.Process())
+	assert.InDelta(t, gases.HP(co2, compressor.TStagOut(), pHigh)-gases.HP(co2, tCompIn, pLow), compressor.LSpecific(), 1e-6)
+	var tIdeal, _ = gases.PolytropicCompressionT(co2, tCompIn, pHigh/pLow, 0.85)
+	assert.True(t, compressor.TStagOut() < tIdeal-10, "%f %f", compressor.TStagOut(), tIdeal)
+
+	var turbine = getTestFreeTurbineNode()
+	setComplexInput(turbine, co2, tTurbIn, pHigh, 1)
+	turbine.PressureOutput().SetState(states.NewPressurePortState(pLow))
+	assert.Nil(t, turbine.Process())
+	eta, err := gases.AdiabaticEfficiencyP(co2, tTurbIn, pHigh, turbine.TStagOut(), pLow)
+	assert.Nil(t, err)
+	assert.InDelta(t, etaT, eta, 1e-6)
+
+	// compression work of the dense fluid is a small part of expansion work
+	assert.True(t, compressor.LSpecific() < 0.3*turbine.LSpecific(), "%f %f", compressor.LSpecific(), turbine.LSpecific())
+
+	var regenerator = NewRegeneratorNode(0.9, 1e-5)
+	setComplexInput(regenerator.ColdInput(), co2, compressor.TStagOut(), pHigh, 1)
+	setComplexInput(regenerator.HotInput(), co2, turbine.TStagOut(), pLow, 1)
+	assert.Nil(t, regenerator.Process())
+
+	var tColdOut = regenerator.ColdOutput().TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	var tHotOut = regenerator.HotOutput().TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	var qCold = gases.HP(co2, tColdOut, pHigh) - gases.HP(co2, compressor.TStagOut(), pHigh)
+	var qHot = gases.HP(co2, turbine.TStagOut(), pLow) - gases.HP(co2, tHotOut, pLow)
+	assert.InDelta(t, qCold, qHot, 1e-3*qCold)
+}
+
+func TestRealGas_CombinerNetwork(t *testing.T) {
+	var pHigh = 20e6
+	var mainSource = source.NewComplexGasSourceNode(realgas.GetCO2(), 400, pHigh, 1)
+	var extraSource = source.NewComplexGasSourceNode(realgas.GetCO2(), 700, pHigh, 0.5)
+	var combiner = NewGasCombiner(1e-6, 1, 100)
+
+	nodes.LinkComplexOutToIn(mainSource, combiner.MainInput())
+	nodes.LinkComplexOutToIn(extraSource, combiner.ExtraInput())
+	var output = combiner.Output()
+	var sinks = sink.SinkAll(output.GasOutput(), output.TemperatureOutput(), output.PressureOutput(), output.MassRateOutput())
+
+	var network, networkErr = graph.NewNetwork([]graph.Node{
+		mainSource, extraSource, combiner, sinks[0], sinks[1], sinks[2], sinks[3],
+	})
+	assert.Nil(t, networkErr)
+	// network converges only if residual of the successive real gas states of the output port vanishes
+	assert.Nil(t, network.Solve(0.5, 2, 100, 1e-6))
+
+	var gasOut = output.GasOutput().GetState().(states.GasPortState).Gas
+	var _, ok = gasOut.(gases.RealGas)
+	assert.True(t, ok)
+	assert.InDelta(t, gases.HP(realgas.GetCO2(), 320, pHigh), gases.HP(gasOut, 320, pHigh), 1e-9)
+
+	// enthalpy balance of real gas
+	var tOut = output.TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	var hIn = gases.HP(gasOut, 400, pHigh) + 0.5*gases.HP(gasOut, 700, pHigh)
+	var hOut = 1.5 * gases.HP(gasOut, tOut, pHigh)
+	assert.InDelta(t, hIn, hOut, 1e-4*math.Abs(hIn))
+}
+
+func TestRealGas_MixingWithIdealGas(t *testing.T) {
+	// mixing rules of real gases are not implemented, so nodes fail instead of panicking
+	var mixer = NewMixerNode(0.3, 0.5)
+	setComplexInput(mixer.MainInput(), realgas.GetCO2(), 1100, 2.6e5, 30)
+	setComplexInput(mixer.ExtraInput(), gases.GetAir(), 400, 2.5e5, 60)
+	assert.NotNil(t, mixer.Process())
+
+	var combiner = NewGasCombiner(1e-6, 1, 100)
+	setComplexInput(combiner.MainInput(), realgas.GetCO2(), 400, 2e7, 1)
+	setComplexInput(combiner.ExtraInput(), gases.GetAir(), 700, 2e7, 0.5)
+	assert.NotNil(t, combiner.Process())
+}
+
+type complexInput interface {
+	GasInput() graph.Port
+	TemperatureInput() graph.Port
+	PressureInput() graph.Port
+	MassRateInput() graph.Port
+}
+
+func setComplexInput(node complexInput, gas gases.Gas, t, p, massRate float64) {
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(gas), states.NewTemperaturePortState(t),
+			states.NewPressurePortState(p), states.NewMassRatePortState(massRate),
+		},
+		[]graph.Port{node.GasInput(), node.TemperatureInput(), node.PressureInput(), node.MassRateInput()},
+	)
+}
@@ -97,8 +97,11 @@ func (node *regeneratorNode) getNewTOutIter(tStagColdOutCurr, tStagHotOutCurr fl
 	var coldMassRate = node.coldMassRateInput.GetState().(states.MassRatePortState).MassRate
 	var coldGas = node.coldGasInput.GetState().(states.GasPortState).Gas
 
-	var hotHeatRate = hotMassRate * gases.CpMean(hotGas, node.tStagHotIn(), tStagHotOutCurr, nodes.DefaultN)
-	var coldHeatRate = coldMassRate * gases.CpMean(coldGas, node.tStagColdIn(), tStagColdOutCurr, nodes.DefaultN)
+	var hotPressure = node.hotPressureInput.GetState().(states.PressurePortState).PStag
+	var coldPressure = node.coldPressureInput.GetState().(states.PressurePortState).PStag
+
+	var hotHeatRate = hotMassRate * gases.CpMeanP(hotGas, node.tStagHotIn(), tStagHotOutCurr, hotPressure, nodes.DefaultN)
+	var coldHeatRate = coldMassRate * gases.CpMeanP(coldGas, node.tStagColdIn(), tStagColdOutCurr, coldPressure, nodes.DefaultN)
 	var heatRateFactor = hotHeatRate / coldHeatRate
 
 	tStagColdOut = node.tStagColdIn() + node.sigma*(node.tStagHotIn()-node.tStagColdIn())
@@ -26,7 +26,7 @@ func DefaultNuFunc(gas gases.Gas, velocity, pressure, temperature, d float64) fl
 	var re = velocity * d * density / viscosity
 
 	var lambda = gas.Lambda(temperature)
-	var cp = gas.Cp(temperature)
+	var cp = gases.CpP(gas, temperature, pressure)
 	var pr = viscosity * cp / lambda
 
 	return 0.56 * math2.Pow(re, 0.5) * math2.Pow(pr, 0.36)
@@ -235,10 +235,10 @@ func (node *parametricRegeneratorNode) getOutputTemperatures() (float64, float64
 	residualFunc := func(tVec *mat.VecDense) (*mat.VecDense, error) {
 		tHotOut, tColdOut := tVec.At(0, 0), tVec.At(1, 0)
 
-		cpHot := gases.CpMean(hotGas, tHotIn, tHotOut, nodes.DefaultN)
+		cpHot := gases.CpMeanP(hotGas, tHotIn, tHotOut, pHot, nodes.DefaultN)
 		qHot := hotMassRate * cpHot * (tHotIn - tHotOut)
 
-		cpCold := gases.CpMean(coldGas, tColdIn, tColdOut, nodes.DefaultN)
+		cpCold := gases.CpMeanP(coldGas, tColdIn, tColdOut, pCold, nodes.DefaultN)
 		qCold := coldMassRate * cpCold * (tColdOut - tColdIn)
 
 		tDrop := node.meanTemperatureDropFunc(tHotIn, tHotOut, tColdIn, tColdOut)
@@ -285,7 +285,7 @@ func (node *parametricRegeneratorNode) setGeomParameters() error {
 
 	var tColdIn0, tColdOut0 = node.tColdIn0, node.getTColdOut0()
 
-	var q0 = node.massRateCold0 * gases.CpMean(node.coldGas0, tColdIn0, tColdOut0, nodes.DefaultN) * (tColdOut0 - tColdIn0)
+	var q0 = node.massRateCold0 * gases.CpMeanP(node.coldGas0, tColdIn0, tColdOut0, node.pColdIn0, nodes.DefaultN) * (tColdOut0 - tColdIn0)
 	var heatExchangeCoef = node.getHeatTransferCoef0(meanTDrop0)
 
 	var heatExchangeArea = q0 / (heatExchangeCoef * meanTDrop0)
@@ -343,12 +343,12 @@ func (node *parametricRegeneratorNode) getMeanTDrop0() (float64, error) {
 }
 
 func (node *parametricRegeneratorNode) getTHotOut0() (float64, error) {
-	var cpCold = gases.CpMean(node.coldGas0, node.tColdIn0, node.getTColdOut0(), nodes.DefaultN)
+	var cpCold = gases.CpMeanP(node.coldGas0, node.tColdIn0, node.getTColdOut0(), node.pColdIn0, nodes.DefaultN)
 
 	var iterFunc = func(tHotOut0 float64) (float64, error) {
 		var massRateCoef = node.massRateCold0 / node.massRateHot0
 
-		var cpHot = gases.CpMean(node.hotGas0, node.tHotIn0, tHotOut0, nodes.DefaultN)
+		var cpHot = gases.CpMeanP(node.hotGas0, node.tHotIn0, tHotOut0, node.pHotIn0, nodes.DefaultN)
 		var cpCoef = cpCold / cpHot
 
 		return node.tHotIn0 - massRateCoef*cpCoef*node.sigma0*(node.tHotIn0-node.tColdIn0), nil
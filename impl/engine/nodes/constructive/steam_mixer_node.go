@@ -0,0 +1,108 @@
+package constructive
+
+import (
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/helper"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+)
+
+// SteamMixerNode mixes two steam streams, e.g. admits steam of the lower pressure level
+// to the turbine exhaust. Outlet pressure is the least of the inlet ones
+type SteamMixerNode interface {
+	graph.Node
+	nodes.SteamSource
+	MainInput() nodes.SteamSink
+	ExtraInput() nodes.SteamSink
+}
+
+func NewSteamMixerNode() SteamMixerNode {
+	var result = &steamMixerNode{}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.mainSteamInput, &result.mainMassRateInput,
+			&result.extraSteamInput, &result.extraMassRateInput,
+			&result.steamOutput, &result.massRateOutput,
+		},
+		[]string{
+			"mainSteamInput", "mainMassRateInput",
+			"extraSteamInput", "extraMassRateInput",
+			nodes.SteamOutputTag, nodes.MassRateOutputTag,
+		},
+	)
+	return result
+}
+
+type steamMixerNode struct {
+	graph.BaseNode
+
+	mainSteamInput     graph.Port
+	mainMassRateInput  graph.Port
+	extraSteamInput    graph.Port
+	extraMassRateInput graph.Port
+
+	steamOutput    graph.Port
+	massRateOutput graph.Port
+}
+
+func (node *steamMixerNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "SteamMixer")
+}
+
+func (node *steamMixerNode) MainInput() nodes.SteamSink {
+	return helper.NewPseudoSteamSink(node.mainSteamInput, node.mainMassRateInput)
+}
+
+func (node *steamMixerNode) ExtraInput() nodes.SteamSink {
+	return helper.NewPseudoSteamSink(node.extraSteamInput, node.extraMassRateInput)
+}
+
+func (node *steamMixerNode) SteamOutput() graph.Port {
+	return node.steamOutput
+}
+
+func (node *steamMixerNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *steamMixerNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.mainSteamInput, node.mainMassRateInput,
+		node.extraSteamInput, node.extraMassRateInput,
+		node.steamOutput, node.massRateOutput,
+	}
+}
+
+func (node *steamMixerNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{
+		node.mainSteamInput, node.mainMassRateInput,
+		node.extraSteamInput, node.extraMassRateInput,
+	}, nil
+}
+
+func (node *steamMixerNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{node.steamOutput, node.massRateOutput}, nil
+}
+
+func (node *steamMixerNode) Process() error {
+	var main = node.mainSteamInput.GetState().(states.SteamPortState)
+	var extra = node.extraSteamInput.GetState().(states.SteamPortState)
+	var mainMassRate = node.mainMassRateInput.GetState().(states.MassRatePortState).MassRate
+	var extraMassRate = node.extraMassRateInput.GetState().(states.MassRatePortState).MassRate
+
+	var massRate = mainMassRate + extraMassRate
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewSteamPortState(
+				math.Min(main.P, extra.P), (mainMassRate*main.H+extraMassRate*extra.H)/massRate,
+			),
+			states.NewMassRatePortState(massRate),
+		},
+		[]graph.Port{node.steamOutput, node.massRateOutput},
+	)
+	return nil
+}
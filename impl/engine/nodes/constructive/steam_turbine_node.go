@@ -0,0 +1,161 @@
+package constructive
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/water"
+)
+
+// SteamTurbineNode expands steam to the outlet pressure with isentropic efficiency eta.
+// Power output carries labour per unit mass rate of steam
+type SteamTurbineNode interface {
+	graph.Node
+	nodes.SteamSink
+	nodes.SteamSource
+	nodes.PowerSource
+
+	Eta() float64
+	POut() float64
+	SetPOut(pOut float64)
+	// LSpecific is labour per unit mass rate of steam, J / kg
+	LSpecific() float64
+	// Power is labour of the whole steam mass rate
+	Power() float64
+	// XOut is vapour mass fraction at the outlet
+	XOut() float64
+}
+
+func NewSteamTurbineNode(eta, pOut float64) SteamTurbineNode {
+	var result = &steamTurbineNode{
+		eta:  eta,
+		pOut: pOut,
+	}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.steamInput, &result.massRateInput,
+			&result.steamOutput, &result.massRateOutput, &result.powerOutput,
+		},
+		[]string{
+			nodes.SteamInputTag, nodes.MassRateInputTag,
+			nodes.SteamOutputTag, nodes.MassRateOutputTag, nodes.PowerOutputTag,
+		},
+	)
+	return result
+}
+
+type steamTurbineNode struct {
+	graph.BaseNode
+
+	steamInput    graph.Port
+	massRateInput graph.Port
+
+	steamOutput    graph.Port
+	massRateOutput graph.Port
+	powerOutput    graph.Port
+
+	eta  float64
+	pOut float64
+
+	lSpecific float64
+	xOut      float64
+}
+
+func (node *steamTurbineNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "SteamTurbine")
+}
+
+func (node *steamTurbineNode) SteamInput() graph.Port {
+	return node.steamInput
+}
+
+func (node *steamTurbineNode) MassRateInput() graph.Port {
+	return node.massRateInput
+}
+
+func (node *steamTurbineNode) SteamOutput() graph.Port {
+	return node.steamOutput
+}
+
+func (node *steamTurbineNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *steamTurbineNode) PowerOutput() graph.Port {
+	return node.powerOutput
+}
+
+func (node *steamTurbineNode) Eta() float64 {
+	return node.eta
+}
+
+func (node *steamTurbineNode) POut() float64 {
+	return node.pOut
+}
+
+func (node *steamTurbineNode) SetPOut(pOut float64) {
+	node.pOut = pOut
+}
+
+func (node *steamTurbineNode) LSpecific() float64 {
+	return node.lSpecific
+}
+
+func (node *steamTurbineNode) Power() float64 {
+	return node.lSpecific * node.massRateInput.GetState().(states.MassRatePortState).MassRate
+}
+
+func (node *steamTurbineNode) XOut() float64 {
+	return node.xOut
+}
+
+func (node *steamTurbineNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.steamInput, node.massRateInput,
+		node.steamOutput, node.massRateOutput, node.powerOutput,
+	}
+}
+
+func (node *steamTurbineNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{node.steamInput, node.massRateInput}, nil
+}
+
+func (node *steamTurbineNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{node.steamOutput, node.massRateOutput, node.powerOutput}, nil
+}
+
+func (node *steamTurbineNode) Process() error {
+	var steamIn = node.steamInput.GetState().(states.SteamPortState)
+	if node.pOut >= steamIn.P {
+		return fmt.Errorf("steam turbine outlet pressure %f is not less than inlet pressure %f", node.pOut, steamIn.P)
+	}
+	var stateIn, err = steamIn.State()
+	if err != nil {
+		return err
+	}
+	stateAd, err := water.PS(node.pOut, stateIn.S)
+	if err != nil {
+		return err
+	}
+	var hOut = stateIn.H - node.eta*(stateIn.H-stateAd.H)
+	stateOut, err := water.PH(node.pOut, hOut)
+	if err != nil {
+		return err
+	}
+
+	node.lSpecific = stateIn.H - hOut
+	node.xOut = stateOut.X
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewSteamPortState(node.pOut, hOut),
+			node.massRateInput.GetState(),
+			states.NewPowerPortState(node.lSpecific),
+		},
+		[]graph.Port{node.steamOutput, node.massRateOutput, node.powerOutput},
+	)
+	return nil
+}
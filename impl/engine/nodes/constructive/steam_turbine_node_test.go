@@ -0,0 +1,85 @@
+package constructive
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/water"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	stPIn  = 8e6
+	stTIn  = 800
+	stPOut = 5e3
+)
+
+func TestSteamTurbineNode_Process(t *testing.T) {
+	var turbine = getTestSteamTurbine(0.88, 2)
+	assert.Nil(t, turbine.Process())
+
+	// condensing turbine with typical live steam parameters
+	assert.True(t, turbine.LSpecific() > 1e6 && turbine.LSpecific() < 1.5e6, "%f", turbine.LSpecific())
+	assert.True(t, turbine.XOut() > 0.8 && turbine.XOut() < 1, "%f", turbine.XOut())
+	assert.InDelta(t, 2*turbine.LSpecific(), turbine.Power(), 1e-6)
+	assert.InDelta(t, turbine.LSpecific(), turbine.PowerOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, 2, turbine.MassRateOutput().GetState().Value().(float64), 1e-12)
+
+	var steamOut = turbine.SteamOutput().GetState().(states.SteamPortState)
+	assert.InDelta(t, stPOut, steamOut.P, 1e-9)
+}
+
+func TestSteamTurbineNode_Isentropic(t *testing.T) {
+	var turbine = getTestSteamTurbine(1, 1)
+	assert.Nil(t, turbine.Process())
+
+	var stateIn, _ = water.PT(stPIn, stTIn)
+	var stateOut, err = turbine.SteamOutput().GetState().(states.SteamPortState).State()
+	assert.Nil(t, err)
+	assert.InDelta(t, stateIn.S, stateOut.S, 1e-3)
+
+	var real = getTestSteamTurbine(0.85, 1)
+	assert.Nil(t, real.Process())
+	assert.True(t, real.LSpecific() < turbine.LSpecific())
+	assert.True(t, real.XOut() > turbine.XOut())
+}
+
+func TestSteamTurbineNode_PressureRise(t *testing.T) {
+	var turbine = getTestSteamTurbine(0.88, 1)
+	turbine.SetPOut(stPIn * 1.1)
+	assert.NotNil(t, turbine.Process())
+}
+
+func TestSteamMixerNode_Process(t *testing.T) {
+	var hp, _ = water.PT(4e6, 700)
+	var lp, _ = water.PT(5e5, 500)
+
+	var mixer = NewSteamMixerNode()
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewSteamPortState(hp.P, hp.H), states.NewMassRatePortState(3),
+			states.NewSteamPortState(lp.P, lp.H), states.NewMassRatePortState(1),
+		},
+		[]graph.Port{
+			mixer.MainInput().SteamInput(), mixer.MainInput().MassRateInput(),
+			mixer.ExtraInput().SteamInput(), mixer.ExtraInput().MassRateInput(),
+		},
+	)
+	assert.Nil(t, mixer.Process())
+
+	var steamOut = mixer.SteamOutput().GetState().(states.SteamPortState)
+	assert.InDelta(t, lp.P, steamOut.P, 1e-9)
+	assert.InDelta(t, (3*hp.H+lp.H)/4, steamOut.H, 1e-6)
+	assert.InDelta(t, 4, mixer.MassRateOutput().GetState().Value().(float64), 1e-12)
+}
+
+func getTestSteamTurbine(eta, massRate float64) SteamTurbineNode {
+	var stateIn, _ = water.PT(stPIn, stTIn)
+	var turbine = NewSteamTurbineNode(eta, stPOut)
+	graph.SetAll(
+		[]graph.PortState{states.NewSteamPortState(stateIn.P, stateIn.H), states.NewMassRatePortState(massRate)},
+		[]graph.Port{turbine.SteamInput(), turbine.MassRateInput()},
+	)
+	return turbine
+}
@@ -188,9 +188,9 @@ func (node *parametricTurbineNode) Process() error {
 	// goes downstream
 	massRateOut := massRateIn * (1 + i + l)
 
-	var cp = gases.CpMean(node.inputGas(), node.tStagIn(), tStagOut, nodes.DefaultN)
+	var gas = node.inputGas()
 	// it is assumed that cooling air does not make labour
-	var lSpecific = cp * (node.tStagIn() - tStagOut) * (1 + l + c)
+	var lSpecific = (gases.HP(gas, node.tStagIn(), node.pStagIn()) - gases.HP(gas, tStagOut, pStagOut)) * (1 + l + c)
 
 	graph.SetAll(
 		[]graph.PortState{
@@ -236,8 +236,8 @@ func (node *parametricTurbineNode) CoolMassRateRel() float64 {
 }
 
 func (node *parametricTurbineNode) LSpecific() float64 {
-	var cp = gases.CpMean(node.inputGas(), node.tStagIn(), node.tStagOut(), nodes.DefaultN)
-	return -cp * (node.tStagIn() - node.tStagOut())
+	var gas = node.inputGas()
+	return -(gases.HP(gas, node.tStagIn(), node.pStagIn()) - gases.HP(gas, node.tStagOut(), node.pStagOut()))
 }
 
 func (node *parametricTurbineNode) PiTStag() float64 {
@@ -253,21 +253,7 @@ func (node *parametricTurbineNode) MassRateInput() graph.Port {
 }
 
 func (node *parametricTurbineNode) getTStagOut() (float64, error) {
-	var t0, err = node.getNewTtStag(0.8 * node.tStagIn()) // TODO move 0.8 out of code
-	if err != nil {
-		return t0, err
-	}
-	return common.SolveIteratively(node.getNewTtStag, t0, node.precision, 1, nodes.DefaultN)
-}
-
-func (node *parametricTurbineNode) getNewTtStag(currTtStag float64) (float64, error) {
-	var k = gases.KMean(node.inputGas(), node.tStagIn(), currTtStag, nodes.DefaultN)
-	var tStagIn = node.tStagIn()
-	var result = tStagIn * (1 - (1-math.Pow(node.piTStag(), (1-k)/k))*node.etaT())
-	if math.IsNaN(result) {
-		return 0, fmt.Errorf("nan obtained while calculating tStagOut")
-	}
-	return result, nil
+	return gases.ExpansionTP(node.inputGas(), node.tStagIn(), node.pStagIn(), node.pStagIn()/node.piTStag(), node.etaT())
 }
 
 func (node *parametricTurbineNode) massRateRelFactor() float64 {
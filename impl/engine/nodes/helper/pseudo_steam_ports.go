@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+)
+
+func NewPseudoSteamSource(steamOutput, massRateOutput graph.Port) nodes.SteamSource {
+	return &pseudoSteamSource{
+		steamOutput:    steamOutput,
+		massRateOutput: massRateOutput,
+	}
+}
+
+type pseudoSteamSource struct {
+	steamOutput    graph.Port
+	massRateOutput graph.Port
+}
+
+func (s *pseudoSteamSource) SteamOutput() graph.Port {
+	return s.steamOutput
+}
+
+func (s *pseudoSteamSource) MassRateOutput() graph.Port {
+	return s.massRateOutput
+}
+
+func NewPseudoSteamSink(steamInput, massRateInput graph.Port) nodes.SteamSink {
+	return &pseudoSteamSink{
+		steamInput:    steamInput,
+		massRateInput: massRateInput,
+	}
+}
+
+type pseudoSteamSink struct {
+	steamInput    graph.Port
+	massRateInput graph.Port
+}
+
+func (s *pseudoSteamSink) SteamInput() graph.Port {
+	return s.steamInput
+}
+
+func (s *pseudoSteamSink) MassRateInput() graph.Port {
+	return s.massRateInput
+}
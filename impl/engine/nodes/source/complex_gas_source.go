@@ -32,6 +32,16 @@ func NewComplexGasSourceNode(gas gases.Gas, tStag, pStag, massRate float64) Comp
 	return result
 }
 
+// NewHumidAirSourceNode returns source of humid air with relative humidity relHumidity in [0, 1]
+// (specified at tStag and pStag)
+func NewHumidAirSourceNode(tStag, pStag, relHumidity, massRate float64) (ComplexGasSourceNode, error) {
+	var gas, err = gases.NewHumidAir(tStag, pStag, relHumidity)
+	if err != nil {
+		return nil, err
+	}
+	return NewComplexGasSourceNode(gas, tStag, pStag, massRate), nil
+}
+
 type complexGasSourceNode struct {
 	sourceNode
 
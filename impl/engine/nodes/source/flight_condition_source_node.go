@@ -0,0 +1,206 @@
+package source
+
+import (
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/common/atmosphere"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+const (
+	ambientPressureOutputTag = "ambientPressureOutput"
+	velocityOutputTag        = "velocityOutput"
+)
+
+// FlightConditionSourceNode is a source of the free stream of the aircraft flying with Mach number
+// at pressure altitude in the standard atmosphere deviated by DeltaT.
+// Temperature and pressure outputs carry stagnation parameters of the free stream,
+// AmbientPressureOutput carries static pressure of the atmosphere (e.g. for nozzles)
+// and VelocityOutput carries flight velocity (e.g. for ram drag).
+// Velocity and stagnation state are calculated with properties of the source gas, so they differ
+// slightly from the ones of atmosphere.FlightCondition which assumes calorically perfect air
+type FlightConditionSourceNode interface {
+	ComplexGasSourceNode
+	AmbientPressureOutput() graph.Port
+	VelocityOutput() graph.Port
+
+	Altitude() float64
+	SetAltitude(altitude float64)
+	Mach() float64
+	SetMach(mach float64)
+	DeltaT() float64
+	SetDeltaT(deltaT float64)
+	MassRate() float64
+	SetMassRate(massRate float64)
+
+	// Ambient returns static state of the atmosphere computed during the last call of Process
+	Ambient() atmosphere.State
+	Velocity() float64
+}
+
+func NewFlightConditionSourceNode(gas gases.Gas, altitude, mach, deltaT, massRate float64) FlightConditionSourceNode {
+	var result = &flightConditionSourceNode{
+		gas:      gas,
+		altitude: altitude,
+		mach:     mach,
+		deltaT:   deltaT,
+		massRate: massRate,
+	}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{
+			&result.gasOutput, &result.temperatureOutput, &result.pressureOutput, &result.massRateOutput,
+			&result.ambientPressureOutput, &result.velocityOutput,
+		},
+		[]string{
+			nodes.GasOutputTag, nodes.TemperatureOutputTag, nodes.PressureOutputTag, nodes.MassRateOutputTag,
+			ambientPressureOutputTag, velocityOutputTag,
+		},
+	)
+	return result
+}
+
+type flightConditionSourceNode struct {
+	sourceNode
+
+	gasOutput             graph.Port
+	temperatureOutput     graph.Port
+	pressureOutput        graph.Port
+	massRateOutput        graph.Port
+	ambientPressureOutput graph.Port
+	velocityOutput        graph.Port
+
+	gas      gases.Gas
+	altitude float64
+	mach     float64
+	deltaT   float64
+	massRate float64
+
+	ambient  atmosphere.State
+	velocity float64
+}
+
+func (node *flightConditionSourceNode) GetRequirePorts() ([]graph.Port, error) {
+	return make([]graph.Port, 0), nil
+}
+
+func (node *flightConditionSourceNode) GetUpdatePorts() ([]graph.Port, error) {
+	return node.GetPorts(), nil
+}
+
+func (node *flightConditionSourceNode) GetPorts() []graph.Port {
+	return []graph.Port{
+		node.gasOutput, node.temperatureOutput, node.pressureOutput, node.massRateOutput,
+		node.ambientPressureOutput, node.velocityOutput,
+	}
+}
+
+func (node *flightConditionSourceNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "FlightConditionSource")
+}
+
+func (node *flightConditionSourceNode) Process() error {
+	var ambient, err = atmosphere.FlightCondition{
+		Altitude: node.altitude, Mach: node.mach, DeltaT: node.deltaT,
+	}.Ambient()
+	if err != nil {
+		return err
+	}
+	node.ambient = ambient
+	node.velocity = node.mach * math.Sqrt(gases.K(node.gas, ambient.T)*node.gas.R()*ambient.T)
+
+	var tStag, pStag = ambient.T, ambient.P
+	if node.velocity > 0 {
+		// free stream is decelerated isentropically
+		tStag, err = gases.TFromH(node.gas, node.gas.H(ambient.T)+node.velocity*node.velocity/2, ambient.T)
+		if err != nil {
+			return err
+		}
+		var pi float64
+		if pi, err = gases.CompressionPressureRatio(node.gas, ambient.T, tStag, 1); err != nil {
+			return err
+		}
+		pStag *= pi
+	}
+
+	graph.SetAll(
+		[]graph.PortState{
+			states.NewGasPortState(node.gas),
+			states.NewTemperaturePortState(tStag),
+			states.NewPressurePortState(pStag),
+			states.NewMassRatePortState(node.massRate),
+			states.NewPressurePortState(ambient.P),
+			graph.NewNumberPortState(node.velocity),
+		},
+		node.GetPorts(),
+	)
+	return nil
+}
+
+func (node *flightConditionSourceNode) TemperatureOutput() graph.Port {
+	return node.temperatureOutput
+}
+
+func (node *flightConditionSourceNode) PressureOutput() graph.Port {
+	return node.pressureOutput
+}
+
+func (node *flightConditionSourceNode) MassRateOutput() graph.Port {
+	return node.massRateOutput
+}
+
+func (node *flightConditionSourceNode) GasOutput() graph.Port {
+	return node.gasOutput
+}
+
+func (node *flightConditionSourceNode) AmbientPressureOutput() graph.Port {
+	return node.ambientPressureOutput
+}
+
+func (node *flightConditionSourceNode) VelocityOutput() graph.Port {
+	return node.velocityOutput
+}
+
+func (node *flightConditionSourceNode) Altitude() float64 {
+	return node.altitude
+}
+
+func (node *flightConditionSourceNode) SetAltitude(altitude float64) {
+	node.altitude = altitude
+}
+
+func (node *flightConditionSourceNode) Mach() float64 {
+	return node.mach
+}
+
+func (node *flightConditionSourceNode) SetMach(mach float64) {
+	node.mach = mach
+}
+
+func (node *flightConditionSourceNode) DeltaT() float64 {
+	return node.deltaT
+}
+
+func (node *flightConditionSourceNode) SetDeltaT(deltaT float64) {
+	node.deltaT = deltaT
+}
+
+func (node *flightConditionSourceNode) MassRate() float64 {
+	return node.massRate
+}
+
+func (node *flightConditionSourceNode) SetMassRate(massRate float64) {
+	node.massRate = massRate
+}
+
+func (node *flightConditionSourceNode) Ambient() atmosphere.State {
+	return node.ambient
+}
+
+func (node *flightConditionSourceNode) Velocity() float64 {
+	return node.velocity
+}
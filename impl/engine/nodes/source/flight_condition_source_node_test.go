@@ -0,0 +1,53 @@
+package source
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common/atmosphere"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightConditionSourceNode_Static(t *testing.T) {
+	var node = NewFlightConditionSourceNode(gases.GetAir(), 0, 0, 15, 10)
+	assert.Nil(t, node.Process())
+
+	assert.InDelta(t, 303.15, node.TemperatureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, atmosphere.SeaLevelP, node.PressureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, atmosphere.SeaLevelP, node.AmbientPressureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, 0, node.VelocityOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, 10, node.MassRateOutput().GetState().Value().(float64), 1e-9)
+}
+
+func TestFlightConditionSourceNode_Cruise(t *testing.T) {
+	var node = NewFlightConditionSourceNode(gases.GetAir(), 11000, 0.8, 0, 10)
+	assert.Nil(t, node.Process())
+
+	var ambient = node.Ambient()
+	assert.InDelta(t, 216.65, ambient.T, 1e-9)
+	assert.InDelta(t, ambient.P, node.AmbientPressureOutput().GetState().Value().(float64), 1e-9)
+	assert.InDelta(t, 0.8*ambient.SoundSpeed, node.Velocity(), 1)
+	assert.InDelta(t, node.Velocity(), node.VelocityOutput().GetState().Value().(float64), 1e-9)
+
+	// stagnation parameters are close to perfect gas ones since air is almost calorically perfect at low temperature
+	var tStag = node.TemperatureOutput().GetState().Value().(float64)
+	var pStag = node.PressureOutput().GetState().Value().(float64)
+	assert.InDelta(t, ambient.T*1.128, tStag, 0.5)
+	assert.InDelta(t, 1, pStag/(ambient.P*math.Pow(1.128, 3.5)), 5e-3)
+
+	var gas = gases.GetAir()
+	assert.InDelta(t, node.Velocity()*node.Velocity()/2, gas.H(tStag)-gas.H(ambient.T), 1e-3)
+}
+
+func TestFlightConditionSourceNode_Setters(t *testing.T) {
+	var node = NewFlightConditionSourceNode(gases.GetAir(), 0, 0, 0, 10)
+	node.SetAltitude(5000)
+	node.SetMach(0.5)
+	node.SetDeltaT(-10)
+	assert.Nil(t, node.Process())
+	assert.InDelta(t, 245.65, node.Ambient().T, 1e-9)
+
+	node.SetAltitude(1e5)
+	assert.NotNil(t, node.Process())
+}
@@ -3,6 +3,7 @@ package states
 import (
 	"encoding/json"
 
+	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbocycle/material/gases"
 )
@@ -22,13 +23,39 @@ func (state GasPortState) Value() interface{} {
 }
 
 func (state GasPortState) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct{}{})
+	var composition = gases.Flatten(state.Gas)
+	var massFractions = make(map[string]float64)
+	for _, gas := range composition.Components() {
+		massFractions[gas.String()] = composition.MassFraction(gas.String())
+	}
+	return json.Marshal(struct {
+		MassFractions map[string]float64 `json:"mass_fractions"`
+	}{
+		MassFractions: massFractions,
+	})
 }
 
 func (state GasPortState) Mix(another graph.PortState, relaxCoef float64) (graph.PortState, error) {
-	return state, nil // TODO add gas check
+	switch v := another.(type) {
+	case GasPortState:
+		var gas, err = gases.Mix(
+			[]gases.Gas{state.Gas, v.Gas},
+			[]float64{1 - relaxCoef, relaxCoef},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return NewGasPortState(gas), nil
+	default:
+		return nil, common.GetTypeError("GasPortState", v)
+	}
 }
 
 func (state GasPortState) MaxResidual(another graph.PortState) (float64, error) {
-	return 0, nil // TODO add gas check
+	switch v := another.(type) {
+	case GasPortState:
+		return gases.Residual(state.Gas, v.Gas), nil
+	default:
+		return 0, common.GetTypeError("GasPortState", v)
+	}
 }
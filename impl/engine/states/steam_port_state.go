@@ -0,0 +1,64 @@
+package states
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/material/water"
+)
+
+// SteamPortState describes water or steam by pressure and specific enthalpy,
+// which define the state in the two-phase region as well
+type SteamPortState struct {
+	P float64
+	H float64
+}
+
+func NewSteamPortState(p, h float64) SteamPortState {
+	return SteamPortState{P: p, H: h}
+}
+
+func (state SteamPortState) Value() interface{} {
+	return state
+}
+
+// State returns thermodynamic state of water
+func (state SteamPortState) State() (water.State, error) {
+	return water.PH(state.P, state.H)
+}
+
+func (state SteamPortState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		P float64 `json:"p"`
+		H float64 `json:"h"`
+	}{
+		P: state.P,
+		H: state.H,
+	})
+}
+
+func (state SteamPortState) Mix(another graph.PortState, relaxCoef float64) (graph.PortState, error) {
+	switch v := another.(type) {
+	case SteamPortState:
+		return NewSteamPortState(
+			common.Lerp(state.P, v.P, relaxCoef),
+			common.Lerp(state.H, v.H, relaxCoef),
+		), nil
+	default:
+		return nil, common.GetTypeError("SteamPortState", v)
+	}
+}
+
+func (state SteamPortState) MaxResidual(another graph.PortState) (float64, error) {
+	switch v := another.(type) {
+	case SteamPortState:
+		return math.Max(
+			common.GetRelResidual(state.P, v.P),
+			common.GetRelResidual(state.H, v.H),
+		), nil
+	default:
+		return 0, common.GetTypeError("SteamPortState", v)
+	}
+}
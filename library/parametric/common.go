@@ -21,6 +21,15 @@ func NewGasPart(gas gases.Gas, tStagIn, pStagIn, pStagOut float64) *GasPart {
 	}
 }
 
+// NewHumidGasPart returns gas part with humid air inlet of relative humidity relHumidity in [0, 1]
+func NewHumidGasPart(tStagIn, pStagIn, pStagOut, relHumidity float64) (*GasPart, error) {
+	var gas, err = gases.NewHumidAir(tStagIn, pStagIn, relHumidity)
+	if err != nil {
+		return nil, err
+	}
+	return NewGasPart(gas, tStagIn, pStagIn, pStagOut), nil
+}
+
 type GasPart struct {
 	GasSource            source.GasSourceNode
 	TemperatureSource    source.TemperatureSourceNode
@@ -0,0 +1,28 @@
+package parametric
+
+import (
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/library/schemes"
+)
+
+// JetEngine provides performance of the off-design jet engine. Efficiency returns thermal efficiency
+type JetEngine interface {
+	Efficient
+	schemes.JetEngine
+}
+
+// NewJetEngine returns performance of the jet engine with free stream captured by the inlet
+// and fuel burnt in the burners. Jets are nozzles exhausting to the atmosphere
+func NewJetEngine(inlet c.InletNode, burners []c.BurnerNode, jets ...c.NozzleNode) JetEngine {
+	return &jetEngine{
+		JetEngine: schemes.NewJetEngine(inlet, burners, jets...),
+	}
+}
+
+type jetEngine struct {
+	schemes.JetEngine
+}
+
+func (engine *jetEngine) Efficiency() float64 {
+	return engine.ThermalEfficiency()
+}
@@ -0,0 +1,69 @@
+package tf2n
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/parametric"
+)
+
+// TwoSpoolMixedScheme is a turbofan with core and bypass flows mixed before the common nozzle.
+// Static pressures of the flows at the mixer inlet are balanced by the bypass ratio
+type TwoSpoolMixedScheme interface {
+	TwoSpoolScheme
+	Mixer() c.MixerNode
+	Nozzle() c.ParametricNozzleNode
+}
+
+func NewTwoSpoolMixedScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	fan c.ParametricCompressorNode,
+	splitter c.GasSplitter,
+	hpc c.ParametricCompressorNode,
+	hpcPipe c.PressureLossNode,
+	burner c.ParametricBurnerNode,
+	hpt c.ParametricTurbineNode,
+	hptPipe c.PressureLossNode,
+	lpt c.ParametricTurbineNode,
+	bypassDuct c.PressureLossNode,
+	mixer c.MixerNode,
+	nozzle c.ParametricNozzleNode,
+	tGas, etaM float64,
+) TwoSpoolMixedScheme {
+	var result = &twoSpoolMixedScheme{
+		twoSpoolScheme: newTwoSpoolScheme(
+			flightCondition, inlet, fan, splitter, hpc, hpcPipe, burner, hpt, hptPipe, lpt, bypassDuct, tGas, etaM,
+		),
+		mixer:  mixer,
+		nozzle: nozzle,
+	}
+	result.JetEngine = parametric.NewJetEngine(inlet, []c.BurnerNode{burner}, nozzle)
+
+	result.fanPart.LinkJet(nozzle)
+	nodes.LinkComplexOutToIn(lpt, mixer.MainInput())
+	nodes.LinkComplexOutToIn(bypassDuct, mixer.ExtraInput())
+	nodes.LinkComplexOutToIn(mixer.Output(), nozzle)
+
+	result.setEquations(mixer.StaticPressureResidualOutput(), nozzle.MassRateResidualOutput())
+	return result
+}
+
+type twoSpoolMixedScheme struct {
+	*twoSpoolScheme
+	mixer  c.MixerNode
+	nozzle c.ParametricNozzleNode
+}
+
+func (scheme *twoSpoolMixedScheme) Mixer() c.MixerNode {
+	return scheme.mixer
+}
+
+func (scheme *twoSpoolMixedScheme) Nozzle() c.ParametricNozzleNode {
+	return scheme.nozzle
+}
+
+func (scheme *twoSpoolMixedScheme) GetNetwork() (graph.Network, error) {
+	return graph.NewNetwork(append(scheme.nodes(), scheme.mixer, scheme.nozzle))
+}
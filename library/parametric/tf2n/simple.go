@@ -0,0 +1,320 @@
+package tf2n
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/utils"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/parametric"
+)
+
+type TwoSpoolScheme interface {
+	parametric.JetEngine
+	FlightCondition() source.FlightConditionSourceNode
+	Inlet() c.InletNode
+	Fan() c.ParametricCompressorNode
+	Splitter() c.GasSplitter
+	BypassDuct() c.PressureLossNode
+	HPC() c.ParametricCompressorNode
+	HPCPipe() c.PressureLossNode
+	Burner() c.ParametricBurnerNode
+	HPT() c.ParametricTurbineNode
+	HPTPipe() c.PressureLossNode
+	LPT() c.ParametricTurbineNode
+	TemperatureSource() source.TemperatureSourceNode
+	Assembler() graph.VectorAssemblerNode
+	Variators() []variator.Variator
+	GetNetwork() (graph.Network, error)
+}
+
+// TwoSpoolSeparateScheme is a turbofan with core and bypass flows exhausted by separate nozzles
+type TwoSpoolSeparateScheme interface {
+	TwoSpoolScheme
+	CoreNozzle() c.ParametricNozzleNode
+	BypassNozzle() c.ParametricNozzleNode
+}
+
+func NewTwoSpoolSeparateScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	fan c.ParametricCompressorNode,
+	splitter c.GasSplitter,
+	hpc c.ParametricCompressorNode,
+	hpcPipe c.PressureLossNode,
+	burner c.ParametricBurnerNode,
+	hpt c.ParametricTurbineNode,
+	hptPipe c.PressureLossNode,
+	lpt c.ParametricTurbineNode,
+	coreNozzle c.ParametricNozzleNode,
+	bypassDuct c.PressureLossNode,
+	bypassNozzle c.ParametricNozzleNode,
+	tGas, etaM float64,
+) TwoSpoolSeparateScheme {
+	var result = &twoSpoolSeparateScheme{
+		twoSpoolScheme: newTwoSpoolScheme(
+			flightCondition, inlet, fan, splitter, hpc, hpcPipe, burner, hpt, hptPipe, lpt, bypassDuct, tGas, etaM,
+		),
+		coreNozzle:   coreNozzle,
+		bypassNozzle: bypassNozzle,
+	}
+	result.JetEngine = parametric.NewJetEngine(inlet, []c.BurnerNode{burner}, coreNozzle, bypassNozzle)
+
+	result.fanPart.LinkJet(coreNozzle)
+	result.fanPart.LinkJet(bypassNozzle)
+	nodes.LinkComplexOutToIn(lpt, coreNozzle)
+	nodes.LinkComplexOutToIn(bypassDuct, bypassNozzle)
+
+	result.setEquations(coreNozzle.MassRateResidualOutput(), bypassNozzle.MassRateResidualOutput())
+	return result
+}
+
+type twoSpoolSeparateScheme struct {
+	*twoSpoolScheme
+	coreNozzle   c.ParametricNozzleNode
+	bypassNozzle c.ParametricNozzleNode
+}
+
+func (scheme *twoSpoolSeparateScheme) CoreNozzle() c.ParametricNozzleNode {
+	return scheme.coreNozzle
+}
+
+func (scheme *twoSpoolSeparateScheme) BypassNozzle() c.ParametricNozzleNode {
+	return scheme.bypassNozzle
+}
+
+func (scheme *twoSpoolSeparateScheme) GetNetwork() (graph.Network, error) {
+	return graph.NewNetwork(append(scheme.nodes(), scheme.coreNozzle, scheme.bypassNozzle))
+}
+
+func newTwoSpoolScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	fan c.ParametricCompressorNode,
+	splitter c.GasSplitter,
+	hpc c.ParametricCompressorNode,
+	hpcPipe c.PressureLossNode,
+	burner c.ParametricBurnerNode,
+	hpt c.ParametricTurbineNode,
+	hptPipe c.PressureLossNode,
+	lpt c.ParametricTurbineNode,
+	bypassDuct c.PressureLossNode,
+	tGas, etaM float64,
+) *twoSpoolScheme {
+	var result = &twoSpoolScheme{
+		fanPart: parametric.NewFanPart(
+			flightCondition, inlet, fan, c.NewTransmissionNode(etaM), lpt, splitter, bypassDuct,
+		),
+		gasGeneratorPart: parametric.NewGasGeneratorPart(
+			hpc, burner, hpt, c.NewTransmissionNode(etaM), hpcPipe,
+		),
+		hptPipe: hptPipe,
+
+		burnerTemperatureSource: source.NewTemperatureSourceNode(tGas),
+
+		assembler: graph.NewVectorAssemblerNode(),
+
+		variators: []variator.Variator{
+			variator.FromCallables(flightCondition.MassRate, flightCondition.SetMassRate),
+			variator.FromCallables(fan.NormMassRate, fan.SetNormMassRate),
+			variator.FromCallables(fan.NormPiStag, fan.SetNormPiStag),
+			variator.FromCallables(splitter.ExtraWeight, splitter.SetExtraWeight),
+			variator.FromCallables(hpc.NormMassRate, hpc.SetNormMassRate),
+			variator.FromCallables(hpc.NormPiStag, hpc.SetNormPiStag),
+			variator.FromCallables(burner.FuelRateRel, burner.SetFuelRateRel),
+			variator.FromCallables(hpt.NormPiT, hpt.SetNormPiT),
+			variator.FromCallables(lpt.NormPiT, lpt.SetNormPiT),
+		},
+	}
+	result.linkPorts()
+	return result
+}
+
+type twoSpoolScheme struct {
+	parametric.JetEngine
+
+	fanPart          *parametric.FanPart
+	gasGeneratorPart *parametric.GasGeneratorPart
+	hptPipe          c.PressureLossNode
+
+	burnerTemperatureSource source.TemperatureSourceNode
+
+	fanMassRateEq  graph.ReduceNode
+	coreMassRateEq graph.ReduceNode
+	hptMassRateEq  graph.ReduceNode
+	lptMassRateEq  graph.ReduceNode
+	hpPowerEq      graph.ReduceNode
+	lpPowerEq      graph.ReduceNode
+	burnerEq       graph.ReduceNode
+
+	assembler graph.VectorAssemblerNode
+	variators []variator.Variator
+}
+
+func (scheme *twoSpoolScheme) FlightCondition() source.FlightConditionSourceNode {
+	return scheme.fanPart.FlightCondition
+}
+
+func (scheme *twoSpoolScheme) Inlet() c.InletNode {
+	return scheme.fanPart.Inlet
+}
+
+func (scheme *twoSpoolScheme) Fan() c.ParametricCompressorNode {
+	return scheme.fanPart.Compressor
+}
+
+func (scheme *twoSpoolScheme) Splitter() c.GasSplitter {
+	return scheme.fanPart.Splitter
+}
+
+func (scheme *twoSpoolScheme) BypassDuct() c.PressureLossNode {
+	return scheme.fanPart.BypassDuct
+}
+
+func (scheme *twoSpoolScheme) HPC() c.ParametricCompressorNode {
+	return scheme.gasGeneratorPart.Compressor
+}
+
+func (scheme *twoSpoolScheme) HPCPipe() c.PressureLossNode {
+	return scheme.gasGeneratorPart.CompressorPipe
+}
+
+func (scheme *twoSpoolScheme) Burner() c.ParametricBurnerNode {
+	return scheme.gasGeneratorPart.Burner
+}
+
+func (scheme *twoSpoolScheme) HPT() c.ParametricTurbineNode {
+	return scheme.gasGeneratorPart.Turbine
+}
+
+func (scheme *twoSpoolScheme) HPTPipe() c.PressureLossNode {
+	return scheme.hptPipe
+}
+
+func (scheme *twoSpoolScheme) LPT() c.ParametricTurbineNode {
+	return scheme.fanPart.Turbine
+}
+
+func (scheme *twoSpoolScheme) TemperatureSource() source.TemperatureSourceNode {
+	return scheme.burnerTemperatureSource
+}
+
+func (scheme *twoSpoolScheme) Assembler() graph.VectorAssemblerNode {
+	return scheme.assembler
+}
+
+func (scheme *twoSpoolScheme) Variators() []variator.Variator {
+	return scheme.variators
+}
+
+func (scheme *twoSpoolScheme) nodes() []graph.Node {
+	var result = append(scheme.fanPart.Nodes(), scheme.gasGeneratorPart.Nodes()...)
+	return append(
+		result, scheme.hptPipe, scheme.burnerTemperatureSource, scheme.assembler,
+		scheme.fanMassRateEq, scheme.coreMassRateEq, scheme.hptMassRateEq, scheme.lptMassRateEq,
+		scheme.hpPowerEq, scheme.lpPowerEq, scheme.burnerEq,
+	)
+}
+
+func (scheme *twoSpoolScheme) linkPorts() {
+	var splitter = scheme.fanPart.Splitter
+	var hpc = scheme.gasGeneratorPart.Compressor
+	var hpt = scheme.gasGeneratorPart.Turbine
+	var lpt = scheme.fanPart.Turbine
+
+	graph.LinkAll(
+		[]graph.Port{
+			splitter.MainOutput().GasOutput(), splitter.MainOutput().TemperatureOutput(),
+			splitter.MainOutput().PressureOutput(),
+		},
+		[]graph.Port{hpc.GasInput(), hpc.TemperatureInput(), hpc.PressureInput()},
+	)
+	sink.SinkAll(splitter.MainOutput().MassRateOutput(), hpc.MassRateInput())
+
+	graph.LinkAll(
+		[]graph.Port{hpt.GasOutput(), hpt.TemperatureOutput(), hpt.PressureOutput(), hpt.MassRateOutput()},
+		[]graph.Port{
+			scheme.hptPipe.GasInput(), scheme.hptPipe.TemperatureInput(),
+			scheme.hptPipe.PressureInput(), scheme.hptPipe.MassRateInput(),
+		},
+	)
+	graph.LinkAll(
+		[]graph.Port{scheme.hptPipe.GasOutput(), scheme.hptPipe.TemperatureOutput(), scheme.hptPipe.PressureOutput()},
+		[]graph.Port{lpt.GasInput(), lpt.TemperatureInput(), lpt.PressureInput()},
+	)
+	sink.SinkAll(scheme.hptPipe.MassRateOutput(), lpt.MassRateInput())
+}
+
+// setEquations sets balance equations of the spools and adds residuals of the exhaust system to them
+func (scheme *twoSpoolScheme) setEquations(exhaustResiduals ...graph.Port) {
+	var fanPart = scheme.fanPart
+	var ggPart = scheme.gasGeneratorPart
+
+	scheme.fanMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(fanPart.Inlet.MassRateOutput()),
+		graph.NewWeakPort(fanPart.Compressor.MassRateInput()),
+	)
+	scheme.fanMassRateEq.SetName("fanMassRateEq")
+
+	scheme.coreMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(fanPart.Splitter.MainOutput().MassRateOutput()),
+		graph.NewWeakPort(ggPart.Compressor.MassRateInput()),
+	)
+	scheme.coreMassRateEq.SetName("coreMassRateEq")
+
+	scheme.hptMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(ggPart.Burner.MassRateOutput()),
+		graph.NewWeakPort(ggPart.Turbine.MassRateInput()),
+	)
+	scheme.hptMassRateEq.SetName("hptMassRateEq")
+
+	scheme.lptMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(scheme.hptPipe.MassRateOutput()),
+		graph.NewWeakPort(fanPart.Turbine.MassRateInput()),
+	)
+	scheme.lptMassRateEq.SetName("lptMassRateEq")
+
+	scheme.hpPowerEq = utils.NewMultiAdderFromPorts(
+		[]graph.Port{
+			graph.NewWeakPort(ggPart.Turbine.PowerOutput()),
+			graph.NewWeakPort(ggPart.Turbine.MassRateInput()),
+		},
+		[]graph.Port{
+			graph.NewWeakPort(ggPart.Shaft.PowerOutput()),
+			graph.NewWeakPort(ggPart.Compressor.MassRateInput()),
+		},
+	)
+	scheme.hpPowerEq.SetName("hpPowerEq")
+
+	scheme.lpPowerEq = utils.NewMultiAdderFromPorts(
+		[]graph.Port{
+			graph.NewWeakPort(fanPart.Turbine.PowerOutput()),
+			graph.NewWeakPort(fanPart.Turbine.MassRateInput()),
+		},
+		[]graph.Port{
+			graph.NewWeakPort(fanPart.Shaft.PowerOutput()),
+			graph.NewWeakPort(fanPart.Compressor.MassRateInput()),
+		},
+	)
+	scheme.lpPowerEq.SetName("lpPowerEq")
+
+	scheme.burnerEq = utils.NewEquality(
+		scheme.burnerTemperatureSource.TemperatureOutput(),
+		graph.NewWeakPort(ggPart.Burner.TemperatureOutput()),
+	)
+	scheme.burnerEq.SetName("burnerEq")
+
+	scheme.assembler.AddInputPorts(
+		scheme.fanMassRateEq.OutputPort(),
+		scheme.coreMassRateEq.OutputPort(),
+		scheme.hptMassRateEq.OutputPort(),
+		scheme.lptMassRateEq.OutputPort(),
+		scheme.hpPowerEq.OutputPort(),
+		scheme.lpPowerEq.OutputPort(),
+		scheme.burnerEq.OutputPort(),
+	)
+	scheme.assembler.AddInputPorts(exhaustResiduals...)
+	sink.SinkPort(scheme.assembler.GetVectorPort())
+}
@@ -0,0 +1,268 @@
+package tf2n
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/solvers/newton"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/schemes"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	altitude    = 11000
+	mach        = 0.8
+	bypassRatio = 5
+
+	fanPi0  = 1.6
+	fanEta0 = 0.9
+	fanRPM0 = 5e3
+
+	hpcPi0  = 18
+	hpcEta0 = 0.88
+	hpcRPM0 = 1.2e4
+
+	tGas0     = 1500
+	tFuel     = 300
+	sigmaBurn = 0.96
+	etaBurn   = 0.99
+	lambdaIn0 = 0.2
+
+	hptEta0  = 0.9
+	hptDMean = 0.5
+	lptEta0  = 0.91
+	lptDMean = 0.8
+
+	pipeSigma = 0.99
+	ductSigma = 0.98
+	phi       = 0.98
+	etaM      = 0.99
+
+	precision = 1e-6
+)
+
+func TestTwoSpoolSeparateScheme_DesignPoint(t *testing.T) {
+	var design = getDesignSeparateScheme()
+	var scheme = getSeparateScheme(design, tGas0)
+	var network, err = scheme.GetNetwork()
+	assert.Nil(t, err)
+	assert.Nil(t, network.Solve(1, 2, 100, precision))
+
+	// residuals vanish at design values of the variators
+	var residual = scheme.Assembler().GetVectorPort().GetState().(graph.VectorPortState).Vec
+	for i := 0; i != residual.Len(); i++ {
+		assert.InDelta(t, 0, residual.AtVec(i), 1e-3, "%d", i)
+	}
+	assert.InDelta(t, design.NetThrust(), scheme.NetThrust(), 1e-3*design.NetThrust())
+	assert.InDelta(t, design.TSFC(), scheme.TSFC(), 1e-3*design.TSFC())
+	assert.InDelta(t, design.ThermalEfficiency(), scheme.Efficiency(), 1e-3)
+}
+
+func TestTwoSpoolSeparateScheme_Throttle(t *testing.T) {
+	var design = getDesignSeparateScheme()
+	var scheme = getSeparateScheme(design, 1350)
+	assert.Nil(t, solve(scheme, getInitialGuess(design)))
+
+	assert.InDelta(t, 1350, scheme.Burner().TStagOut(), 1e-3)
+	var netThrust = scheme.NetThrust()
+	assert.True(t, netThrust > 0)
+	assert.True(t, netThrust < design.NetThrust(), "%f", netThrust)
+	assert.True(t, scheme.Fan().PiStag() < fanPi0)
+	assert.True(t, scheme.HPC().PiStag() < hpcPi0)
+
+	var coreMassRate = scheme.HPC().MassRate()
+	var bypassMassRate = scheme.BypassNozzle().MassRateInput().GetState().Value().(float64)
+	assert.True(t, bypassMassRate/coreMassRate > bypassRatio, "%f", bypassMassRate/coreMassRate)
+	assert.InDelta(
+		t, scheme.BypassNozzle().MassRateCapacity(), bypassMassRate, 1e-4*bypassMassRate,
+	)
+}
+
+func TestTwoSpoolMixedScheme_DesignPoint(t *testing.T) {
+	var design = getDesignMixedScheme()
+	var designNetwork, designErr = design.GetNetwork()
+	assert.Nil(t, designErr)
+	assert.Nil(t, designNetwork.Solve(1, 2, 100, precision))
+
+	var scheme = getMixedScheme(design, tGas0)
+	var network, err = scheme.GetNetwork()
+	assert.Nil(t, err)
+	assert.Nil(t, network.Solve(1, 2, 100, precision))
+
+	// residuals vanish at design values of the variators
+	var residual = scheme.Assembler().GetVectorPort().GetState().(graph.VectorPortState).Vec
+	for i := 0; i != residual.Len(); i++ {
+		assert.InDelta(t, 0, residual.AtVec(i), 1e-3, "%d", i)
+	}
+	assert.InDelta(t, design.NetThrust(), scheme.NetThrust(), 1e-3*design.NetThrust())
+	assert.InDelta(t, design.TSFC(), scheme.TSFC(), 1e-3*design.TSFC())
+	assert.InDelta(t, design.ThermalEfficiency(), scheme.Efficiency(), 1e-3)
+
+	// equation system keeps the design point
+	var solved = getMixedScheme(design, tGas0)
+	assert.Nil(t, solve(solved, getInitialGuess(design)))
+	assert.InDelta(t, design.Splitter().ExtraWeight(), solved.Splitter().ExtraWeight(), 1e-4)
+	assert.InDelta(t, design.NetThrust(), solved.NetThrust(), 1e-3*design.NetThrust())
+}
+
+func TestTwoSpoolMixedScheme_Throttle(t *testing.T) {
+	var design = getDesignMixedScheme()
+	var network, err = design.GetNetwork()
+	assert.Nil(t, err)
+	assert.Nil(t, network.Solve(1, 2, 100, precision))
+
+	var scheme = getMixedScheme(design, 1350)
+	assert.Nil(t, solve(scheme, getInitialGuess(design)))
+
+	assert.InDelta(t, 0, scheme.Mixer().PStaticMainIn()-scheme.Mixer().PStaticExtraIn(), 1e-3*scheme.Mixer().PStaticMainIn())
+	assert.True(t, scheme.NetThrust() > 0)
+	assert.True(t, scheme.NetThrust() < design.NetThrust())
+	assert.True(t, scheme.Efficiency() > 0)
+}
+
+func solve(scheme TwoSpoolScheme, init []float64) error {
+	var network, err = scheme.GetNetwork()
+	if err != nil {
+		return err
+	}
+	if err = network.Solve(1, 2, 100, precision); err != nil {
+		return err
+	}
+	var sysCall = variator.SysCallFromNetwork(
+		network, scheme.Assembler().GetVectorPort(), 1, 2, 100, precision,
+	)
+	var solverGen = newton.NewUniformNewtonSolverGen(1e-5, newton.NoLog)
+	var variatorSolver = variator.NewVariatorSolver(sysCall, scheme.Variators(), solverGen)
+
+	_, err = variatorSolver.Solve(mat.NewVecDense(len(init), init), 1e-7, 1, 100)
+	return err
+}
+
+func getInitialGuess(design schemes.TwoSpoolTurbofanScheme) []float64 {
+	return []float64{
+		1 + bypassRatio, 1, 1, design.Splitter().ExtraWeight(), 1, 1, design.MainBurner().FuelRateRel(), 1, 1,
+	}
+}
+
+func getSeparateScheme(design schemes.TwoSpoolSeparateTurbofanScheme, tGas float64) TwoSpoolSeparateScheme {
+	return NewTwoSpoolSeparateScheme(
+		getFlightCondition(), c.NewParametricInletNodeFromProto(design.Inlet()),
+		getFan(design), c.NewGasSplitter(design.Splitter().ExtraWeight()),
+		getHPC(design), c.NewPressureLossNode(1), getBurner(design), getHPT(design),
+		c.NewPressureLossNode(design.HPTPipe().Sigma()), getLPT(design),
+		c.NewParametricNozzleNodeFromProto(design.CoreNozzle()),
+		c.NewPressureLossNode(design.BypassDuct().Sigma()),
+		c.NewParametricNozzleNodeFromProto(design.BypassNozzle()),
+		tGas, etaM,
+	)
+}
+
+func getMixedScheme(design schemes.TwoSpoolMixedTurbofanScheme, tGas float64) TwoSpoolMixedScheme {
+	return NewTwoSpoolMixedScheme(
+		getFlightCondition(), c.NewParametricInletNodeFromProto(design.Inlet()),
+		getFan(design), c.NewGasSplitter(design.Splitter().ExtraWeight()),
+		getHPC(design), c.NewPressureLossNode(1), getBurner(design), getHPT(design),
+		c.NewPressureLossNode(design.HPTPipe().Sigma()), getLPT(design),
+		c.NewPressureLossNode(design.BypassDuct().Sigma()),
+		c.NewMixerNode(design.Mixer().MainArea(), design.Mixer().ExtraArea()),
+		c.NewParametricNozzleNodeFromProto(design.Nozzle()),
+		tGas, etaM,
+	)
+}
+
+func getFlightCondition() source.FlightConditionSourceNode {
+	return source.NewFlightConditionSourceNode(gases.GetAir(), altitude, mach, 0, 1+bypassRatio)
+}
+
+func getFan(design schemes.TwoSpoolTurbofanScheme) c.ParametricCompressorNode {
+	return c.NewParametricCompressorNodeFromProto(
+		design.Fan(), unitCompressorChar, unitCompressorChar, fanRPM0, 1+bypassRatio, precision,
+	)
+}
+
+// mass rates of the design scheme are relative to the core one, so that core mass rate is 1 kg/s
+func getHPC(design schemes.TwoSpoolTurbofanScheme) c.ParametricCompressorNode {
+	return c.NewParametricCompressorNodeFromProto(
+		design.HPC(), unitCompressorChar, unitCompressorChar, hpcRPM0, 1, precision,
+	)
+}
+
+func getBurner(design schemes.TwoSpoolTurbofanScheme) c.ParametricBurnerNode {
+	return c.NewParametricBurnerFromProto(design.MainBurner(), lambdaIn0, 1, precision, 1, nodes.DefaultN)
+}
+
+func getHPT(design schemes.TwoSpoolTurbofanScheme) c.ParametricTurbineNode {
+	return c.NewParametricTurbineNodeFromProto(
+		design.HPT(), chokedTurbineChar, unitTurbineChar,
+		design.HPT().MassRateInput().GetState().Value().(float64), hptDMean, precision,
+	)
+}
+
+func getLPT(design schemes.TwoSpoolTurbofanScheme) c.ParametricTurbineNode {
+	return c.NewParametricTurbineNodeFromProto(
+		design.LPT(), chokedTurbineChar, unitTurbineChar,
+		design.LPT().MassRateInput().GetState().Value().(float64), lptDMean, precision,
+	)
+}
+
+func getDesignSeparateScheme() schemes.TwoSpoolSeparateTurbofanScheme {
+	var scheme = schemes.NewTwoSpoolSeparateTurbofanScheme(
+		getFlightCondition(),
+		c.NewInletNode(c.MilSpecRecovery(0.99), 0.99),
+		c.NewCompressorNode(fanEta0, fanPi0, precision),
+		bypassRatio, getDesignGasGenerator(), c.NewPressureLossNode(pipeSigma),
+		c.NewSimpleBlockedTurbineNode(lptEta0, 0.3, 0, 0, 0, precision), etaM,
+		c.NewNozzleNode(c.ConvergentNozzle, phi, 1),
+		c.NewPressureLossNode(ductSigma),
+		c.NewNozzleNode(c.ConvergentNozzle, phi, 1),
+	)
+	var network, _ = scheme.GetNetwork()
+	network.Solve(1, 2, 100, precision)
+	return scheme
+}
+
+func getDesignMixedScheme() schemes.TwoSpoolMixedTurbofanScheme {
+	return schemes.NewTwoSpoolMixedTurbofanScheme(
+		getFlightCondition(),
+		c.NewInletNode(c.MilSpecRecovery(0.99), 0.99),
+		c.NewCompressorNode(fanEta0, 2.0, precision),
+		bypassRatio, getDesignGasGenerator(), c.NewPressureLossNode(pipeSigma),
+		c.NewSimpleBlockedTurbineNode(lptEta0, 0.3, 0, 0, 0, precision), etaM,
+		c.NewPressureLossNode(ductSigma),
+		0.4,
+		c.NewNozzleNode(c.ConvergentNozzle, phi, 1),
+	)
+}
+
+func getDesignGasGenerator() compose.GasGeneratorNode {
+	return compose.NewGasGeneratorNode(
+		hpcEta0, hpcPi0, fuel.GetCH4(),
+		tGas0, tFuel, sigmaBurn, etaBurn, 3, 300,
+		hptEta0, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc,
+		etaM, precision, 1, nodes.DefaultN,
+	)
+}
+
+func unitCompressorChar(normMassRate, normPiStag float64) float64 {
+	return 1
+}
+
+func chokedTurbineChar(lambdaU, normPiStag float64) float64 {
+	return 1
+}
+
+func unitTurbineChar(lambdaU, normPiStag float64) float64 {
+	return 1
+}
+
+func zeroTurbineFunc(c.TurbineNode) float64 {
+	return 0
+}
@@ -0,0 +1,348 @@
+package tf3n
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/utils"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/parametric"
+)
+
+// ThreeSpoolScheme is a separate flow turbofan with the fan, the intermediate pressure
+// and the high pressure compressors driven by their own turbines
+type ThreeSpoolScheme interface {
+	parametric.JetEngine
+	FlightCondition() source.FlightConditionSourceNode
+	Inlet() c.InletNode
+	Fan() c.ParametricCompressorNode
+	Splitter() c.GasSplitter
+	BypassDuct() c.PressureLossNode
+	BypassNozzle() c.ParametricNozzleNode
+	IPC() c.ParametricCompressorNode
+	IPCPipe() c.PressureLossNode
+	HPC() c.ParametricCompressorNode
+	HPCPipe() c.PressureLossNode
+	Burner() c.ParametricBurnerNode
+	HPT() c.ParametricTurbineNode
+	HPTPipe() c.PressureLossNode
+	IPT() c.ParametricTurbineNode
+	IPTPipe() c.PressureLossNode
+	LPT() c.ParametricTurbineNode
+	CoreNozzle() c.ParametricNozzleNode
+	TemperatureSource() source.TemperatureSourceNode
+	Assembler() graph.VectorAssemblerNode
+	Variators() []variator.Variator
+	GetNetwork() (graph.Network, error)
+}
+
+func NewThreeSpoolScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	fan c.ParametricCompressorNode,
+	splitter c.GasSplitter,
+	ipc c.ParametricCompressorNode,
+	ipcPipe c.PressureLossNode,
+	hpc c.ParametricCompressorNode,
+	hpcPipe c.PressureLossNode,
+	burner c.ParametricBurnerNode,
+	hpt c.ParametricTurbineNode,
+	hptPipe c.PressureLossNode,
+	ipt c.ParametricTurbineNode,
+	iptPipe c.PressureLossNode,
+	lpt c.ParametricTurbineNode,
+	coreNozzle c.ParametricNozzleNode,
+	bypassDuct c.PressureLossNode,
+	bypassNozzle c.ParametricNozzleNode,
+	tGas, etaM float64,
+) ThreeSpoolScheme {
+	var result = &threeSpoolScheme{
+		JetEngine: parametric.NewJetEngine(inlet, []c.BurnerNode{burner}, coreNozzle, bypassNozzle),
+
+		fanPart: parametric.NewFanPart(
+			flightCondition, inlet, fan, c.NewTransmissionNode(etaM), lpt, splitter, bypassDuct,
+		),
+		ipPart: parametric.NewTurboShaftPart(ipc, ipt, c.NewTransmissionNode(etaM)),
+		gasGeneratorPart: parametric.NewGasGeneratorPart(
+			hpc, burner, hpt, c.NewTransmissionNode(etaM), hpcPipe,
+		),
+		ipcPipe:      ipcPipe,
+		hptPipe:      hptPipe,
+		iptPipe:      iptPipe,
+		coreNozzle:   coreNozzle,
+		bypassNozzle: bypassNozzle,
+
+		burnerTemperatureSource: source.NewTemperatureSourceNode(tGas),
+
+		assembler: graph.NewVectorAssemblerNode(),
+
+		variators: []variator.Variator{
+			variator.FromCallables(flightCondition.MassRate, flightCondition.SetMassRate),
+			variator.FromCallables(fan.NormMassRate, fan.SetNormMassRate),
+			variator.FromCallables(fan.NormPiStag, fan.SetNormPiStag),
+			variator.FromCallables(splitter.ExtraWeight, splitter.SetExtraWeight),
+			variator.FromCallables(ipc.NormMassRate, ipc.SetNormMassRate),
+			variator.FromCallables(ipc.NormPiStag, ipc.SetNormPiStag),
+			variator.FromCallables(hpc.NormMassRate, hpc.SetNormMassRate),
+			variator.FromCallables(hpc.NormPiStag, hpc.SetNormPiStag),
+			variator.FromCallables(burner.FuelRateRel, burner.SetFuelRateRel),
+			variator.FromCallables(hpt.NormPiT, hpt.SetNormPiT),
+			variator.FromCallables(ipt.NormPiT, ipt.SetNormPiT),
+			variator.FromCallables(lpt.NormPiT, lpt.SetNormPiT),
+		},
+	}
+	result.linkPorts()
+	result.setEquations()
+	return result
+}
+
+type threeSpoolScheme struct {
+	parametric.JetEngine
+
+	fanPart          *parametric.FanPart
+	ipPart           *parametric.TurboShaftPart
+	gasGeneratorPart *parametric.GasGeneratorPart
+	ipcPipe          c.PressureLossNode
+	hptPipe          c.PressureLossNode
+	iptPipe          c.PressureLossNode
+	coreNozzle       c.ParametricNozzleNode
+	bypassNozzle     c.ParametricNozzleNode
+
+	burnerTemperatureSource source.TemperatureSourceNode
+
+	fanMassRateEq graph.ReduceNode
+	ipcMassRateEq graph.ReduceNode
+	hpcMassRateEq graph.ReduceNode
+	hptMassRateEq graph.ReduceNode
+	iptMassRateEq graph.ReduceNode
+	lptMassRateEq graph.ReduceNode
+	hpPowerEq     graph.ReduceNode
+	ipPowerEq     graph.ReduceNode
+	lpPowerEq     graph.ReduceNode
+	burnerEq      graph.ReduceNode
+
+	assembler graph.VectorAssemblerNode
+	variators []variator.Variator
+}
+
+func (scheme *threeSpoolScheme) FlightCondition() source.FlightConditionSourceNode {
+	return scheme.fanPart.FlightCondition
+}
+
+func (scheme *threeSpoolScheme) Inlet() c.InletNode {
+	return scheme.fanPart.Inlet
+}
+
+func (scheme *threeSpoolScheme) Fan() c.ParametricCompressorNode {
+	return scheme.fanPart.Compressor
+}
+
+func (scheme *threeSpoolScheme) Splitter() c.GasSplitter {
+	return scheme.fanPart.Splitter
+}
+
+func (scheme *threeSpoolScheme) BypassDuct() c.PressureLossNode {
+	return scheme.fanPart.BypassDuct
+}
+
+func (scheme *threeSpoolScheme) BypassNozzle() c.ParametricNozzleNode {
+	return scheme.bypassNozzle
+}
+
+func (scheme *threeSpoolScheme) IPC() c.ParametricCompressorNode {
+	return scheme.ipPart.Compressor
+}
+
+func (scheme *threeSpoolScheme) IPCPipe() c.PressureLossNode {
+	return scheme.ipcPipe
+}
+
+func (scheme *threeSpoolScheme) HPC() c.ParametricCompressorNode {
+	return scheme.gasGeneratorPart.Compressor
+}
+
+func (scheme *threeSpoolScheme) HPCPipe() c.PressureLossNode {
+	return scheme.gasGeneratorPart.CompressorPipe
+}
+
+func (scheme *threeSpoolScheme) Burner() c.ParametricBurnerNode {
+	return scheme.gasGeneratorPart.Burner
+}
+
+func (scheme *threeSpoolScheme) HPT() c.ParametricTurbineNode {
+	return scheme.gasGeneratorPart.Turbine
+}
+
+func (scheme *threeSpoolScheme) HPTPipe() c.PressureLossNode {
+	return scheme.hptPipe
+}
+
+func (scheme *threeSpoolScheme) IPT() c.ParametricTurbineNode {
+	return scheme.ipPart.Turbine
+}
+
+func (scheme *threeSpoolScheme) IPTPipe() c.PressureLossNode {
+	return scheme.iptPipe
+}
+
+func (scheme *threeSpoolScheme) LPT() c.ParametricTurbineNode {
+	return scheme.fanPart.Turbine
+}
+
+func (scheme *threeSpoolScheme) CoreNozzle() c.ParametricNozzleNode {
+	return scheme.coreNozzle
+}
+
+func (scheme *threeSpoolScheme) TemperatureSource() source.TemperatureSourceNode {
+	return scheme.burnerTemperatureSource
+}
+
+func (scheme *threeSpoolScheme) Assembler() graph.VectorAssemblerNode {
+	return scheme.assembler
+}
+
+func (scheme *threeSpoolScheme) Variators() []variator.Variator {
+	return scheme.variators
+}
+
+func (scheme *threeSpoolScheme) GetNetwork() (graph.Network, error) {
+	var result = append(scheme.fanPart.Nodes(), scheme.ipPart.Nodes()...)
+	result = append(result, scheme.gasGeneratorPart.Nodes()...)
+	result = append(
+		result, scheme.ipcPipe, scheme.hptPipe, scheme.iptPipe, scheme.coreNozzle, scheme.bypassNozzle,
+		scheme.burnerTemperatureSource, scheme.assembler,
+		scheme.fanMassRateEq, scheme.ipcMassRateEq, scheme.hpcMassRateEq,
+		scheme.hptMassRateEq, scheme.iptMassRateEq, scheme.lptMassRateEq,
+		scheme.hpPowerEq, scheme.ipPowerEq, scheme.lpPowerEq, scheme.burnerEq,
+	)
+	return graph.NewNetwork(result)
+}
+
+func (scheme *threeSpoolScheme) linkPorts() {
+	var fanPart = scheme.fanPart
+	var mainOutput = fanPart.Splitter.MainOutput()
+	var ipc = scheme.ipPart.Compressor
+	var hpc = scheme.gasGeneratorPart.Compressor
+	var ipt = scheme.ipPart.Turbine
+	var lpt = fanPart.Turbine
+
+	graph.LinkAll(
+		[]graph.Port{mainOutput.GasOutput(), mainOutput.TemperatureOutput(), mainOutput.PressureOutput()},
+		[]graph.Port{ipc.GasInput(), ipc.TemperatureInput(), ipc.PressureInput()},
+	)
+	sink.SinkAll(mainOutput.MassRateOutput(), ipc.MassRateInput())
+
+	nodes.LinkComplexOutToIn(ipc, scheme.ipcPipe)
+	graph.LinkAll(
+		[]graph.Port{scheme.ipcPipe.GasOutput(), scheme.ipcPipe.TemperatureOutput(), scheme.ipcPipe.PressureOutput()},
+		[]graph.Port{hpc.GasInput(), hpc.TemperatureInput(), hpc.PressureInput()},
+	)
+	sink.SinkAll(scheme.ipcPipe.MassRateOutput(), hpc.MassRateInput())
+
+	nodes.LinkComplexOutToIn(scheme.gasGeneratorPart.Turbine, scheme.hptPipe)
+	graph.LinkAll(
+		[]graph.Port{scheme.hptPipe.GasOutput(), scheme.hptPipe.TemperatureOutput(), scheme.hptPipe.PressureOutput()},
+		[]graph.Port{ipt.GasInput(), ipt.TemperatureInput(), ipt.PressureInput()},
+	)
+	sink.SinkAll(scheme.hptPipe.MassRateOutput(), ipt.MassRateInput())
+
+	nodes.LinkComplexOutToIn(ipt, scheme.iptPipe)
+	graph.LinkAll(
+		[]graph.Port{scheme.iptPipe.GasOutput(), scheme.iptPipe.TemperatureOutput(), scheme.iptPipe.PressureOutput()},
+		[]graph.Port{lpt.GasInput(), lpt.TemperatureInput(), lpt.PressureInput()},
+	)
+	sink.SinkAll(scheme.iptPipe.MassRateOutput(), lpt.MassRateInput())
+
+	nodes.LinkComplexOutToIn(lpt, scheme.coreNozzle)
+	nodes.LinkComplexOutToIn(fanPart.BypassDuct, scheme.bypassNozzle)
+	fanPart.LinkJet(scheme.coreNozzle)
+	fanPart.LinkJet(scheme.bypassNozzle)
+}
+
+func (scheme *threeSpoolScheme) setEquations() {
+	var fanPart = scheme.fanPart
+	var ipPart = scheme.ipPart
+	var ggPart = scheme.gasGeneratorPart
+
+	scheme.fanMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(fanPart.Inlet.MassRateOutput()),
+		graph.NewWeakPort(fanPart.Compressor.MassRateInput()),
+	)
+	scheme.fanMassRateEq.SetName("fanMassRateEq")
+
+	scheme.ipcMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(fanPart.Splitter.MainOutput().MassRateOutput()),
+		graph.NewWeakPort(ipPart.Compressor.MassRateInput()),
+	)
+	scheme.ipcMassRateEq.SetName("ipcMassRateEq")
+
+	scheme.hpcMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(scheme.ipcPipe.MassRateOutput()),
+		graph.NewWeakPort(ggPart.Compressor.MassRateInput()),
+	)
+	scheme.hpcMassRateEq.SetName("hpcMassRateEq")
+
+	scheme.hptMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(ggPart.Burner.MassRateOutput()),
+		graph.NewWeakPort(ggPart.Turbine.MassRateInput()),
+	)
+	scheme.hptMassRateEq.SetName("hptMassRateEq")
+
+	scheme.iptMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(scheme.hptPipe.MassRateOutput()),
+		graph.NewWeakPort(ipPart.Turbine.MassRateInput()),
+	)
+	scheme.iptMassRateEq.SetName("iptMassRateEq")
+
+	scheme.lptMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(scheme.iptPipe.MassRateOutput()),
+		graph.NewWeakPort(fanPart.Turbine.MassRateInput()),
+	)
+	scheme.lptMassRateEq.SetName("lptMassRateEq")
+
+	scheme.hpPowerEq = newPowerEq(ggPart.TurboShaftPart)
+	scheme.hpPowerEq.SetName("hpPowerEq")
+
+	scheme.ipPowerEq = newPowerEq(ipPart)
+	scheme.ipPowerEq.SetName("ipPowerEq")
+
+	scheme.lpPowerEq = newPowerEq(fanPart.TurboShaftPart)
+	scheme.lpPowerEq.SetName("lpPowerEq")
+
+	scheme.burnerEq = utils.NewEquality(
+		scheme.burnerTemperatureSource.TemperatureOutput(),
+		graph.NewWeakPort(ggPart.Burner.TemperatureOutput()),
+	)
+	scheme.burnerEq.SetName("burnerEq")
+
+	scheme.assembler.AddInputPorts(
+		scheme.fanMassRateEq.OutputPort(),
+		scheme.ipcMassRateEq.OutputPort(),
+		scheme.hpcMassRateEq.OutputPort(),
+		scheme.hptMassRateEq.OutputPort(),
+		scheme.iptMassRateEq.OutputPort(),
+		scheme.lptMassRateEq.OutputPort(),
+		scheme.hpPowerEq.OutputPort(),
+		scheme.ipPowerEq.OutputPort(),
+		scheme.lpPowerEq.OutputPort(),
+		scheme.burnerEq.OutputPort(),
+		scheme.coreNozzle.MassRateResidualOutput(),
+		scheme.bypassNozzle.MassRateResidualOutput(),
+	)
+	sink.SinkPort(scheme.assembler.GetVectorPort())
+}
+
+func newPowerEq(part *parametric.TurboShaftPart) graph.ReduceNode {
+	return utils.NewMultiAdderFromPorts(
+		[]graph.Port{
+			graph.NewWeakPort(part.Turbine.PowerOutput()),
+			graph.NewWeakPort(part.Turbine.MassRateInput()),
+		},
+		[]graph.Port{
+			graph.NewWeakPort(part.Shaft.PowerOutput()),
+			graph.NewWeakPort(part.Compressor.MassRateInput()),
+		},
+	)
+}
@@ -0,0 +1,156 @@
+package tf3n
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/math/solvers/newton"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/schemes"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	altitude    = 11000
+	mach        = 0.8
+	bypassRatio = 6
+
+	fanPi0 = 1.5
+	ipcPi0 = 4
+	hpcPi0 = 6
+	etaC0  = 0.88
+	rpm0   = 1e4
+
+	tGas0 = 1550
+	etaT0 = 0.9
+	dMean = 0.6
+
+	pipeSigma = 0.99
+	ductSigma = 0.98
+	phi       = 0.98
+	etaM      = 0.99
+
+	precision = 1e-6
+)
+
+func TestThreeSpoolScheme_Throttle(t *testing.T) {
+	var design = getDesignScheme()
+	var designNetwork, designErr = design.GetNetwork()
+	assert.Nil(t, designErr)
+	assert.Nil(t, designNetwork.Solve(1, 2, 100, precision))
+
+	var scheme = getScheme(design, 1400)
+	var network, err = scheme.GetNetwork()
+	assert.Nil(t, err)
+	assert.Nil(t, network.Solve(1, 2, 100, precision))
+
+	var sysCall = variator.SysCallFromNetwork(
+		network, scheme.Assembler().GetVectorPort(), 1, 2, 100, precision,
+	)
+	var solverGen = newton.NewUniformNewtonSolverGen(1e-5, newton.NoLog)
+	var variatorSolver = variator.NewVariatorSolver(sysCall, scheme.Variators(), solverGen)
+
+	_, err = variatorSolver.Solve(
+		mat.NewVecDense(12, []float64{
+			1 + bypassRatio, 1, 1, design.Splitter().ExtraWeight(),
+			1, 1, 1, 1, design.MainBurner().FuelRateRel(), 1, 1, 1,
+		}),
+		1e-7, 1, 100,
+	)
+	assert.Nil(t, err)
+
+	assert.InDelta(t, 1400, scheme.Burner().TStagOut(), 1e-3)
+	assert.True(t, scheme.NetThrust() > 0)
+	assert.True(t, scheme.NetThrust() < design.NetThrust())
+	assert.True(t, scheme.TSFC() > 0)
+	var overallPi = scheme.Fan().PiStag() * scheme.IPC().PiStag() * scheme.HPC().PiStag()
+	assert.True(t, overallPi < fanPi0*ipcPi0*hpcPi0, "%f", overallPi)
+	assert.InDelta(t, scheme.PropulsiveEfficiency()*scheme.ThermalEfficiency(), overallEfficiency(scheme), 1e-9)
+}
+
+func overallEfficiency(scheme ThreeSpoolScheme) float64 {
+	var velocity = scheme.FlightCondition().Velocity()
+	var burner = scheme.Burner()
+	var fuelMassRate = burner.MassRateInput().GetState().Value().(float64) * burner.FuelRateRel()
+	return scheme.NetThrust() * velocity / (fuelMassRate * burner.Fuel().QLower())
+}
+
+func getScheme(design schemes.ThreeSpoolTurbofanScheme, tGas float64) ThreeSpoolScheme {
+	// mass rates of the design scheme are relative to the core one, so that core mass rate is 1 kg/s
+	return NewThreeSpoolScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), altitude, mach, 0, 1+bypassRatio),
+		c.NewParametricInletNodeFromProto(design.Inlet()),
+		getCompressor(design.Fan(), 1+bypassRatio),
+		c.NewGasSplitter(design.Splitter().ExtraWeight()),
+		getCompressor(design.IPC(), 1),
+		c.NewPressureLossNode(design.IPCPipe().Sigma()),
+		getCompressor(design.HPC(), 1),
+		c.NewPressureLossNode(1),
+		c.NewParametricBurnerFromProto(design.MainBurner(), 0.2, 1, precision, 1, nodes.DefaultN),
+		getTurbine(design.HPT()),
+		c.NewPressureLossNode(design.HPTPipe().Sigma()),
+		getTurbine(design.IPT()),
+		c.NewPressureLossNode(design.IPTPipe().Sigma()),
+		getTurbine(design.LPT()),
+		c.NewParametricNozzleNodeFromProto(design.CoreNozzle()),
+		c.NewPressureLossNode(design.BypassDuct().Sigma()),
+		c.NewParametricNozzleNodeFromProto(design.BypassNozzle()),
+		tGas, etaM,
+	)
+}
+
+func getCompressor(proto c.CompressorNode, massRate0 float64) c.ParametricCompressorNode {
+	return c.NewParametricCompressorNodeFromProto(
+		proto, unitCompressorChar, unitCompressorChar, rpm0, massRate0, precision,
+	)
+}
+
+func getTurbine(proto c.StaticTurbineNode) c.ParametricTurbineNode {
+	return c.NewParametricTurbineNodeFromProto(
+		proto, unitTurbineChar, unitTurbineChar,
+		proto.MassRateInput().GetState().Value().(float64), dMean, precision,
+	)
+}
+
+func getDesignScheme() schemes.ThreeSpoolTurbofanScheme {
+	return schemes.NewThreeSpoolTurbofanScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), altitude, mach, 0, 1),
+		c.NewInletNode(c.MilSpecRecovery(0.99), 0.99),
+		c.NewCompressorNode(etaC0, fanPi0, precision),
+		bypassRatio,
+		compose.NewTurboCascadeNode(
+			etaC0, ipcPi0, etaT0, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc, etaM, precision,
+		),
+		c.NewPressureLossNode(pipeSigma),
+		compose.NewGasGeneratorNode(
+			etaC0, hpcPi0, fuel.GetCH4(),
+			tGas0, 300, 0.96, 0.99, 3, 300,
+			etaT0, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc,
+			etaM, precision, 1, nodes.DefaultN,
+		),
+		c.NewPressureLossNode(pipeSigma),
+		c.NewPressureLossNode(pipeSigma),
+		c.NewSimpleBlockedTurbineNode(etaT0, 0.3, 0, 0, 0, precision), etaM,
+		c.NewNozzleNode(c.ConvergentNozzle, phi, 1),
+		c.NewPressureLossNode(ductSigma),
+		c.NewNozzleNode(c.ConvergentNozzle, phi, 1),
+	)
+}
+
+func unitCompressorChar(normMassRate, normPiStag float64) float64 {
+	return 1
+}
+
+func unitTurbineChar(lambdaU, normPiStag float64) float64 {
+	return 1
+}
+
+func zeroTurbineFunc(c.TurbineNode) float64 {
+	return 0
+}
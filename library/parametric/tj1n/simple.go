@@ -0,0 +1,217 @@
+package tj1n
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/utils"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/parametric"
+)
+
+// TurbojetScheme is a single spool turbojet with afterburner and variable area nozzle.
+// Throat area of the nozzle is a variator, so that turbine pressure ratio set by NormPiT of
+// the turbine is kept regardless of the afterburner mode (afterburner does not affect the spool)
+type TurbojetScheme interface {
+	parametric.JetEngine
+	FlightCondition() source.FlightConditionSourceNode
+	Inlet() c.InletNode
+	Compressor() c.ParametricCompressorNode
+	CompressorPipe() c.PressureLossNode
+	Burner() c.ParametricBurnerNode
+	Turbine() c.ParametricTurbineNode
+	TurbinePipe() c.PressureLossNode
+	Afterburner() c.AfterburnerNode
+	Nozzle() c.ParametricNozzleNode
+	TemperatureSource() source.TemperatureSourceNode
+	Assembler() graph.VectorAssemblerNode
+	Variators() []variator.Variator
+	GetNetwork() (graph.Network, error)
+}
+
+func NewTurbojetScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	compressor c.ParametricCompressorNode,
+	compressorPipe c.PressureLossNode,
+	burner c.ParametricBurnerNode,
+	turbine c.ParametricTurbineNode,
+	turbinePipe c.PressureLossNode,
+	afterburner c.AfterburnerNode,
+	nozzle c.ParametricNozzleNode,
+	tGas, etaM float64,
+) TurbojetScheme {
+	var result = &turbojetScheme{
+		JetEngine: parametric.NewJetEngine(inlet, []c.BurnerNode{burner, afterburner}, nozzle),
+
+		flightCondition: flightCondition,
+		inlet:           inlet,
+		gasGeneratorPart: parametric.NewGasGeneratorPart(
+			compressor, burner, turbine, c.NewTransmissionNode(etaM), compressorPipe,
+		),
+		turbinePipe: turbinePipe,
+		afterburner: afterburner,
+		nozzle:      nozzle,
+
+		burnerTemperatureSource: source.NewTemperatureSourceNode(tGas),
+
+		assembler: graph.NewVectorAssemblerNode(),
+
+		variators: []variator.Variator{
+			variator.FromCallables(flightCondition.MassRate, flightCondition.SetMassRate),
+			variator.FromCallables(compressor.NormMassRate, compressor.SetNormMassRate),
+			variator.FromCallables(compressor.NormPiStag, compressor.SetNormPiStag),
+			variator.FromCallables(burner.FuelRateRel, burner.SetFuelRateRel),
+			variator.FromCallables(nozzle.ThroatArea, nozzle.SetThroatArea),
+		},
+	}
+	result.linkPorts()
+	result.setEquations()
+	return result
+}
+
+type turbojetScheme struct {
+	parametric.JetEngine
+
+	flightCondition  source.FlightConditionSourceNode
+	inlet            c.InletNode
+	gasGeneratorPart *parametric.GasGeneratorPart
+	turbinePipe      c.PressureLossNode
+	afterburner      c.AfterburnerNode
+	nozzle           c.ParametricNozzleNode
+
+	burnerTemperatureSource source.TemperatureSourceNode
+
+	compressorMassRateEq graph.ReduceNode
+	turbineMassRateEq    graph.ReduceNode
+	powerEq              graph.ReduceNode
+	burnerEq             graph.ReduceNode
+
+	assembler graph.VectorAssemblerNode
+	variators []variator.Variator
+}
+
+func (scheme *turbojetScheme) FlightCondition() source.FlightConditionSourceNode {
+	return scheme.flightCondition
+}
+
+func (scheme *turbojetScheme) Inlet() c.InletNode {
+	return scheme.inlet
+}
+
+func (scheme *turbojetScheme) Compressor() c.ParametricCompressorNode {
+	return scheme.gasGeneratorPart.Compressor
+}
+
+func (scheme *turbojetScheme) CompressorPipe() c.PressureLossNode {
+	return scheme.gasGeneratorPart.CompressorPipe
+}
+
+func (scheme *turbojetScheme) Burner() c.ParametricBurnerNode {
+	return scheme.gasGeneratorPart.Burner
+}
+
+func (scheme *turbojetScheme) Turbine() c.ParametricTurbineNode {
+	return scheme.gasGeneratorPart.Turbine
+}
+
+func (scheme *turbojetScheme) TurbinePipe() c.PressureLossNode {
+	return scheme.turbinePipe
+}
+
+func (scheme *turbojetScheme) Afterburner() c.AfterburnerNode {
+	return scheme.afterburner
+}
+
+func (scheme *turbojetScheme) Nozzle() c.ParametricNozzleNode {
+	return scheme.nozzle
+}
+
+func (scheme *turbojetScheme) TemperatureSource() source.TemperatureSourceNode {
+	return scheme.burnerTemperatureSource
+}
+
+func (scheme *turbojetScheme) Assembler() graph.VectorAssemblerNode {
+	return scheme.assembler
+}
+
+func (scheme *turbojetScheme) Variators() []variator.Variator {
+	return scheme.variators
+}
+
+func (scheme *turbojetScheme) GetNetwork() (graph.Network, error) {
+	return graph.NewNetwork(append(
+		scheme.gasGeneratorPart.Nodes(),
+		scheme.flightCondition, scheme.inlet, scheme.turbinePipe, scheme.afterburner, scheme.nozzle,
+		scheme.burnerTemperatureSource, scheme.assembler,
+		scheme.compressorMassRateEq, scheme.turbineMassRateEq, scheme.powerEq, scheme.burnerEq,
+	))
+}
+
+func (scheme *turbojetScheme) linkPorts() {
+	var compressor = scheme.gasGeneratorPart.Compressor
+	var turbine = scheme.gasGeneratorPart.Turbine
+
+	nodes.LinkComplexOutToIn(scheme.flightCondition, scheme.inlet)
+	graph.Link(scheme.flightCondition.VelocityOutput(), scheme.inlet.VelocityInput())
+	graph.LinkAll(
+		[]graph.Port{scheme.inlet.GasOutput(), scheme.inlet.TemperatureOutput(), scheme.inlet.PressureOutput()},
+		[]graph.Port{compressor.GasInput(), compressor.TemperatureInput(), compressor.PressureInput()},
+	)
+	sink.SinkAll(scheme.inlet.MassRateOutput(), compressor.MassRateInput(), scheme.inlet.RamDragOutput())
+
+	nodes.LinkComplexOutToIn(turbine, scheme.turbinePipe)
+	nodes.LinkComplexOutToIn(scheme.turbinePipe, scheme.afterburner)
+	nodes.LinkComplexOutToIn(scheme.afterburner, scheme.nozzle)
+
+	graph.Link(scheme.flightCondition.AmbientPressureOutput(), scheme.nozzle.AmbientPressureInput())
+	sink.SinkAll(
+		scheme.nozzle.GasOutput(), scheme.nozzle.TemperatureOutput(), scheme.nozzle.PressureOutput(),
+		scheme.nozzle.MassRateOutput(), scheme.nozzle.ThrustOutput(),
+	)
+}
+
+func (scheme *turbojetScheme) setEquations() {
+	var ggPart = scheme.gasGeneratorPart
+
+	scheme.compressorMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(scheme.inlet.MassRateOutput()),
+		graph.NewWeakPort(ggPart.Compressor.MassRateInput()),
+	)
+	scheme.compressorMassRateEq.SetName("compressorMassRateEq")
+
+	scheme.turbineMassRateEq = utils.NewEquality(
+		graph.NewWeakPort(ggPart.Burner.MassRateOutput()),
+		graph.NewWeakPort(ggPart.Turbine.MassRateInput()),
+	)
+	scheme.turbineMassRateEq.SetName("turbineMassRateEq")
+
+	scheme.powerEq = utils.NewMultiAdderFromPorts(
+		[]graph.Port{
+			graph.NewWeakPort(ggPart.Turbine.PowerOutput()),
+			graph.NewWeakPort(ggPart.Turbine.MassRateInput()),
+		},
+		[]graph.Port{
+			graph.NewWeakPort(ggPart.Shaft.PowerOutput()),
+			graph.NewWeakPort(ggPart.Compressor.MassRateInput()),
+		},
+	)
+	scheme.powerEq.SetName("powerEq")
+
+	scheme.burnerEq = utils.NewEquality(
+		scheme.burnerTemperatureSource.TemperatureOutput(),
+		graph.NewWeakPort(ggPart.Burner.TemperatureOutput()),
+	)
+	scheme.burnerEq.SetName("burnerEq")
+
+	scheme.assembler.AddInputPorts(
+		scheme.compressorMassRateEq.OutputPort(),
+		scheme.turbineMassRateEq.OutputPort(),
+		scheme.powerEq.OutputPort(),
+		scheme.burnerEq.OutputPort(),
+		scheme.nozzle.MassRateResidualOutput(),
+	)
+	sink.SinkPort(scheme.assembler.GetVectorPort())
+}
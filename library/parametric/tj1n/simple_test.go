@@ -0,0 +1,169 @@
+package tj1n
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/solvers/newton"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/schemes"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	altitude = 11000
+	mach     = 1.5
+
+	pi0   = 8
+	etaC0 = 0.86
+	rpm0  = 1.2e4
+
+	tGas0     = 1500
+	tFuel     = 300
+	sigmaBurn = 0.96
+	etaBurn   = 0.99
+	lambdaIn0 = 0.2
+
+	etaT0 = 0.9
+	dMean = 0.5
+
+	tMax       = 2000
+	abSigmaDry = 0.98
+	abSigmaWet = 0.94
+	abEta      = 0.92
+
+	pipeSigma = 0.99
+	phi       = 0.98
+	etaM      = 0.99
+
+	precision = 1e-6
+)
+
+func TestTurbojetScheme_DesignPoint(t *testing.T) {
+	var design = getDesignScheme()
+	var scheme = getScheme(design, false, tGas0)
+	var network, err = scheme.GetNetwork()
+	assert.Nil(t, err)
+	assert.Nil(t, network.Solve(1, 2, 100, precision))
+
+	// residuals vanish at design values of the variators
+	var residual = scheme.Assembler().GetVectorPort().GetState().(graph.VectorPortState).Vec
+	for i := 0; i != residual.Len(); i++ {
+		assert.InDelta(t, 0, residual.AtVec(i), 1e-3, "%d", i)
+	}
+	assert.InDelta(t, design.NetThrust(), scheme.NetThrust(), 1e-3*design.NetThrust())
+	assert.InDelta(t, design.TSFC(), scheme.TSFC(), 1e-3*design.TSFC())
+}
+
+func TestTurbojetScheme_Reheat(t *testing.T) {
+	var design = getDesignScheme()
+	var dry = getScheme(design, false, tGas0)
+	assert.Nil(t, solve(dry, getInitialGuess(design)))
+	var wet = getScheme(design, true, tGas0)
+	assert.Nil(t, solve(wet, getInitialGuess(design)))
+
+	// with turbine pressure ratio kept the spool does not feel the afterburner
+	assert.InDelta(t, design.Nozzle().ThroatArea(), dry.Nozzle().ThroatArea(), 1e-3*design.Nozzle().ThroatArea())
+	assert.InDelta(t, dry.Compressor().PiStag(), wet.Compressor().PiStag(), 1e-3)
+	assert.InDelta(t, dry.Compressor().NormMassRate(), wet.Compressor().NormMassRate(), 1e-3)
+
+	assert.InDelta(t, tMax, wet.Afterburner().TStagOut(), 1e-6)
+	assert.True(t, wet.Nozzle().ThroatArea() > dry.Nozzle().ThroatArea())
+	var areaRatio = wet.Nozzle().ExitArea() / wet.Nozzle().ThroatArea()
+	assert.InDelta(t, design.Nozzle().ExitArea()/design.Nozzle().ThroatArea(), areaRatio, 1e-9)
+
+	assert.True(t, wet.NetThrust() > dry.NetThrust())
+	assert.True(t, wet.TSFC() > dry.TSFC())
+	assert.True(t, wet.ThermalEfficiency() < dry.ThermalEfficiency())
+}
+
+func solve(scheme TurbojetScheme, init []float64) error {
+	var network, err = scheme.GetNetwork()
+	if err != nil {
+		return err
+	}
+	if err = network.Solve(1, 2, 100, precision); err != nil {
+		return err
+	}
+	var sysCall = variator.SysCallFromNetwork(
+		network, scheme.Assembler().GetVectorPort(), 1, 2, 100, precision,
+	)
+	var solverGen = newton.NewUniformNewtonSolverGen(1e-5, newton.NoLog)
+	var variatorSolver = variator.NewVariatorSolver(sysCall, scheme.Variators(), solverGen)
+
+	_, err = variatorSolver.Solve(mat.NewVecDense(len(init), init), 1e-7, 1, 100)
+	return err
+}
+
+func getInitialGuess(design schemes.TurbojetScheme) []float64 {
+	return []float64{1, 1, 1, design.MainBurner().FuelRateRel(), design.Nozzle().ThroatArea()}
+}
+
+// mass rates of the design scheme are relative to the compressor one, so that it is 1 kg/s
+func getScheme(design schemes.TurbojetScheme, lit bool, tGas float64) TurbojetScheme {
+	var afterburner = getAfterburner()
+	afterburner.SetLit(lit)
+	return NewTurbojetScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), altitude, mach, 0, 1),
+		c.NewParametricInletNodeFromProto(design.Inlet()),
+		c.NewParametricCompressorNodeFromProto(
+			design.Compressor(), unitCompressorChar, unitCompressorChar, rpm0, 1, precision,
+		),
+		c.NewPressureLossNode(1),
+		c.NewParametricBurnerFromProto(design.MainBurner(), lambdaIn0, 1, precision, 1, nodes.DefaultN),
+		c.NewParametricTurbineNodeFromProto(
+			design.Turbine(), unitTurbineChar, unitTurbineChar,
+			design.Turbine().MassRateInput().GetState().Value().(float64), dMean, precision,
+		),
+		c.NewPressureLossNode(design.TurbinePipe().Sigma()),
+		afterburner,
+		c.NewParametricNozzleNodeFromProto(design.Nozzle()),
+		tGas, etaM,
+	)
+}
+
+func getDesignScheme() schemes.TurbojetScheme {
+	var afterburner = getAfterburner()
+	afterburner.SetLit(false)
+	var scheme = schemes.NewTurbojetScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), altitude, mach, 0, 1),
+		c.NewInletNode(c.MilSpecRecovery(0.99), 0.99),
+		compose.NewGasGeneratorNode(
+			etaC0, pi0, fuel.GetCH4(),
+			tGas0, tFuel, sigmaBurn, etaBurn, 3, 300,
+			etaT0, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc,
+			etaM, precision, 1, nodes.DefaultN,
+		),
+		c.NewPressureLossNode(pipeSigma),
+		afterburner,
+		c.NewNozzleNode(c.ConvergentDivergentNozzle, phi, 1),
+	)
+	var network, _ = scheme.GetNetwork()
+	network.Solve(1, 2, 100, precision)
+	return scheme
+}
+
+func getAfterburner() c.AfterburnerNode {
+	return c.NewAfterburnerNode(
+		fuel.GetCH4(), tMax, tFuel, abSigmaDry, abSigmaWet, abEta, 3, 300, precision, 1, nodes.DefaultN,
+	)
+}
+
+func unitCompressorChar(normMassRate, normPiStag float64) float64 {
+	return 1
+}
+
+func unitTurbineChar(lambdaU, normPiStag float64) float64 {
+	return 1
+}
+
+func zeroTurbineFunc(c.TurbineNode) float64 {
+	return 0
+}
@@ -0,0 +1,68 @@
+package parametric
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	c "github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+)
+
+// NewFanPart links free stream to the fan through the inlet, fan to the splitter
+// and extra output of the splitter to the bypass duct. Fan is driven by the low pressure turbine.
+// Mass rate of the free stream differs from the one of the fan until the scheme is solved,
+// so that mass rate ports of the inlet and the fan are left for the equations
+func NewFanPart(
+	flightCondition source.FlightConditionSourceNode,
+	inlet c.InletNode,
+	fan c.ParametricCompressorNode,
+	shaft c.TransmissionNode,
+	lpt c.ParametricTurbineNode,
+	splitter c.GasSplitter,
+	bypassDuct c.PressureLossNode,
+) *FanPart {
+	var result = &FanPart{
+		TurboShaftPart:  NewTurboShaftPart(fan, lpt, shaft),
+		FlightCondition: flightCondition,
+		Inlet:           inlet,
+		Splitter:        splitter,
+		BypassDuct:      bypassDuct,
+	}
+	nodes.LinkComplexOutToIn(flightCondition, inlet)
+	graph.Link(flightCondition.VelocityOutput(), inlet.VelocityInput())
+	graph.LinkAll(
+		[]graph.Port{inlet.GasOutput(), inlet.TemperatureOutput(), inlet.PressureOutput()},
+		[]graph.Port{fan.GasInput(), fan.TemperatureInput(), fan.PressureInput()},
+	)
+	sink.SinkAll(inlet.MassRateOutput(), fan.MassRateInput())
+	nodes.LinkComplexOutToIn(fan, splitter.Input())
+	nodes.LinkComplexOutToIn(splitter.ExtraOutput(), bypassDuct)
+
+	// ambient pressure is passed to the nozzles by weak ports
+	sink.SinkAll(inlet.RamDragOutput(), flightCondition.AmbientPressureOutput())
+	return result
+}
+
+type FanPart struct {
+	*TurboShaftPart
+	FlightCondition source.FlightConditionSourceNode
+	Inlet           c.InletNode
+	Splitter        c.GasSplitter
+	BypassDuct      c.PressureLossNode
+}
+
+// LinkJet links nozzle to the ambient pressure and sinks its outputs
+func (part *FanPart) LinkJet(nozzle c.NozzleNode) {
+	graph.Link(graph.NewWeakPort(part.FlightCondition.AmbientPressureOutput()), nozzle.AmbientPressureInput())
+	sink.SinkAll(
+		nozzle.GasOutput(), nozzle.TemperatureOutput(), nozzle.PressureOutput(), nozzle.MassRateOutput(),
+		nozzle.ThrustOutput(),
+	)
+}
+
+func (part *FanPart) Nodes() []graph.Node {
+	return append(
+		part.TurboShaftPart.Nodes(),
+		part.FlightCondition, part.Inlet, part.Splitter, part.BypassDuct,
+	)
+}
@@ -0,0 +1,187 @@
+package schemes
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/material/water"
+)
+
+// NewCombinedCycleScheme returns two shafts gas turbine with exhaust heat recovered by the HRSG.
+// Every pressure level of the HRSG has its own steam turbine section: steam of the level is admitted
+// to the exhaust of the previous section and expands to the pressure of the next level,
+// the last section expands to condenser pressure. Feed water temperature of the HRSG
+// is expected to be close to the condensate one at condenser pressure.
+// The function panics if condenser pressure is out of the range of water properties
+func NewCombinedCycleScheme(
+	gasSource source.ComplexGasSourceNode,
+	inletPressureDrop constructive.PressureLossNode,
+	gasGenerator compose.GasGeneratorNode,
+	compressorTurbinePipe constructive.PressureLossNode,
+	freeTurbineBlock compose.FreeTurbineBlockNode,
+	hrsg constructive.HRSGNode,
+	steamTurbines []constructive.SteamTurbineNode,
+	condenserPressure, etaPump float64,
+) CombinedCycleScheme {
+	var levels = hrsg.Levels()
+	if len(steamTurbines) != len(levels) {
+		panic(fmt.Sprintf(
+			"got %d steam turbines for %d hrsg pressure levels", len(steamTurbines), len(levels),
+		))
+	}
+
+	var condensate, err = water.SaturatedLiquid(condenserPressure)
+	if err != nil {
+		panic(fmt.Sprintf("invalid condenser pressure: %s", err.Error()))
+	}
+
+	var result = &combinedCycleScheme{
+		twoShaftsScheme: twoShaftsScheme{
+			gasSource:             gasSource,
+			inletPressureDrop:     inletPressureDrop,
+			gasGenerator:          gasGenerator,
+			compressorTurbinePipe: compressorTurbinePipe,
+			freeTurbineBlock:      freeTurbineBlock,
+		},
+		hrsg:              hrsg,
+		steamTurbines:     steamTurbines,
+		condenserPressure: condenserPressure,
+		condensateV:       condensate.V,
+		etaPump:           etaPump,
+	}
+	for i, turbine := range steamTurbines {
+		if i < len(levels)-1 {
+			turbine.SetPOut(levels[i+1].P)
+		} else {
+			turbine.SetPOut(condenserPressure)
+		}
+		if i > 0 {
+			result.steamMixers = append(result.steamMixers, constructive.NewSteamMixerNode())
+		}
+	}
+	return result
+}
+
+type CombinedCycleScheme interface {
+	TwoShaftsScheme
+	HRSG() constructive.HRSGNode
+	SteamTurbines() []constructive.SteamTurbineNode
+	CondenserPressure() float64
+
+	GasTurbinePower() float64
+	SteamTurbinePower() float64
+	// PumpPower is labour of feed water pumps of all the HRSG levels
+	PumpPower() float64
+	GasTurbineEfficiency() float64
+	CombinedCycleEfficiency() float64
+}
+
+type combinedCycleScheme struct {
+	twoShaftsScheme
+
+	hrsg              constructive.HRSGNode
+	steamTurbines     []constructive.SteamTurbineNode
+	steamMixers       []constructive.SteamMixerNode
+	condenserPressure float64
+	condensateV       float64
+	etaPump           float64
+
+	steamSinks []sink.SinkNode
+}
+
+func (scheme *combinedCycleScheme) HRSG() constructive.HRSGNode {
+	return scheme.hrsg
+}
+
+func (scheme *combinedCycleScheme) SteamTurbines() []constructive.SteamTurbineNode {
+	return scheme.steamTurbines
+}
+
+func (scheme *combinedCycleScheme) CondenserPressure() float64 {
+	return scheme.condenserPressure
+}
+
+func (scheme *combinedCycleScheme) GasTurbinePower() float64 {
+	return scheme.twoShaftsScheme.GetSpecificPower()
+}
+
+func (scheme *combinedCycleScheme) SteamTurbinePower() float64 {
+	var result float64
+	for _, turbine := range scheme.steamTurbines {
+		result += turbine.Power()
+	}
+	return result
+}
+
+func (scheme *combinedCycleScheme) PumpPower() float64 {
+	var result float64
+	for i, level := range scheme.hrsg.Levels() {
+		result += scheme.hrsg.SteamMassRate(i) * scheme.condensateV * (level.P - scheme.condenserPressure) / scheme.etaPump
+	}
+	return result
+}
+
+func (scheme *combinedCycleScheme) GasTurbineEfficiency() float64 {
+	return GetEfficiency(&scheme.twoShaftsScheme)
+}
+
+func (scheme *combinedCycleScheme) CombinedCycleEfficiency() float64 {
+	return GetEfficiency(scheme)
+}
+
+func (scheme *combinedCycleScheme) GetSpecificPower() float64 {
+	return scheme.GasTurbinePower() + scheme.SteamTurbinePower() - scheme.PumpPower()
+}
+
+func (scheme *combinedCycleScheme) GetNetwork() (graph.Network, graph.GraphError) {
+	scheme.linkPorts()
+
+	var networkNodes = []graph.Node{
+		scheme.gasSource, scheme.inletPressureDrop, scheme.gasGenerator,
+		scheme.compressorTurbinePipe, scheme.freeTurbineBlock, scheme.hrsg,
+		scheme.gasSink, scheme.temperatureSink, scheme.pressureSink, scheme.massRateSink, scheme.powerSink,
+	}
+	for _, turbine := range scheme.steamTurbines {
+		networkNodes = append(networkNodes, turbine)
+	}
+	for _, mixer := range scheme.steamMixers {
+		networkNodes = append(networkNodes, mixer)
+	}
+	for _, steamSink := range scheme.steamSinks {
+		networkNodes = append(networkNodes, steamSink)
+	}
+	return graph.NewNetwork(networkNodes)
+}
+
+func (scheme *combinedCycleScheme) linkPorts() {
+	nodes.LinkComplexOutToIn(scheme.gasSource, scheme.inletPressureDrop)
+	nodes.LinkComplexOutToIn(scheme.inletPressureDrop, scheme.gasGenerator)
+	nodes.LinkComplexOutToIn(scheme.gasGenerator, scheme.compressorTurbinePipe)
+	nodes.LinkComplexOutToIn(scheme.compressorTurbinePipe, scheme.freeTurbineBlock)
+	nodes.LinkComplexOutToIn(scheme.freeTurbineBlock, scheme.hrsg)
+
+	scheme.gasSink = sink.SinkPort(scheme.hrsg.GasOutput())
+	scheme.temperatureSink = sink.SinkPort(scheme.hrsg.TemperatureOutput())
+	scheme.pressureSink = sink.SinkPort(scheme.hrsg.PressureOutput())
+	scheme.massRateSink = sink.SinkPort(scheme.hrsg.MassRateOutput())
+	scheme.powerSink = sink.SinkPort(scheme.freeTurbineBlock.PowerOutput())
+
+	nodes.LinkSteamOutToIn(scheme.hrsg.SteamOutput(0), scheme.steamTurbines[0])
+	for i := 1; i < len(scheme.steamTurbines); i++ {
+		var mixer = scheme.steamMixers[i-1]
+		nodes.LinkSteamOutToIn(scheme.steamTurbines[i-1], mixer.MainInput())
+		nodes.LinkSteamOutToIn(scheme.hrsg.SteamOutput(i), mixer.ExtraInput())
+		nodes.LinkSteamOutToIn(mixer, scheme.steamTurbines[i])
+	}
+
+	var lastTurbine = scheme.steamTurbines[len(scheme.steamTurbines)-1]
+	scheme.steamSinks = sink.SinkAll(lastTurbine.SteamOutput(), lastTurbine.MassRateOutput())
+	for _, turbine := range scheme.steamTurbines {
+		scheme.steamSinks = append(scheme.steamSinks, sink.SinkPort(turbine.PowerOutput()))
+	}
+}
@@ -0,0 +1,87 @@
+package schemes
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/water"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ccCondenserPressure = 5e3
+	ccEtaSteamTurbine   = 0.88
+)
+
+func TestCombinedCycleScheme_SinglePressure(t *testing.T) {
+	var scheme = getCombinedCycleScheme([]constructive.HRSGLevel{{P: 8e6, TSteam: 800}})
+	var network, networkErr = scheme.GetNetwork()
+	assert.Nil(t, networkErr)
+	assert.Nil(t, network.Solve(0.2, 1, 100, 1e-4))
+
+	var gtEfficiency = scheme.GasTurbineEfficiency()
+	var ccEfficiency = scheme.CombinedCycleEfficiency()
+	assert.True(t, gtEfficiency > 0.2 && gtEfficiency < 0.4, "%f", gtEfficiency)
+	assert.True(t, ccEfficiency > gtEfficiency*1.2, "gt: %f, cc: %f", gtEfficiency, ccEfficiency)
+	assert.True(t, ccEfficiency < 0.6, "%f", ccEfficiency)
+	assert.InDelta(t, GetEfficiency(scheme), ccEfficiency, 1e-12)
+
+	assert.True(t, scheme.SteamTurbinePower() > 0)
+	assert.True(t, scheme.PumpPower() > 0)
+	assert.True(t, scheme.PumpPower() < 0.05*scheme.SteamTurbinePower())
+	assert.InDelta(
+		t, scheme.GasTurbinePower()+scheme.SteamTurbinePower()-scheme.PumpPower(), scheme.GetSpecificPower(), 1e-9,
+	)
+	assert.InDelta(t, ccCondenserPressure, scheme.SteamTurbines()[0].POut(), 1e-9)
+}
+
+func TestCombinedCycleScheme_DualPressure(t *testing.T) {
+	var single = getCombinedCycleScheme([]constructive.HRSGLevel{{P: 8e6, TSteam: 800}})
+	var dual = getCombinedCycleScheme([]constructive.HRSGLevel{{P: 8e6, TSteam: 800}, {P: 4e5, TSteam: 430}})
+	for _, scheme := range []CombinedCycleScheme{single, dual} {
+		var network, networkErr = scheme.GetNetwork()
+		assert.Nil(t, networkErr)
+		assert.Nil(t, network.Solve(0.2, 1, 100, 1e-4))
+	}
+
+	assert.InDelta(t, 4e5, dual.SteamTurbines()[0].POut(), 1e-9)
+	assert.InDelta(t, ccCondenserPressure, dual.SteamTurbines()[1].POut(), 1e-9)
+	// low pressure turbine expands steam of both levels
+	var lpMassRate = dual.SteamTurbines()[1].MassRateInput().GetState().Value().(float64)
+	assert.InDelta(t, dual.HRSG().SteamMassRate(0)+dual.HRSG().SteamMassRate(1), lpMassRate, 1e-9)
+
+	assert.True(t, dual.HRSG().StackTemperature() < single.HRSG().StackTemperature())
+	assert.True(
+		t, dual.CombinedCycleEfficiency() > single.CombinedCycleEfficiency(),
+		"single: %f, dual: %f", single.CombinedCycleEfficiency(), dual.CombinedCycleEfficiency(),
+	)
+}
+
+func TestCombinedCycleScheme_InvalidCondenserPressure(t *testing.T) {
+	var gt = getTwoShaftsScheme(source.NewComplexGasSourceNode(gases.GetAir(), 288, 1e5, 1)).(*twoShaftsScheme)
+	var levels = []constructive.HRSGLevel{{P: 8e6, TSteam: 800}}
+	assert.Panics(t, func() {
+		NewCombinedCycleScheme(
+			gt.gasSource, gt.inletPressureDrop, gt.gasGenerator, gt.compressorTurbinePipe, gt.freeTurbineBlock,
+			constructive.NewHRSGNode(levels, 310, 10, 5, 0.97),
+			[]constructive.SteamTurbineNode{constructive.NewSteamTurbineNode(ccEtaSteamTurbine, 1e8)}, 1e8, 0.8,
+		)
+	})
+}
+
+func getCombinedCycleScheme(levels []constructive.HRSGLevel) CombinedCycleScheme {
+	var gt = getTwoShaftsScheme(source.NewComplexGasSourceNode(gases.GetAir(), 288, 1e5, 1)).(*twoShaftsScheme)
+	var tCondensate, _ = water.SaturationTemperature(ccCondenserPressure)
+
+	var steamTurbines []constructive.SteamTurbineNode
+	for range levels {
+		steamTurbines = append(steamTurbines, constructive.NewSteamTurbineNode(ccEtaSteamTurbine, ccCondenserPressure))
+	}
+	return NewCombinedCycleScheme(
+		gt.gasSource, gt.inletPressureDrop, gt.gasGenerator, gt.compressorTurbinePipe, gt.freeTurbineBlock,
+		constructive.NewHRSGNode(levels, tCondensate+5, 10, 5, 0.97),
+		steamTurbines, ccCondenserPressure, 0.8,
+	)
+}
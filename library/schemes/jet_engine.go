@@ -0,0 +1,181 @@
+package schemes
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+)
+
+// JetEngine provides performance of the engine producing thrust.
+// It is shared by design schemes and off-design (parametric) schemes
+type JetEngine interface {
+	// NetThrust is net thrust per unit mass rate of the scheme, N * s / kg
+	NetThrust() float64
+	// TSFC is thrust specific fuel consumption, kg / (N * h)
+	TSFC() float64
+	PropulsiveEfficiency() float64
+	ThermalEfficiency() float64
+	// JetPower is gain of the kinetic power of the flow passing the engine.
+	// Jet velocities are effective ones, i.e. they include pressure thrust
+	JetPower() float64
+	FuelMassRate() float64
+	// FuelHeat is lower heat of the fuel burnt in all the burners
+	FuelHeat() float64
+}
+
+// NewJetEngine returns performance of the jet engine with free stream captured by the inlet
+// and fuel burnt in the burners. Jets are nozzles exhausting to the atmosphere
+func NewJetEngine(inlet constructive.InletNode, burners []constructive.BurnerNode, jets ...constructive.NozzleNode) JetEngine {
+	return &jetEngine{
+		inlet:   inlet,
+		burners: burners,
+		jets:    jets,
+	}
+}
+
+type jetEngine struct {
+	inlet   constructive.InletNode
+	burners []constructive.BurnerNode
+	jets    []constructive.NozzleNode
+}
+
+func (engine *jetEngine) NetThrust() float64 {
+	var thrust = -engine.inlet.RamDrag()
+	for _, jet := range engine.jets {
+		thrust += jet.GrossThrust()
+	}
+	return thrust
+}
+
+func (engine *jetEngine) TSFC() float64 {
+	return 3600 * engine.FuelMassRate() / engine.NetThrust()
+}
+
+func (engine *jetEngine) PropulsiveEfficiency() float64 {
+	return engine.NetThrust() * engine.velocity() / engine.JetPower()
+}
+
+func (engine *jetEngine) ThermalEfficiency() float64 {
+	return engine.JetPower() / engine.FuelHeat()
+}
+
+func (engine *jetEngine) JetPower() float64 {
+	var velocity = engine.velocity()
+	var power = -engine.inletMassRate() * velocity * velocity / 2
+	for _, jet := range engine.jets {
+		var massRate = jet.MassRateInput().GetState().(states.MassRatePortState).MassRate
+		var c = jet.GrossThrust() / massRate
+		power += massRate * c * c / 2
+	}
+	return power
+}
+
+func (engine *jetEngine) FuelMassRate() float64 {
+	var result = 0.
+	for _, burner := range engine.burners {
+		result += fuelMassRate(burner)
+	}
+	return result
+}
+
+func (engine *jetEngine) FuelHeat() float64 {
+	var result = 0.
+	for _, burner := range engine.burners {
+		result += fuelMassRate(burner) * burner.Fuel().QLower()
+	}
+	return result
+}
+
+func (engine *jetEngine) velocity() float64 {
+	return engine.inlet.VelocityInput().GetState().Value().(float64)
+}
+
+func (engine *jetEngine) inletMassRate() float64 {
+	return engine.inlet.MassRateInput().GetState().(states.MassRatePortState).MassRate
+}
+
+func fuelMassRate(burner constructive.BurnerNode) float64 {
+	var massRateRel = burner.MassRateInput().GetState().(states.MassRatePortState).MassRate
+	return burner.FuelRateRel() * massRateRel
+}
+
+// JetEngineScheme is a design point scheme of engine producing thrust.
+// GetSpecificPower returns gain of the jet kinetic power, so that GetEfficiency returns thermal efficiency
+type JetEngineScheme interface {
+	Scheme
+	JetEngine
+	FlightCondition() source.FlightConditionSourceNode
+	Inlet() constructive.InletNode
+
+	// SpecificThrust is net thrust per unit inlet mass rate, N * s / kg
+	SpecificThrust() float64
+}
+
+// jetEngineScheme holds free stream, inlet, burners and exhaust nozzles of the jet engine
+type jetEngineScheme struct {
+	*jetEngine
+	flightCondition source.FlightConditionSourceNode
+}
+
+func newJetEngineScheme(flightCondition source.FlightConditionSourceNode, inlet constructive.InletNode) jetEngineScheme {
+	return jetEngineScheme{
+		jetEngine:       &jetEngine{inlet: inlet},
+		flightCondition: flightCondition,
+	}
+}
+
+func (engine *jetEngineScheme) FlightCondition() source.FlightConditionSourceNode {
+	return engine.flightCondition
+}
+
+func (engine *jetEngineScheme) Inlet() constructive.InletNode {
+	return engine.inlet
+}
+
+func (engine *jetEngineScheme) SpecificThrust() float64 {
+	return engine.NetThrust() / engine.inletMassRate()
+}
+
+// GetSpecificPower returns gain of kinetic power of the flow passing the engine
+func (engine *jetEngineScheme) GetSpecificPower() float64 {
+	return engine.JetPower()
+}
+
+func (engine *jetEngineScheme) GetFuelMassRateRel() float64 {
+	return engine.FuelMassRate()
+}
+
+// GetQLower returns lower heating value averaged over fuel burnt in all the burners
+func (engine *jetEngineScheme) GetQLower() float64 {
+	var fuelRate = engine.FuelMassRate()
+	if fuelRate == 0 {
+		return engine.burners[0].Fuel().QLower()
+	}
+	return engine.FuelHeat() / fuelRate
+}
+
+func (engine *jetEngineScheme) nodes() []graph.Node {
+	return []graph.Node{engine.flightCondition, engine.inlet}
+}
+
+// linkInlet links free stream to the inlet
+func (engine *jetEngineScheme) linkInlet() {
+	nodes.LinkComplexOutToIn(engine.flightCondition, engine.inlet)
+	graph.Link(engine.flightCondition.VelocityOutput(), engine.inlet.VelocityInput())
+
+	// ambient pressure is passed to the nozzles by weak ports
+	sink.SinkAll(engine.inlet.RamDragOutput(), engine.flightCondition.AmbientPressureOutput())
+}
+
+// linkJet links nozzle to the ambient pressure and sinks its outputs
+func (engine *jetEngineScheme) linkJet(nozzle constructive.NozzleNode) {
+	engine.jets = append(engine.jets, nozzle)
+	graph.Link(graph.NewWeakPort(engine.flightCondition.AmbientPressureOutput()), nozzle.AmbientPressureInput())
+	sink.SinkAll(
+		nozzle.GasOutput(), nozzle.TemperatureOutput(), nozzle.PressureOutput(), nozzle.MassRateOutput(),
+		nozzle.ThrustOutput(),
+	)
+}
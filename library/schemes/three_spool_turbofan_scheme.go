@@ -0,0 +1,146 @@
+package schemes
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/helper"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// ThreeSpoolTurbofanScheme is a separate flow turbofan with the fan driven by the low pressure turbine
+// and the core compressed by the intermediate and the high pressure spools
+type ThreeSpoolTurbofanScheme interface {
+	TurbofanScheme
+	DoubleCompressor
+	IPC() constructive.CompressorNode
+	IPT() constructive.BlockedTurbineNode
+	HPT() constructive.StaticTurbineNode
+	IPCPipe() constructive.PressureLossNode
+	HPTPipe() constructive.PressureLossNode
+	IPTPipe() constructive.PressureLossNode
+	MiddlePressureCascade() compose.TurboCascadeNode
+	GasGenerator() compose.GasGeneratorNode
+	CoreNozzle() constructive.NozzleNode
+	BypassNozzle() constructive.NozzleNode
+}
+
+func NewThreeSpoolTurbofanScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	fan constructive.CompressorNode,
+	bypassRatio float64,
+	middlePressureCascade compose.TurboCascadeNode,
+	ipcPipe constructive.PressureLossNode,
+	gasGenerator compose.GasGeneratorNode,
+	hptPipe constructive.PressureLossNode,
+	iptPipe constructive.PressureLossNode,
+	lpt constructive.BlockedTurbineNode,
+	etaM float64,
+	coreNozzle constructive.NozzleNode,
+	bypassDuct constructive.PressureLossNode,
+	bypassNozzle constructive.NozzleNode,
+) ThreeSpoolTurbofanScheme {
+	var result = &threeSpoolTurbofanScheme{
+		turbofan:              newTurbofan(flightCondition, inlet, fan, bypassRatio, bypassDuct, lpt, etaM),
+		middlePressureCascade: middlePressureCascade,
+		ipcPipe:               ipcPipe,
+		gasGenerator:          gasGenerator,
+		hptPipe:               hptPipe,
+		iptPipe:               iptPipe,
+		coreNozzle:            coreNozzle,
+		bypassNozzle:          bypassNozzle,
+		breaker: helper.NewComplexCycleBreakNode(
+			gases.GetAir(), states.StandardTemperature, states.StandardPressure, 1,
+		),
+	}
+	result.burners = append(result.burners, gasGenerator.Burner())
+
+	result.linkFan()
+	nodes.LinkComplexOutToIn(result.splitter.MainOutput(), middlePressureCascade.CompressorComplexGasInput())
+	nodes.LinkComplexOutToIn(middlePressureCascade.CompressorComplexGasOutput(), ipcPipe)
+	nodes.LinkComplexOutToIn(ipcPipe, result.breaker)
+	nodes.LinkComplexOutToIn(result.breaker, gasGenerator)
+	nodes.LinkComplexOutToIn(gasGenerator, hptPipe)
+	nodes.LinkComplexOutToIn(hptPipe, middlePressureCascade.TurbineComplexGasInput())
+	nodes.LinkComplexOutToIn(middlePressureCascade.TurbineComplexGasOutput(), iptPipe)
+	nodes.LinkComplexOutToIn(iptPipe, lpt)
+	nodes.LinkComplexOutToIn(lpt, coreNozzle)
+	nodes.LinkComplexOutToIn(bypassDuct, bypassNozzle)
+
+	result.linkJet(coreNozzle)
+	result.linkJet(bypassNozzle)
+	return result
+}
+
+type threeSpoolTurbofanScheme struct {
+	turbofan
+	middlePressureCascade compose.TurboCascadeNode
+	ipcPipe               constructive.PressureLossNode
+	gasGenerator          compose.GasGeneratorNode
+	hptPipe               constructive.PressureLossNode
+	iptPipe               constructive.PressureLossNode
+	coreNozzle            constructive.NozzleNode
+	bypassNozzle          constructive.NozzleNode
+
+	breaker helper.ComplexCycleBreakNode
+}
+
+func (scheme *threeSpoolTurbofanScheme) LPC() constructive.CompressorNode {
+	return scheme.IPC()
+}
+
+func (scheme *threeSpoolTurbofanScheme) HPC() constructive.CompressorNode {
+	return scheme.gasGenerator.TurboCascade().Compressor()
+}
+
+func (scheme *threeSpoolTurbofanScheme) IPC() constructive.CompressorNode {
+	return scheme.middlePressureCascade.Compressor()
+}
+
+func (scheme *threeSpoolTurbofanScheme) IPT() constructive.BlockedTurbineNode {
+	return scheme.middlePressureCascade.Turbine()
+}
+
+func (scheme *threeSpoolTurbofanScheme) HPT() constructive.StaticTurbineNode {
+	return scheme.gasGenerator.TurboCascade().Turbine()
+}
+
+func (scheme *threeSpoolTurbofanScheme) IPCPipe() constructive.PressureLossNode {
+	return scheme.ipcPipe
+}
+
+func (scheme *threeSpoolTurbofanScheme) HPTPipe() constructive.PressureLossNode {
+	return scheme.hptPipe
+}
+
+func (scheme *threeSpoolTurbofanScheme) IPTPipe() constructive.PressureLossNode {
+	return scheme.iptPipe
+}
+
+func (scheme *threeSpoolTurbofanScheme) MiddlePressureCascade() compose.TurboCascadeNode {
+	return scheme.middlePressureCascade
+}
+
+func (scheme *threeSpoolTurbofanScheme) GasGenerator() compose.GasGeneratorNode {
+	return scheme.gasGenerator
+}
+
+func (scheme *threeSpoolTurbofanScheme) CoreNozzle() constructive.NozzleNode {
+	return scheme.coreNozzle
+}
+
+func (scheme *threeSpoolTurbofanScheme) BypassNozzle() constructive.NozzleNode {
+	return scheme.bypassNozzle
+}
+
+func (scheme *threeSpoolTurbofanScheme) GetNetwork() (graph.Network, graph.GraphError) {
+	return graph.NewNetwork(append(
+		scheme.turbofan.nodes(),
+		scheme.middlePressureCascade, scheme.ipcPipe, scheme.breaker, scheme.gasGenerator,
+		scheme.hptPipe, scheme.iptPipe, scheme.coreNozzle, scheme.bypassNozzle,
+	))
+}
@@ -0,0 +1,50 @@
+package schemes
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreeSpoolTurbofanScheme_Cruise(t *testing.T) {
+	var scheme = NewThreeSpoolTurbofanScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), 11000, 0.8, 0, 1),
+		constructive.NewInletNode(constructive.MilSpecRecovery(0.99), 1),
+		constructive.NewCompressorNode(0.9, 1.5, 0.05),
+		6,
+		compose.NewTurboCascadeNode(
+			0.88, 4, 0.91, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc, 0.99, 0.05,
+		),
+		constructive.NewPressureLossNode(0.99),
+		getTurbofanGasGenerator(),
+		constructive.NewPressureLossNode(0.99),
+		constructive.NewPressureLossNode(0.99),
+		getTurbofanLPT(), 0.99,
+		constructive.NewNozzleNode(constructive.ConvergentNozzle, 0.98, 1),
+		constructive.NewPressureLossNode(0.98),
+		constructive.NewNozzleNode(constructive.ConvergentNozzle, 0.98, 1),
+	)
+	var network, networkErr = scheme.GetNetwork()
+	assert.Nil(t, networkErr)
+	assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+
+	var ipcMassRate = scheme.IPC().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 1, ipcMassRate, 1e-9)
+	var bypassMassRate = scheme.BypassNozzle().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 6, bypassMassRate, 1e-9)
+
+	// overall pressure ratio is the product of the spool ones
+	var pIn = scheme.Fan().PressureInput().GetState().(states.PressurePortState).PStag
+	var pOut = scheme.HPC().PressureOutput().GetState().(states.PressurePortState).PStag
+	assert.InDelta(t, 1.5*4*0.99*18, pOut/pIn, 1e-6)
+
+	assert.True(t, scheme.NetThrust() > 0)
+	var etaTh = scheme.ThermalEfficiency()
+	assert.True(t, etaTh > 0.4 && etaTh < 0.7, "%f", etaTh)
+	assert.True(t, scheme.PropulsiveEfficiency() > 0.5)
+}
@@ -0,0 +1,150 @@
+package schemes
+
+import (
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+)
+
+// TurbofanScheme is a design point scheme of turbofan. Mass rates of the scheme are relative to
+// the core mass rate, so the flight condition source issues 1 + bypassRatio
+type TurbofanScheme interface {
+	JetEngineScheme
+	Fan() constructive.CompressorNode
+	Splitter() constructive.GasSplitter
+	BypassDuct() constructive.PressureLossNode
+	LPT() constructive.BlockedTurbineNode
+	MainBurner() constructive.BurnerNode
+
+	BypassRatio() float64
+	SetBypassRatio(bypassRatio float64)
+}
+
+// turbofan holds nodes common for all the turbofan schemes
+type turbofan struct {
+	jetEngineScheme
+	fan        constructive.CompressorNode
+	fanShaft   *fanShaftNode
+	splitter   constructive.GasSplitter
+	bypassDuct constructive.PressureLossNode
+	lpt        constructive.BlockedTurbineNode
+
+	bypassRatio float64
+}
+
+func newTurbofan(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	fan constructive.CompressorNode,
+	bypassRatio float64,
+	bypassDuct constructive.PressureLossNode,
+	lpt constructive.BlockedTurbineNode,
+	etaM float64,
+) turbofan {
+	var result = turbofan{
+		jetEngineScheme: newJetEngineScheme(flightCondition, inlet),
+		fan:             fan,
+		fanShaft:        newFanShaftNode(etaM),
+		splitter:        constructive.NewGasSplitter(0),
+		bypassDuct:      bypassDuct,
+		lpt:             lpt,
+	}
+	result.SetBypassRatio(bypassRatio)
+	return result
+}
+
+func (tf *turbofan) Fan() constructive.CompressorNode {
+	return tf.fan
+}
+
+func (tf *turbofan) Splitter() constructive.GasSplitter {
+	return tf.splitter
+}
+
+func (tf *turbofan) BypassDuct() constructive.PressureLossNode {
+	return tf.bypassDuct
+}
+
+func (tf *turbofan) LPT() constructive.BlockedTurbineNode {
+	return tf.lpt
+}
+
+func (tf *turbofan) MainBurner() constructive.BurnerNode {
+	return tf.burners[0]
+}
+
+func (tf *turbofan) BypassRatio() float64 {
+	return tf.bypassRatio
+}
+
+func (tf *turbofan) SetBypassRatio(bypassRatio float64) {
+	tf.bypassRatio = bypassRatio
+	tf.flightCondition.SetMassRate(1 + bypassRatio)
+	tf.splitter.SetExtraWeight(bypassRatio / (1 + bypassRatio))
+}
+
+func (tf *turbofan) nodes() []graph.Node {
+	return append(tf.jetEngineScheme.nodes(), tf.fan, tf.fanShaft, tf.splitter, tf.bypassDuct, tf.lpt)
+}
+
+// linkFan links free stream to the fan and fan to the splitter and the low pressure turbine
+func (tf *turbofan) linkFan() {
+	tf.linkInlet()
+	nodes.LinkComplexOutToIn(tf.inlet, tf.fan)
+	nodes.LinkComplexOutToIn(tf.fan, tf.splitter.Input())
+	nodes.LinkComplexOutToIn(tf.splitter.ExtraOutput(), tf.bypassDuct)
+
+	graph.Link(tf.fan.PowerOutput(), tf.fanShaft.powerInput)
+	graph.Link(graph.NewWeakPort(tf.fan.MassRateOutput()), tf.fanShaft.massRateInput)
+	graph.Link(tf.fanShaft.powerOutput, tf.lpt.PowerInput())
+	sink.SinkPort(tf.lpt.PowerOutput())
+}
+
+// fanShaftNode transmits labour of the fan to the low pressure turbine. Unlike TransmissionNode
+// it accounts for the fan mass rate, since labour of turbines is related to the core mass rate
+type fanShaftNode struct {
+	graph.BaseNode
+
+	powerInput    graph.Port
+	massRateInput graph.Port
+	powerOutput   graph.Port
+
+	etaM float64
+}
+
+func newFanShaftNode(etaM float64) *fanShaftNode {
+	var result = &fanShaftNode{etaM: etaM}
+	graph.AttachAllWithTags(
+		result,
+		[]*graph.Port{&result.powerInput, &result.massRateInput, &result.powerOutput},
+		[]string{nodes.PowerInputTag, nodes.MassRateInputTag, nodes.PowerOutputTag},
+	)
+	return result
+}
+
+func (node *fanShaftNode) GetName() string {
+	return common.EitherString(node.GetInstanceName(), "FanShaft")
+}
+
+func (node *fanShaftNode) GetPorts() []graph.Port {
+	return []graph.Port{node.powerInput, node.massRateInput, node.powerOutput}
+}
+
+func (node *fanShaftNode) GetRequirePorts() ([]graph.Port, error) {
+	return []graph.Port{node.powerInput, node.massRateInput}, nil
+}
+
+func (node *fanShaftNode) GetUpdatePorts() ([]graph.Port, error) {
+	return []graph.Port{node.powerOutput}, nil
+}
+
+func (node *fanShaftNode) Process() error {
+	var lSpecific = node.powerInput.GetState().(states.PowerPortState).LSpecific
+	var massRate = node.massRateInput.GetState().(states.MassRatePortState).MassRate
+	node.powerOutput.SetState(states.NewPowerPortState(lSpecific * massRate / node.etaM))
+	return nil
+}
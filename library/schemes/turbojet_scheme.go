@@ -0,0 +1,92 @@
+package schemes
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+)
+
+// TurbojetScheme is a design point scheme of single spool turbojet with afterburner
+// and variable area nozzle. Mass rates of the scheme are relative to the compressor mass rate.
+// Dry (afterburner off) operation is obtained with Afterburner().SetLit(false)
+type TurbojetScheme interface {
+	JetEngineScheme
+	GasGenerator() compose.GasGeneratorNode
+	Compressor() constructive.CompressorNode
+	Turbine() constructive.StaticTurbineNode
+	TurbinePipe() constructive.PressureLossNode
+	MainBurner() constructive.BurnerNode
+	Afterburner() constructive.AfterburnerNode
+	Nozzle() constructive.NozzleNode
+}
+
+func NewTurbojetScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	gasGenerator compose.GasGeneratorNode,
+	turbinePipe constructive.PressureLossNode,
+	afterburner constructive.AfterburnerNode,
+	nozzle constructive.NozzleNode,
+) TurbojetScheme {
+	var result = &turbojetScheme{
+		jetEngineScheme: newJetEngineScheme(flightCondition, inlet),
+		gasGenerator:    gasGenerator,
+		turbinePipe:     turbinePipe,
+		afterburner:     afterburner,
+		nozzle:          nozzle,
+	}
+	result.burners = append(result.burners, gasGenerator.Burner(), afterburner)
+
+	flightCondition.SetMassRate(1)
+	result.linkInlet()
+	nodes.LinkComplexOutToIn(inlet, gasGenerator)
+	nodes.LinkComplexOutToIn(gasGenerator, turbinePipe)
+	nodes.LinkComplexOutToIn(turbinePipe, afterburner)
+	nodes.LinkComplexOutToIn(afterburner, nozzle)
+	result.linkJet(nozzle)
+	return result
+}
+
+type turbojetScheme struct {
+	jetEngineScheme
+	gasGenerator compose.GasGeneratorNode
+	turbinePipe  constructive.PressureLossNode
+	afterburner  constructive.AfterburnerNode
+	nozzle       constructive.NozzleNode
+}
+
+func (scheme *turbojetScheme) GasGenerator() compose.GasGeneratorNode {
+	return scheme.gasGenerator
+}
+
+func (scheme *turbojetScheme) Compressor() constructive.CompressorNode {
+	return scheme.gasGenerator.TurboCascade().Compressor()
+}
+
+func (scheme *turbojetScheme) Turbine() constructive.StaticTurbineNode {
+	return scheme.gasGenerator.TurboCascade().Turbine()
+}
+
+func (scheme *turbojetScheme) TurbinePipe() constructive.PressureLossNode {
+	return scheme.turbinePipe
+}
+
+func (scheme *turbojetScheme) MainBurner() constructive.BurnerNode {
+	return scheme.gasGenerator.Burner()
+}
+
+func (scheme *turbojetScheme) Afterburner() constructive.AfterburnerNode {
+	return scheme.afterburner
+}
+
+func (scheme *turbojetScheme) Nozzle() constructive.NozzleNode {
+	return scheme.nozzle
+}
+
+func (scheme *turbojetScheme) GetNetwork() (graph.Network, graph.GraphError) {
+	return graph.NewNetwork(append(
+		scheme.nodes(), scheme.gasGenerator, scheme.turbinePipe, scheme.afterburner, scheme.nozzle,
+	))
+}
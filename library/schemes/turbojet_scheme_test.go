@@ -0,0 +1,86 @@
+package schemes
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTurbojetScheme_Dry(t *testing.T) {
+	var scheme = getTurbojetScheme(false)
+	var network, networkErr = scheme.GetNetwork()
+	assert.Nil(t, networkErr)
+	assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+
+	assert.Equal(t, 0., scheme.Afterburner().FuelRateRel())
+	assert.InDelta(t, scheme.MainBurner().FuelRateRel(), scheme.GetFuelMassRateRel(), 1e-12)
+	assert.InDelta(t, scheme.MainBurner().Fuel().QLower(), scheme.GetQLower(), 1e-9)
+
+	var netThrust = scheme.NetThrust()
+	assert.True(t, netThrust > 0)
+	assert.InDelta(t, scheme.Nozzle().GrossThrust()-scheme.Inlet().RamDrag(), netThrust, 1e-9)
+	assert.InDelta(t, netThrust, scheme.SpecificThrust(), 1e-9)
+
+	// convergent-divergent nozzle expands the jet to the ambient pressure
+	assert.True(t, scheme.Nozzle().ExitArea() > scheme.Nozzle().ThroatArea())
+	assert.InDelta(t, scheme.FlightCondition().Ambient().P, scheme.Nozzle().PStaticOut(), 1e-3)
+
+	var etaP = scheme.PropulsiveEfficiency()
+	var etaTh = scheme.ThermalEfficiency()
+	assert.True(t, etaP > 0 && etaP < 1, "%f", etaP)
+	assert.True(t, etaTh > 0 && etaTh < 1, "%f", etaTh)
+	assert.InDelta(t, etaTh, GetEfficiency(scheme), 1e-12)
+}
+
+func TestTurbojetScheme_Wet(t *testing.T) {
+	var solve = func(lit bool) TurbojetScheme {
+		var scheme = getTurbojetScheme(lit)
+		var network, _ = scheme.GetNetwork()
+		assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+		return scheme
+	}
+	var dry = solve(false)
+	var wet = solve(true)
+
+	assert.InDelta(t, 2000, wet.Afterburner().TStagOut(), 1e-9)
+	var abMassRate = wet.Afterburner().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(
+		t, wet.MainBurner().FuelRateRel()+wet.Afterburner().FuelRateRel()*abMassRate,
+		wet.GetFuelMassRateRel(), 1e-12,
+	)
+	var nozzleMassRate = wet.Nozzle().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 1+wet.GetFuelMassRateRel(), nozzleMassRate, 1e-9)
+
+	// reheat boosts thrust at the cost of fuel consumption and requires larger nozzle
+	assert.True(t, wet.NetThrust() > dry.NetThrust())
+	assert.True(t, wet.TSFC() > dry.TSFC())
+	assert.True(t, wet.Nozzle().ThroatArea() > dry.Nozzle().ThroatArea())
+	assert.True(t, wet.ThermalEfficiency() < dry.ThermalEfficiency())
+}
+
+func getTurbojetScheme(lit bool) TurbojetScheme {
+	var afterburner = constructive.NewAfterburnerNode(
+		fuel.GetCH4(), 2000, 300, 0.98, 0.94, 0.92, 3, 300, 1e-6, 1, nodes.DefaultN,
+	)
+	afterburner.SetLit(lit)
+	return NewTurbojetScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), 11000, 1.5, 0, 1),
+		constructive.NewInletNode(constructive.MilSpecRecovery(0.99), 1),
+		compose.NewGasGeneratorNode(
+			0.86, 8, fuel.GetCH4(),
+			1500, 300, 0.96, 0.99, 3, 300,
+			0.9, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc,
+			0.99, 0.05, 1, nodes.DefaultN,
+		),
+		constructive.NewPressureLossNode(0.99),
+		afterburner,
+		constructive.NewNozzleNode(constructive.ConvergentDivergentNozzle, 0.98, 1),
+	)
+}
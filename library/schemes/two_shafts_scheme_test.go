@@ -49,3 +49,56 @@ func TestTwoShaftsScheme_GetNetwork_Smoke(t *testing.T) {
 
 	network.Solve(0.2, 1, 100, 0.05)
 }
+
+func TestTwoShaftsScheme_HumidAir(t *testing.T) {
+	var solve = func(relHumidity float64) TwoShaftsScheme {
+		var gasSource, err = source.NewHumidAirSourceNode(303, 1e5, relHumidity, 1)
+		assert.Nil(t, err)
+
+		var scheme = getTwoShaftsScheme(gasSource)
+		var network, networkErr = scheme.GetNetwork()
+		assert.Nil(t, networkErr)
+		assert.Nil(t, network.Solve(0.2, 1, 100, 1e-4))
+		return scheme
+	}
+
+	var dry = solve(0)
+	var humid = solve(0.6)
+
+	var dryPower = dry.GetSpecificPower()
+	var humidPower = humid.GetSpecificPower()
+	// water vapour has higher heat capacity and gas constant, so turbines produce more labour
+	assert.True(t, humidPower > dryPower*1.005, "dry: %f, humid: %f", dryPower, humidPower)
+}
+
+func getTwoShaftsScheme(gasSource source.ComplexGasSourceNode) TwoShaftsScheme {
+	var inletPressureDrop = constructive.NewPressureLossNode(0.98)
+	var gasGenerator = compose.NewGasGeneratorNode(
+		0.86, 6, fuel.GetCH4(),
+		1400, 300, 0.99, 0.99, 3, 300,
+		0.9, 0.3, func(node constructive.TurbineNode) float64 {
+			return 0
+		},
+		func(node constructive.TurbineNode) float64 {
+			return 0
+		},
+		func(node constructive.TurbineNode) float64 {
+			return 0
+		},
+		0.99, 0.05, 1, nodes.DefaultN,
+	)
+	var compressorTurbinePipe = constructive.NewPressureLossNode(0.98)
+	var freeTurbineBlock = compose.NewFreeTurbineBlock(
+		1e5,
+		0.92, 0.3, 0.05, func(node constructive.TurbineNode) float64 {
+			return 0
+		},
+		func(node constructive.TurbineNode) float64 {
+			return 0
+		},
+		func(node constructive.TurbineNode) float64 {
+			return 0
+		}, 0.9,
+	)
+	return NewTwoShaftsScheme(gasSource, inletPressureDrop, gasGenerator, compressorTurbinePipe, freeTurbineBlock)
+}
@@ -0,0 +1,176 @@
+package schemes
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+)
+
+type TwoSpoolTurbofanScheme interface {
+	TurbofanScheme
+	GasGenerator() compose.GasGeneratorNode
+	HPC() constructive.CompressorNode
+	HPT() constructive.StaticTurbineNode
+	HPTPipe() constructive.PressureLossNode
+}
+
+type TwoSpoolSeparateTurbofanScheme interface {
+	TwoSpoolTurbofanScheme
+	CoreNozzle() constructive.NozzleNode
+	BypassNozzle() constructive.NozzleNode
+}
+
+type TwoSpoolMixedTurbofanScheme interface {
+	TwoSpoolTurbofanScheme
+	Mixer() constructive.MixerNode
+	Nozzle() constructive.NozzleNode
+}
+
+func NewTwoSpoolSeparateTurbofanScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	fan constructive.CompressorNode,
+	bypassRatio float64,
+	gasGenerator compose.GasGeneratorNode,
+	hptPipe constructive.PressureLossNode,
+	lpt constructive.BlockedTurbineNode,
+	etaM float64,
+	coreNozzle constructive.NozzleNode,
+	bypassDuct constructive.PressureLossNode,
+	bypassNozzle constructive.NozzleNode,
+) TwoSpoolSeparateTurbofanScheme {
+	var result = &twoSpoolSeparateTurbofanScheme{
+		twoSpoolTurbofanScheme: newTwoSpoolTurbofanScheme(
+			flightCondition, inlet, fan, bypassRatio, gasGenerator, hptPipe, lpt, etaM, bypassDuct,
+		),
+		coreNozzle:   coreNozzle,
+		bypassNozzle: bypassNozzle,
+	}
+	result.linkJet(coreNozzle)
+	result.linkJet(bypassNozzle)
+	nodes.LinkComplexOutToIn(lpt, coreNozzle)
+	nodes.LinkComplexOutToIn(bypassDuct, bypassNozzle)
+	return result
+}
+
+// NewTwoSpoolMixedTurbofanScheme returns turbofan with core and bypass flows mixed
+// in the mixer before the common nozzle. Core flow enters the mixer with velocity coefficient
+// mixerLambdaIn and mixer inlets are sized so that static pressures of the flows are equal
+func NewTwoSpoolMixedTurbofanScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	fan constructive.CompressorNode,
+	bypassRatio float64,
+	gasGenerator compose.GasGeneratorNode,
+	hptPipe constructive.PressureLossNode,
+	lpt constructive.BlockedTurbineNode,
+	etaM float64,
+	bypassDuct constructive.PressureLossNode,
+	mixerLambdaIn float64,
+	nozzle constructive.NozzleNode,
+) TwoSpoolMixedTurbofanScheme {
+	var mixer = constructive.NewDesignMixerNode(mixerLambdaIn)
+	var result = &twoSpoolMixedTurbofanScheme{
+		twoSpoolTurbofanScheme: newTwoSpoolTurbofanScheme(
+			flightCondition, inlet, fan, bypassRatio, gasGenerator, hptPipe, lpt, etaM, bypassDuct,
+		),
+		mixer:  mixer,
+		nozzle: nozzle,
+	}
+	result.linkJet(nozzle)
+	nodes.LinkComplexOutToIn(lpt, mixer.MainInput())
+	nodes.LinkComplexOutToIn(bypassDuct, mixer.ExtraInput())
+	nodes.LinkComplexOutToIn(mixer.Output(), nozzle)
+	sink.SinkPort(mixer.StaticPressureResidualOutput())
+	return result
+}
+
+func newTwoSpoolTurbofanScheme(
+	flightCondition source.FlightConditionSourceNode,
+	inlet constructive.InletNode,
+	fan constructive.CompressorNode,
+	bypassRatio float64,
+	gasGenerator compose.GasGeneratorNode,
+	hptPipe constructive.PressureLossNode,
+	lpt constructive.BlockedTurbineNode,
+	etaM float64,
+	bypassDuct constructive.PressureLossNode,
+) twoSpoolTurbofanScheme {
+	var result = twoSpoolTurbofanScheme{
+		turbofan:     newTurbofan(flightCondition, inlet, fan, bypassRatio, bypassDuct, lpt, etaM),
+		gasGenerator: gasGenerator,
+		hptPipe:      hptPipe,
+	}
+	result.burners = append(result.burners, gasGenerator.Burner())
+
+	result.linkFan()
+	nodes.LinkComplexOutToIn(result.splitter.MainOutput(), gasGenerator)
+	nodes.LinkComplexOutToIn(gasGenerator, hptPipe)
+	nodes.LinkComplexOutToIn(hptPipe, lpt)
+	return result
+}
+
+type twoSpoolTurbofanScheme struct {
+	turbofan
+	gasGenerator compose.GasGeneratorNode
+	hptPipe      constructive.PressureLossNode
+}
+
+func (scheme *twoSpoolTurbofanScheme) GasGenerator() compose.GasGeneratorNode {
+	return scheme.gasGenerator
+}
+
+func (scheme *twoSpoolTurbofanScheme) HPC() constructive.CompressorNode {
+	return scheme.gasGenerator.TurboCascade().Compressor()
+}
+
+func (scheme *twoSpoolTurbofanScheme) HPT() constructive.StaticTurbineNode {
+	return scheme.gasGenerator.TurboCascade().Turbine()
+}
+
+func (scheme *twoSpoolTurbofanScheme) HPTPipe() constructive.PressureLossNode {
+	return scheme.hptPipe
+}
+
+func (scheme *twoSpoolTurbofanScheme) nodes() []graph.Node {
+	return append(scheme.turbofan.nodes(), scheme.gasGenerator, scheme.hptPipe)
+}
+
+type twoSpoolSeparateTurbofanScheme struct {
+	twoSpoolTurbofanScheme
+	coreNozzle   constructive.NozzleNode
+	bypassNozzle constructive.NozzleNode
+}
+
+func (scheme *twoSpoolSeparateTurbofanScheme) CoreNozzle() constructive.NozzleNode {
+	return scheme.coreNozzle
+}
+
+func (scheme *twoSpoolSeparateTurbofanScheme) BypassNozzle() constructive.NozzleNode {
+	return scheme.bypassNozzle
+}
+
+func (scheme *twoSpoolSeparateTurbofanScheme) GetNetwork() (graph.Network, graph.GraphError) {
+	return graph.NewNetwork(append(scheme.nodes(), scheme.coreNozzle, scheme.bypassNozzle))
+}
+
+type twoSpoolMixedTurbofanScheme struct {
+	twoSpoolTurbofanScheme
+	mixer  constructive.MixerNode
+	nozzle constructive.NozzleNode
+}
+
+func (scheme *twoSpoolMixedTurbofanScheme) Mixer() constructive.MixerNode {
+	return scheme.mixer
+}
+
+func (scheme *twoSpoolMixedTurbofanScheme) Nozzle() constructive.NozzleNode {
+	return scheme.nozzle
+}
+
+func (scheme *twoSpoolMixedTurbofanScheme) GetNetwork() (graph.Network, graph.GraphError) {
+	return graph.NewNetwork(append(scheme.nodes(), scheme.mixer, scheme.nozzle))
+}
@@ -0,0 +1,123 @@
+package schemes
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoSpoolSeparateTurbofanScheme_Cruise(t *testing.T) {
+	var scheme = getTwoSpoolSeparateTurbofanScheme(5, 0.8)
+	var network, networkErr = scheme.GetNetwork()
+	assert.Nil(t, networkErr)
+	assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+
+	var fan = scheme.Fan()
+	var fanMassRate = fan.MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 6, fanMassRate, 1e-9)
+	var coreMassRate = scheme.HPC().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 1, coreMassRate, 1e-9)
+
+	// low pressure turbine drives the fan
+	var lptLabour = scheme.LPT().LSpecific() * scheme.LPT().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, fan.LSpecific()*fanMassRate/0.99, lptLabour, 1e-3*lptLabour)
+
+	var netThrust = scheme.NetThrust()
+	assert.InDelta(
+		t,
+		scheme.CoreNozzle().GrossThrust()+scheme.BypassNozzle().GrossThrust()-scheme.Inlet().RamDrag(),
+		netThrust, 1e-9,
+	)
+	assert.True(t, netThrust > 0)
+	assert.InDelta(t, netThrust/6, scheme.SpecificThrust(), 1e-9)
+
+	var etaP = scheme.PropulsiveEfficiency()
+	var etaTh = scheme.ThermalEfficiency()
+	assert.True(t, etaP > 0.5 && etaP < 1, "%f", etaP)
+	assert.True(t, etaTh > 0.4 && etaTh < 0.7, "%f", etaTh)
+	assert.InDelta(t, etaTh, GetEfficiency(scheme), 1e-12)
+
+	// overall efficiency is thrust power to fuel heat ratio
+	var fuelHeat = scheme.GetFuelMassRateRel() * scheme.GetQLower()
+	assert.InDelta(t, netThrust*scheme.FlightCondition().Velocity()/fuelHeat, etaP*etaTh, 1e-9)
+	assert.InDelta(t, 3600*scheme.GetFuelMassRateRel()/netThrust, scheme.TSFC(), 1e-12)
+}
+
+func TestTwoSpoolSeparateTurbofanScheme_BypassRatio(t *testing.T) {
+	var solve = func(bypassRatio float64) TurbofanScheme {
+		var scheme = getTwoSpoolSeparateTurbofanScheme(bypassRatio, 0.8)
+		var network, _ = scheme.GetNetwork()
+		assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+		return scheme
+	}
+	var low = solve(3)
+	var high = solve(6)
+
+	// higher bypass ratio gives lower jet velocity, so specific thrust drops and fuel consumption improves
+	assert.True(t, high.SpecificThrust() < low.SpecificThrust())
+	assert.True(t, high.TSFC() < low.TSFC(), "low: %f, high: %f", low.TSFC(), high.TSFC())
+	assert.True(t, high.PropulsiveEfficiency() > low.PropulsiveEfficiency())
+}
+
+func TestTwoSpoolMixedTurbofanScheme_Smoke(t *testing.T) {
+	var scheme = NewTwoSpoolMixedTurbofanScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), 0, 0, 0, 1),
+		constructive.NewInletNode(constructive.MilSpecRecovery(0.99), 1),
+		constructive.NewCompressorNode(0.88, 3, 0.05),
+		1, getTurbofanGasGenerator(), constructive.NewPressureLossNode(0.99),
+		getTurbofanLPT(), 0.99,
+		constructive.NewPressureLossNode(0.98),
+		0.4,
+		constructive.NewNozzleNode(constructive.ConvergentNozzle, 0.98, 1),
+	)
+	var network, networkErr = scheme.GetNetwork()
+	assert.Nil(t, networkErr)
+	assert.Nil(t, network.Solve(1, 2, 100, 1e-6))
+
+	// mixer is sized for equal static pressures of the flows
+	assert.InDelta(t, scheme.Mixer().PStaticMainIn(), scheme.Mixer().PStaticExtraIn(), 1e-6*scheme.Mixer().PStaticMainIn())
+	assert.InDelta(t, 0.4, scheme.Mixer().LambdaMainIn(), 1e-9)
+
+	var nozzleMassRate = scheme.Nozzle().MassRateInput().GetState().(states.MassRatePortState).MassRate
+	assert.InDelta(t, 2+scheme.GetFuelMassRateRel(), nozzleMassRate, 1e-9)
+	assert.True(t, scheme.NetThrust() > 0)
+	assert.InDelta(t, scheme.Nozzle().GrossThrust(), scheme.NetThrust(), 1e-9)
+	assert.False(t, scheme.Mixer().Choked())
+}
+
+func getTwoSpoolSeparateTurbofanScheme(bypassRatio, mach float64) TwoSpoolSeparateTurbofanScheme {
+	return NewTwoSpoolSeparateTurbofanScheme(
+		source.NewFlightConditionSourceNode(gases.GetAir(), 11000, mach, 0, 1),
+		constructive.NewInletNode(constructive.MilSpecRecovery(0.99), 1),
+		constructive.NewCompressorNode(0.9, 1.6, 0.05),
+		bypassRatio, getTurbofanGasGenerator(), constructive.NewPressureLossNode(0.99),
+		getTurbofanLPT(), 0.99,
+		constructive.NewNozzleNode(constructive.ConvergentNozzle, 0.98, 1),
+		constructive.NewPressureLossNode(0.98),
+		constructive.NewNozzleNode(constructive.ConvergentNozzle, 0.98, 1),
+	)
+}
+
+func getTurbofanGasGenerator() compose.GasGeneratorNode {
+	return compose.NewGasGeneratorNode(
+		0.88, 18, fuel.GetCH4(),
+		1500, 300, 0.96, 0.99, 3, 300,
+		0.9, 0.3, zeroTurbineFunc, zeroTurbineFunc, zeroTurbineFunc,
+		0.99, 0.05, 1, nodes.DefaultN,
+	)
+}
+
+func getTurbofanLPT() constructive.BlockedTurbineNode {
+	return constructive.NewSimpleBlockedTurbineNode(0.91, 0.3, 0, 0, 0, 0.05)
+}
+
+func zeroTurbineFunc(constructive.TurbineNode) float64 {
+	return 0
+}
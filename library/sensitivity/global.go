@@ -0,0 +1,175 @@
+package sensitivity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"github.com/Sovianum/turbocycle/library/uncertainty"
+)
+
+// SobolIndices contains variance-based sensitivity indices.
+// First[i][j] and Total[i][j] correspond to output OutputNames[i] and input InputNames[j]
+type SobolIndices struct {
+	InputNames  []string
+	OutputNames []string
+	First       [][]float64
+	Total       [][]float64
+	Variance    []float64
+	SampleNum   int
+	FailedNum   int
+}
+
+func (s SobolIndices) Index(outputName, inputName string) (first, total float64, err error) {
+	var i, j = indexOf(s.OutputNames, outputName), indexOf(s.InputNames, inputName)
+	if i == -1 {
+		return 0, 0, fmt.Errorf("output \"%s\" not found", outputName)
+	}
+	if j == -1 {
+		return 0, 0, fmt.Errorf("input \"%s\" not found", inputName)
+	}
+	return s.First[i][j], s.Total[i][j], nil
+}
+
+type SobolAnalyzer interface {
+	// Analyze evaluates model in baseSampleNum * (len(inputs) + 2) points of Saltelli scheme.
+	// Number of inputs is limited with half of sweep.MaxSobolDim
+	Analyze(inputs []uncertainty.Input, baseSampleNum int) (SobolIndices, error)
+}
+
+func NewSobolAnalyzer(factory sweep.Factory, outputNames []string, workerNum int) SobolAnalyzer {
+	return &sobolAnalyzer{
+		runner:      sweep.NewRunner(factory, outputNames, workerNum),
+		outputNames: outputNames,
+	}
+}
+
+type sobolAnalyzer struct {
+	runner      sweep.Runner
+	outputNames []string
+}
+
+func (a *sobolAnalyzer) Analyze(inputs []uncertainty.Input, baseSampleNum int) (SobolIndices, error) {
+	var plan, err = saltelliPlan(inputs, baseSampleNum)
+	if err != nil {
+		return SobolIndices{}, err
+	}
+
+	var rowChan, runErr = a.runner.Run(plan)
+	if runErr != nil {
+		return SobolIndices{}, runErr
+	}
+	var rows = sweep.Collect(rowChan)
+
+	var dim = len(inputs)
+	var blockNum = dim + 2
+
+	// sample j is used only if all the block evaluations of it converged
+	var valid = make([]int, 0, baseSampleNum)
+	for j := 0; j != baseSampleNum; j++ {
+		var ok = true
+		for b := 0; b != blockNum; b++ {
+			ok = ok && rows[b*baseSampleNum+j].Converged
+		}
+		if ok {
+			valid = append(valid, j)
+		}
+	}
+	if len(valid) < 2 {
+		return SobolIndices{}, errors.New("too few converged samples")
+	}
+
+	var result = SobolIndices{
+		InputNames:  plan.Names,
+		OutputNames: a.outputNames,
+		First:       make([][]float64, len(a.outputNames)),
+		Total:       make([][]float64, len(a.outputNames)),
+		Variance:    make([]float64, len(a.outputNames)),
+		SampleNum:   len(valid),
+		FailedNum:   baseSampleNum - len(valid),
+	}
+
+	var n = float64(len(valid))
+	for k := range a.outputNames {
+		var f = func(block, j int) float64 {
+			return rows[block*baseSampleNum+j].Outputs[k]
+		}
+
+		var mean, variance float64
+		for _, j := range valid {
+			mean += f(0, j) + f(1, j)
+		}
+		mean /= 2 * n
+		for _, j := range valid {
+			variance += (f(0, j)-mean)*(f(0, j)-mean) + (f(1, j)-mean)*(f(1, j)-mean)
+		}
+		variance /= 2*n - 1
+		result.Variance[k] = variance
+
+		result.First[k] = make([]float64, dim)
+		result.Total[k] = make([]float64, dim)
+		if variance == 0 {
+			continue
+		}
+
+		for i := 0; i != dim; i++ {
+			var first, total float64
+			for _, j := range valid {
+				var fA, fB, fAB = f(0, j), f(1, j), f(i+2, j)
+				first += fB * (fAB - fA)
+				total += (fA - fAB) * (fA - fAB)
+			}
+			result.First[k][i] = first / n / variance
+			result.Total[k][i] = total / (2 * n) / variance
+		}
+	}
+	return result, nil
+}
+
+// saltelliPlan returns plan consisting of blocks A, B, AB_1, ..., AB_d of baseSampleNum points each,
+// where AB_i is A with column i taken from B
+func saltelliPlan(inputs []uncertainty.Input, baseSampleNum int) (sweep.Plan, error) {
+	var dim = len(inputs)
+	if dim == 0 {
+		return sweep.Plan{}, errors.New("no inputs given")
+	}
+	if baseSampleNum < 2 {
+		return sweep.Plan{}, fmt.Errorf("invalid base sample number %d", baseSampleNum)
+	}
+
+	var seq, err = sweep.NewSobolSequence(2 * dim)
+	if err != nil {
+		return sweep.Plan{}, err
+	}
+	seq.Next()
+
+	var a = make([][]float64, baseSampleNum)
+	var b = make([][]float64, baseSampleNum)
+	for j := 0; j != baseSampleNum; j++ {
+		var u = seq.Next()
+		a[j] = make([]float64, dim)
+		b[j] = make([]float64, dim)
+		for i, input := range inputs {
+			a[j][i] = input.Distribution.Quantile(u[i])
+			b[j][i] = input.Distribution.Quantile(u[dim+i])
+		}
+	}
+
+	var points = make([][]float64, 0, baseSampleNum*(dim+2))
+	points = append(points, a...)
+	points = append(points, b...)
+	for i := 0; i != dim; i++ {
+		for j := 0; j != baseSampleNum; j++ {
+			var point = make([]float64, dim)
+			copy(point, a[j])
+			point[i] = b[j][i]
+			points = append(points, point)
+		}
+	}
+
+	var names = make([]string, dim)
+	for i, input := range inputs {
+		names[i] = input.Name
+	}
+	return sweep.NewPlan(names, points)
+}
@@ -0,0 +1,62 @@
+package sensitivity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"github.com/Sovianum/turbocycle/library/uncertainty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSobolAnalyzer_Ishigami(t *testing.T) {
+	var factory = func() (sweep.Model, error) {
+		var x1, x2, x3, y float64
+		return sweep.NewModel(
+			func() error {
+				y = math.Sin(x1) + 7*math.Pow(math.Sin(x2), 2) + 0.1*math.Pow(x3, 4)*math.Sin(x1)
+				return nil
+			},
+			map[string]variator.Variator{
+				"x1": variator.FromPointer(&x1),
+				"x2": variator.FromPointer(&x2),
+				"x3": variator.FromPointer(&x3),
+			},
+			map[string]func() float64{"y": func() float64 { return y }},
+		), nil
+	}
+
+	var d, _ = uncertainty.NewUniform(-math.Pi, math.Pi)
+	var inputs = []uncertainty.Input{
+		uncertainty.NewInput("x1", d),
+		uncertainty.NewInput("x2", d),
+		uncertainty.NewInput("x3", d),
+	}
+
+	var indices, err = NewSobolAnalyzer(factory, []string{"y"}, 4).Analyze(inputs, 1<<13)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, indices.FailedNum)
+
+	var expectedFirst = []float64{0.3139, 0.4424, 0}
+	var expectedTotal = []float64{0.5576, 0.4424, 0.2437}
+	for i := range inputs {
+		assert.InDelta(t, expectedFirst[i], indices.First[0][i], 0.02, "first %d", i)
+		assert.InDelta(t, expectedTotal[i], indices.Total[0][i], 0.02, "total %d", i)
+	}
+
+	var first, total, indexErr = indices.Index("y", "x2")
+	assert.Nil(t, indexErr)
+	assert.Equal(t, indices.First[0][1], first)
+	assert.Equal(t, indices.Total[0][1], total)
+}
+
+func TestSobolAnalyzer_TooManyInputs(t *testing.T) {
+	var d, _ = uncertainty.NewUniform(0, 1)
+	var inputs = make([]uncertainty.Input, sweep.MaxSobolDim)
+	for i := range inputs {
+		inputs[i] = uncertainty.NewInput(string(rune('a'+i)), d)
+	}
+	var _, err = saltelliPlan(inputs, 16)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,178 @@
+package sensitivity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/library/sweep"
+)
+
+// LocalAnalyzer computes normalised sensitivity coefficients dln(y)/dln(x)
+// of model outputs y to model inputs x in the current point of the model
+type LocalAnalyzer interface {
+	Analyze(model sweep.Model, inputNames, outputNames []string) (Table, error)
+}
+
+// NewLocalAnalyzer creates analyzer using central differences.
+// Relative step starts from relStep0 and is halved until two successive derivative
+// estimates differ less than precision (relatively) or stepNum halvings are done
+func NewLocalAnalyzer(relStep0, precision float64, stepNum int) LocalAnalyzer {
+	return &localAnalyzer{
+		relStep0:  relStep0,
+		precision: precision,
+		stepNum:   stepNum,
+	}
+}
+
+type localAnalyzer struct {
+	relStep0  float64
+	precision float64
+	stepNum   int
+}
+
+func (a *localAnalyzer) Analyze(model sweep.Model, inputNames, outputNames []string) (Table, error) {
+	if len(inputNames) == 0 || len(outputNames) == 0 {
+		return Table{}, errors.New("no inputs or outputs given")
+	}
+
+	var inputs = make([]variator.Variator, len(inputNames))
+	for i, name := range inputNames {
+		var v, err = model.Input(name)
+		if err != nil {
+			return Table{}, err
+		}
+		inputs[i] = v
+	}
+
+	if err := model.Solve(); err != nil {
+		return Table{}, fmt.Errorf("failed to solve base point: %s", err.Error())
+	}
+	var baseOutputs, err = getOutputs(model, outputNames)
+	if err != nil {
+		return Table{}, err
+	}
+	for i, y := range baseOutputs {
+		if y == 0 {
+			return Table{}, fmt.Errorf("output \"%s\" is zero in the base point", outputNames[i])
+		}
+	}
+
+	var result = newTable(inputNames, outputNames)
+	result.Outputs = baseOutputs
+	for j, v := range inputs {
+		var x0 = v.GetValue()
+		result.Inputs[j] = x0
+		if x0 == 0 {
+			return Table{}, fmt.Errorf("input \"%s\" is zero in the base point", inputNames[j])
+		}
+
+		var derivatives, err = a.logDerivatives(model, v, outputNames)
+		v.SetValue(x0)
+		if err != nil {
+			return Table{}, fmt.Errorf("failed to vary input \"%s\": %s", inputNames[j], err.Error())
+		}
+		for i, d := range derivatives {
+			result.Coefs[i][j] = d / baseOutputs[i]
+		}
+	}
+
+	// model is returned to its base state
+	if err := model.Solve(); err != nil {
+		return Table{}, fmt.Errorf("failed to solve base point: %s", err.Error())
+	}
+	return result, nil
+}
+
+// logDerivatives returns dy/dln(x) for all the outputs
+func (a *localAnalyzer) logDerivatives(model sweep.Model, v variator.Variator, outputNames []string) ([]float64, error) {
+	var x0 = v.GetValue()
+	var relStep = a.relStep0
+
+	var prev []float64
+	var lastErr error
+	for i := 0; i != a.stepNum; i++ {
+		var curr, err = centralDiff(model, v, outputNames, x0, relStep)
+		relStep /= 2
+
+		if err != nil {
+			// too large step may break convergence of the model
+			lastErr = err
+			prev = nil
+			continue
+		}
+		if prev != nil && diffConverged(prev, curr, a.precision) {
+			return richardson(prev, curr), nil
+		}
+		prev = curr
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("failed to converge derivatives in %d steps", a.stepNum)
+}
+
+func centralDiff(model sweep.Model, v variator.Variator, outputNames []string, x0, relStep float64) ([]float64, error) {
+	var dx = math.Abs(x0) * relStep
+
+	v.SetValue(x0 + dx)
+	if err := model.Solve(); err != nil {
+		return nil, err
+	}
+	var yPlus, err = getOutputs(model, outputNames)
+	if err != nil {
+		return nil, err
+	}
+
+	v.SetValue(x0 - dx)
+	if err := model.Solve(); err != nil {
+		return nil, err
+	}
+	yMinus, err := getOutputs(model, outputNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make([]float64, len(outputNames))
+	for i := range result {
+		result[i] = (yPlus[i] - yMinus[i]) / (2 * relStep) * math.Copysign(1, x0)
+	}
+	return result, nil
+}
+
+func diffConverged(prev, curr []float64, precision float64) bool {
+	for i := range prev {
+		var scale = math.Max(math.Abs(prev[i]), math.Abs(curr[i]))
+		if scale == 0 {
+			continue
+		}
+		if math.Abs(prev[i]-curr[i])/scale > precision {
+			return false
+		}
+	}
+	return true
+}
+
+// richardson eliminates leading error term of the central difference
+// using estimates obtained with step h (coarse) and h / 2 (fine)
+func richardson(coarse, fine []float64) []float64 {
+	var result = make([]float64, len(fine))
+	for i := range fine {
+		result[i] = (4*fine[i] - coarse[i]) / 3
+	}
+	return result
+}
+
+func getOutputs(model sweep.Model, outputNames []string) ([]float64, error) {
+	var result = make([]float64, len(outputNames))
+	for i, name := range outputNames {
+		var y, err = model.Output(name)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = y
+	}
+	return result, nil
+}
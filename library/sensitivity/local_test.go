@@ -0,0 +1,126 @@
+package sensitivity
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/schemes"
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalAnalyzer_PowerLaw(t *testing.T) {
+	var x, z, y, w = 2., 3., 0., 0.
+	var model = sweep.NewModel(
+		func() error {
+			y = 5 * x * x / math.Sqrt(z)
+			w = math.Exp(x)
+			return nil
+		},
+		map[string]variator.Variator{
+			"x": variator.FromPointer(&x),
+			"z": variator.FromPointer(&z),
+		},
+		map[string]func() float64{
+			"y": func() float64 { return y },
+			"w": func() float64 { return w },
+		},
+	)
+
+	var table, err = NewLocalAnalyzer(0.1, 1e-6, 20).Analyze(model, []string{"x", "z"}, []string{"y", "w"})
+	assert.Nil(t, err)
+
+	assert.InDelta(t, 2, table.Coefs[0][0], 1e-6)
+	assert.InDelta(t, -0.5, table.Coefs[0][1], 1e-6)
+	assert.InDelta(t, 2, table.Coefs[1][0], 1e-6) // dln(exp(x))/dln(x) = x
+	assert.InDelta(t, 0, table.Coefs[1][1], 1e-6)
+
+	var coef, coefErr = table.Coef("y", "z")
+	assert.Nil(t, coefErr)
+	assert.InDelta(t, -0.5, coef, 1e-6)
+
+	_, coefErr = table.Coef("y", "unknown")
+	assert.NotNil(t, coefErr)
+
+	// model is left in the base point
+	assert.Equal(t, 2., x)
+	assert.InDelta(t, 20/math.Sqrt(3), y, 1e-10)
+
+	assert.True(t, strings.Contains(table.String(), "-0.5000"))
+}
+
+func TestLocalAnalyzer_ZeroInput(t *testing.T) {
+	var x, y = 0., 0.
+	var model = sweep.NewModel(
+		func() error {
+			y = x + 1
+			return nil
+		},
+		map[string]variator.Variator{"x": variator.FromPointer(&x)},
+		map[string]func() float64{"y": func() float64 { return y }},
+	)
+	var _, err = NewLocalAnalyzer(0.1, 1e-6, 20).Analyze(model, []string{"x"}, []string{"y"})
+	assert.NotNil(t, err)
+}
+
+func TestLocalAnalyzer_TwoShaftsScheme(t *testing.T) {
+	var scheme = getTwoShaftsScheme()
+	var network, err = scheme.GetNetwork()
+	assert.Nil(t, err)
+
+	var compressor = scheme.Compressor()
+	var burner = scheme.Burner().(constructive.TemperatureDefinedBurner)
+	var outputs = sweep.SchemeOutputs(scheme)
+	outputs["t_out"] = sweep.PortOutput(scheme.FreeTurbineBlock().TemperatureOutput())
+
+	var model = sweep.NewModel(
+		sweep.NetworkSolveFunc(network, 1, 1, 100, 1e-8),
+		map[string]variator.Variator{
+			"pi":    variator.FromCallables(compressor.PiStag, compressor.SetPiStag),
+			"t_gas": variator.FromCallables(burner.TgStag, burner.SetTgStag),
+		},
+		outputs,
+	)
+
+	var table, tableErr = NewLocalAnalyzer(0.02, 1e-2, 10).Analyze(
+		model, []string{"pi", "t_gas"}, []string{sweep.EfficiencyOutput, sweep.SpecificPowerOutput, "t_out"},
+	)
+	assert.Nil(t, tableErr)
+
+	// efficiency and power grow with gas temperature
+	assert.True(t, table.Coefs[0][1] > 0, table.String())
+	assert.True(t, table.Coefs[1][1] > 1, table.String())
+	// outlet temperature goes down with pressure ratio
+	assert.True(t, table.Coefs[2][0] < 0, table.String())
+	assert.True(t, table.Coefs[2][1] > 0, table.String())
+}
+
+func getTwoShaftsScheme() schemes.TwoShaftsScheme {
+	var zeroFunc = func(node constructive.TurbineNode) float64 {
+		return 0
+	}
+	var gasSource = source.NewComplexGasSourceNode(gases.GetAir(), 288, 1e5, 1)
+	var inletPressureDrop = constructive.NewPressureLossNode(0.98)
+	var gasGenerator = compose.NewGasGeneratorNode(
+		0.86, 6, fuel.GetCH4(),
+		1400, 300, 0.99, 0.99, 3, 300,
+		0.9, 0.3, zeroFunc, zeroFunc, zeroFunc,
+		0.99, 0.001, 1, nodes.DefaultN,
+	)
+	var compressorTurbinePipe = constructive.NewPressureLossNode(0.98)
+	var freeTurbineBlock = compose.NewFreeTurbineBlock(
+		1e5,
+		0.92, 0.3, 0.001, zeroFunc, zeroFunc, zeroFunc, 0.9,
+	)
+	return schemes.NewTwoShaftsScheme(
+		gasSource, inletPressureDrop, gasGenerator, compressorTurbinePipe, freeTurbineBlock,
+	)
+}
@@ -0,0 +1,74 @@
+package sensitivity
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// Table contains sensitivity coefficients of outputs to inputs.
+// Coefs[i][j] corresponds to output OutputNames[i] and input InputNames[j].
+// Inputs and Outputs contain values in the base point
+type Table struct {
+	InputNames  []string
+	OutputNames []string
+	Inputs      []float64
+	Outputs     []float64
+	Coefs       [][]float64
+}
+
+func newTable(inputNames, outputNames []string) Table {
+	var coefs = make([][]float64, len(outputNames))
+	for i := range coefs {
+		coefs[i] = make([]float64, len(inputNames))
+	}
+	return Table{
+		InputNames:  inputNames,
+		OutputNames: outputNames,
+		Inputs:      make([]float64, len(inputNames)),
+		Outputs:     make([]float64, len(outputNames)),
+		Coefs:       coefs,
+	}
+}
+
+func (t Table) Coef(outputName, inputName string) (float64, error) {
+	var i, j = indexOf(t.OutputNames, outputName), indexOf(t.InputNames, inputName)
+	if i == -1 {
+		return 0, fmt.Errorf("output \"%s\" not found", outputName)
+	}
+	if j == -1 {
+		return 0, fmt.Errorf("input \"%s\" not found", inputName)
+	}
+	return t.Coefs[i][j], nil
+}
+
+// String returns table with outputs as rows and inputs as columns
+func (t Table) String() string {
+	var buf bytes.Buffer
+	var w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(w, "\t")
+	for _, name := range t.InputNames {
+		fmt.Fprintf(w, "%s\t", name)
+	}
+	fmt.Fprintln(w)
+
+	for i, name := range t.OutputNames {
+		fmt.Fprintf(w, "%s\t", name)
+		for _, coef := range t.Coefs[i] {
+			fmt.Fprintf(w, "%.4f\t", coef)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
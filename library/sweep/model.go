@@ -0,0 +1,146 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/library/schemes"
+)
+
+const (
+	EfficiencyOutput       = "efficiency"
+	SpecificPowerOutput    = "specific_power"
+	FuelMassRateRelOutput  = "fuel_mass_rate_rel"
+	SpecificFuelRateOutput = "specific_fuel_rate"
+)
+
+// Model is a single scheme instance which can be evaluated repeatedly
+// with different values of its named inputs
+type Model interface {
+	InputNames() []string
+	OutputNames() []string
+	Input(name string) (variator.Variator, error)
+	Output(name string) (float64, error)
+	Solve() error
+}
+
+// Factory must return a new independent model on each call,
+// cos models are evaluated concurrently
+type Factory func() (Model, error)
+
+func NewModel(
+	solveFunc func() error,
+	inputs map[string]variator.Variator,
+	outputs map[string]func() float64,
+) Model {
+	return &model{
+		solveFunc: solveFunc,
+		inputs:    inputs,
+		outputs:   outputs,
+	}
+}
+
+// NetworkSolveFunc returns solve function of the design point network
+func NetworkSolveFunc(
+	network graph.Network, relaxCoef float64, skipIterations, iterLimit int, precision float64,
+) func() error {
+	return func() error {
+		return network.Solve(relaxCoef, skipIterations, iterLimit, precision)
+	}
+}
+
+// SchemeOutputs returns standard integral outputs of the design point scheme
+func SchemeOutputs(scheme schemes.Scheme) map[string]func() float64 {
+	return map[string]func() float64{
+		EfficiencyOutput: func() float64 {
+			return schemes.GetEfficiency(scheme)
+		},
+		SpecificPowerOutput:   scheme.GetSpecificPower,
+		FuelMassRateRelOutput: scheme.GetFuelMassRateRel,
+		SpecificFuelRateOutput: func() float64 {
+			return schemes.GetSpecificFuelRate(scheme)
+		},
+	}
+}
+
+// BurnerEmissionOutputs returns emission outputs of the burner for each of its emission correlations.
+// Outputs are named "<prefix>_<pollutant>_ei" (g / kg of fuel) and "<prefix>_<pollutant>_ppm15"
+// (ppm in dry exhaust at 15 % of oxygen); they are NaN if emission is not calculated
+func BurnerEmissionOutputs(prefix string, burner constructive.BurnerNode) map[string]func() float64 {
+	var result = make(map[string]func() float64)
+	for _, correlation := range burner.EmissionCorrelations() {
+		var pollutant = correlation.Pollutant()
+		var name = prefix + "_" + strings.ToLower(pollutant)
+		result[name+"_ei"] = func() float64 {
+			var e, err = burner.Emission(pollutant)
+			if err != nil {
+				return math.NaN()
+			}
+			return e.EmissionIndex
+		}
+		result[name+"_ppm15"] = func() float64 {
+			var e, err = burner.Emission(pollutant)
+			if err != nil {
+				return math.NaN()
+			}
+			return e.PPM15
+		}
+	}
+	return result
+}
+
+// PortOutput returns output reading float value of the port state (e.g. temperature or pressure)
+func PortOutput(port graph.Port) func() float64 {
+	return func() float64 {
+		return port.GetState().Value().(float64)
+	}
+}
+
+type model struct {
+	solveFunc func() error
+	inputs    map[string]variator.Variator
+	outputs   map[string]func() float64
+}
+
+func (m *model) InputNames() []string {
+	var result = make([]string, 0, len(m.inputs))
+	for name := range m.inputs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *model) OutputNames() []string {
+	var result = make([]string, 0, len(m.outputs))
+	for name := range m.outputs {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *model) Input(name string) (variator.Variator, error) {
+	var v, ok = m.inputs[name]
+	if !ok {
+		return nil, fmt.Errorf("input \"%s\" not found", name)
+	}
+	return v, nil
+}
+
+func (m *model) Output(name string) (float64, error) {
+	var f, ok = m.outputs[name]
+	if !ok {
+		return 0, fmt.Errorf("output \"%s\" not found", name)
+	}
+	return f(), nil
+}
+
+func (m *model) Solve() error {
+	return m.solveFunc()
+}
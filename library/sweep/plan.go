@@ -0,0 +1,161 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/Sovianum/turbocycle/common"
+)
+
+// Parameter describes range of a named model input.
+// Levels is used only by full-factorial plans
+type Parameter struct {
+	Name   string
+	Min    float64
+	Max    float64
+	Levels int
+}
+
+func NewParameter(name string, min, max float64, levels int) Parameter {
+	return Parameter{Name: name, Min: min, Max: max, Levels: levels}
+}
+
+// Plan is a set of points in the space of named inputs.
+// Points[i][j] is the value of input Names[j] in point i
+type Plan struct {
+	Names  []string
+	Points [][]float64
+}
+
+func (plan Plan) Len() int {
+	return len(plan.Points)
+}
+
+// NewPlan builds plan from explicitly given points
+func NewPlan(names []string, points [][]float64) (Plan, error) {
+	for i, point := range points {
+		if len(point) != len(names) {
+			return Plan{}, fmt.Errorf(
+				"point %d has dimension %d while %d names given", i, len(point), len(names),
+			)
+		}
+	}
+	return Plan{Names: names, Points: points}, nil
+}
+
+func FullFactorial(params []Parameter) (Plan, error) {
+	if err := checkParams(params); err != nil {
+		return Plan{}, err
+	}
+
+	var levels = make([][]float64, len(params))
+	var pointNum = 1
+	for i, param := range params {
+		if param.Levels < 1 {
+			return Plan{}, fmt.Errorf("parameter \"%s\" has invalid levels number %d", param.Name, param.Levels)
+		}
+		if param.Levels == 1 {
+			levels[i] = []float64{(param.Min + param.Max) / 2}
+		} else {
+			levels[i] = common.LinSpace(param.Min, param.Max, param.Levels)
+		}
+		pointNum *= param.Levels
+	}
+
+	var points = make([][]float64, pointNum)
+	for i := range points {
+		var point = make([]float64, len(params))
+		var id = i
+		for j := len(params) - 1; j >= 0; j-- {
+			point[j] = levels[j][id%len(levels[j])]
+			id /= len(levels[j])
+		}
+		points[i] = point
+	}
+	return Plan{Names: paramNames(params), Points: points}, nil
+}
+
+// LatinHypercube builds plan of pointNum points, each parameter range is split
+// into pointNum strata and every stratum contains exactly one point
+func LatinHypercube(params []Parameter, pointNum int, seed int64) (Plan, error) {
+	if err := checkParams(params); err != nil {
+		return Plan{}, err
+	}
+	if pointNum < 1 {
+		return Plan{}, fmt.Errorf("invalid point number %d", pointNum)
+	}
+
+	var rnd = rand.New(rand.NewSource(seed))
+	var points = make([][]float64, pointNum)
+	for i := range points {
+		points[i] = make([]float64, len(params))
+	}
+
+	for j, param := range params {
+		var perm = rnd.Perm(pointNum)
+		for i := range points {
+			var u = (float64(perm[i]) + rnd.Float64()) / float64(pointNum)
+			points[i][j] = scale(u, param)
+		}
+	}
+	return Plan{Names: paramNames(params), Points: points}, nil
+}
+
+// Sobol builds plan of the first pointNum points of Sobol low-discrepancy sequence
+// (zero point is skipped)
+func Sobol(params []Parameter, pointNum int) (Plan, error) {
+	if err := checkParams(params); err != nil {
+		return Plan{}, err
+	}
+	if pointNum < 1 {
+		return Plan{}, fmt.Errorf("invalid point number %d", pointNum)
+	}
+
+	var seq, err = NewSobolSequence(len(params))
+	if err != nil {
+		return Plan{}, err
+	}
+	seq.Next()
+
+	var points = make([][]float64, pointNum)
+	for i := range points {
+		var u = seq.Next()
+		var point = make([]float64, len(params))
+		for j, param := range params {
+			point[j] = scale(u[j], param)
+		}
+		points[i] = point
+	}
+	return Plan{Names: paramNames(params), Points: points}, nil
+}
+
+func scale(u float64, param Parameter) float64 {
+	return param.Min + u*(param.Max-param.Min)
+}
+
+func paramNames(params []Parameter) []string {
+	var result = make([]string, len(params))
+	for i, param := range params {
+		result[i] = param.Name
+	}
+	return result
+}
+
+func checkParams(params []Parameter) error {
+	if len(params) == 0 {
+		return errors.New("no parameters given")
+	}
+	var names = make(map[string]bool)
+	for _, param := range params {
+		if names[param.Name] {
+			return fmt.Errorf("duplicate parameter \"%s\"", param.Name)
+		}
+		names[param.Name] = true
+
+		if param.Max < param.Min {
+			return fmt.Errorf("parameter \"%s\" has max %f < min %f", param.Name, param.Max, param.Min)
+		}
+	}
+	return nil
+}
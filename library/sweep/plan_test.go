@@ -0,0 +1,98 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullFactorial(t *testing.T) {
+	var plan, err = FullFactorial([]Parameter{
+		NewParameter("a", 0, 1, 2),
+		NewParameter("b", 10, 30, 3),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, plan.Names)
+	assert.Equal(t, [][]float64{
+		{0, 10}, {0, 20}, {0, 30},
+		{1, 10}, {1, 20}, {1, 30},
+	}, plan.Points)
+}
+
+func TestFullFactorial_Errors(t *testing.T) {
+	var _, err = FullFactorial([]Parameter{NewParameter("a", 0, 1, 0)})
+	assert.NotNil(t, err)
+
+	_, err = FullFactorial([]Parameter{NewParameter("a", 0, 1, 2), NewParameter("a", 0, 1, 2)})
+	assert.NotNil(t, err)
+
+	_, err = FullFactorial([]Parameter{NewParameter("a", 1, 0, 2)})
+	assert.NotNil(t, err)
+}
+
+func TestLatinHypercube_Stratification(t *testing.T) {
+	var n = 20
+	var plan, err = LatinHypercube([]Parameter{
+		NewParameter("a", 0, 1, 0),
+		NewParameter("b", 100, 200, 0),
+	}, n, 42)
+	assert.Nil(t, err)
+	assert.Equal(t, n, plan.Len())
+
+	for j, param := range []Parameter{{Min: 0, Max: 1}, {Min: 100, Max: 200}} {
+		var filled = make([]bool, n)
+		for _, point := range plan.Points {
+			var stratum = int((point[j] - param.Min) / (param.Max - param.Min) * float64(n))
+			assert.False(t, filled[stratum])
+			filled[stratum] = true
+		}
+	}
+
+	var samePlan, _ = LatinHypercube([]Parameter{
+		NewParameter("a", 0, 1, 0),
+		NewParameter("b", 100, 200, 0),
+	}, n, 42)
+	assert.Equal(t, plan.Points, samePlan.Points)
+}
+
+func TestSobolSequence_FirstPoints(t *testing.T) {
+	var seq, err = NewSobolSequence(2)
+	assert.Nil(t, err)
+
+	var expected = [][]float64{
+		{0, 0}, {0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}, {0.875, 0.875},
+	}
+	for _, point := range expected {
+		assert.Equal(t, point, seq.Next())
+	}
+}
+
+func TestSobolSequence_Stratification(t *testing.T) {
+	var seq, err = NewSobolSequence(MaxSobolDim)
+	assert.Nil(t, err)
+
+	var n = 64
+	var filled = make([][]bool, MaxSobolDim)
+	for j := range filled {
+		filled[j] = make([]bool, n)
+	}
+	for i := 0; i != n; i++ {
+		for j, x := range seq.Next() {
+			var stratum = int(x * float64(n))
+			assert.False(t, filled[j][stratum], "dim %d, stratum %d", j, stratum)
+			filled[j][stratum] = true
+		}
+	}
+
+	_, err = NewSobolSequence(MaxSobolDim + 1)
+	assert.NotNil(t, err)
+}
+
+func TestSobol_Scaling(t *testing.T) {
+	var plan, err = Sobol([]Parameter{
+		NewParameter("a", 2, 4, 0),
+		NewParameter("b", -1, 1, 0),
+	}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]float64{{3, 0}, {3.5, -0.5}, {2.5, 0.5}}, plan.Points)
+}
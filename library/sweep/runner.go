@@ -0,0 +1,168 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+)
+
+// Row is the result of evaluation of a single plan point.
+// Outputs are ordered as output names of the runner;
+// if the point did not converge Outputs is nil
+type Row struct {
+	ID        int
+	Inputs    []float64
+	Outputs   []float64
+	Converged bool
+	Err       error
+}
+
+type Runner interface {
+	OutputNames() []string
+	// Run evaluates all plan points on the pool of workers and streams results.
+	// Rows are sent in order of completion, the channel is closed after the last row
+	Run(plan Plan) (<-chan Row, error)
+}
+
+// NewRunner creates runner with workerNum workers each of which owns
+// a separate model instance created with factory
+func NewRunner(factory Factory, outputNames []string, workerNum int) Runner {
+	return &runner{
+		factory:     factory,
+		outputNames: outputNames,
+		workerNum:   workerNum,
+	}
+}
+
+// Collect reads all the rows from the channel and returns them ordered by ID
+func Collect(rows <-chan Row) []Row {
+	var result []Row
+	for row := range rows {
+		result = append(result, row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+type runner struct {
+	factory     Factory
+	outputNames []string
+	workerNum   int
+}
+
+func (r *runner) OutputNames() []string {
+	return r.outputNames
+}
+
+func (r *runner) Run(plan Plan) (<-chan Row, error) {
+	if r.workerNum < 1 {
+		return nil, fmt.Errorf("invalid worker number %d", r.workerNum)
+	}
+	if len(r.outputNames) == 0 {
+		return nil, errors.New("no outputs requested")
+	}
+
+	var workerNum = r.workerNum
+	if plan.Len() < workerNum {
+		workerNum = plan.Len()
+	}
+
+	var workers = make([]*worker, workerNum)
+	for i := range workers {
+		var w, err = r.newWorker(plan.Names)
+		if err != nil {
+			return nil, err
+		}
+		workers[i] = w
+	}
+
+	var jobs = make(chan int)
+	var rows = make(chan Row, workerNum)
+	var wg sync.WaitGroup
+
+	for _, w := range workers {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			for id := range jobs {
+				rows <- w.evaluate(id, plan.Points[id])
+			}
+		}(w)
+	}
+
+	go func() {
+		for id := range plan.Points {
+			jobs <- id
+		}
+		close(jobs)
+		wg.Wait()
+		close(rows)
+	}()
+
+	return rows, nil
+}
+
+func (r *runner) newWorker(inputNames []string) (*worker, error) {
+	var m, err = r.factory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model: %s", err.Error())
+	}
+
+	var inputs = make([]variator.Variator, len(inputNames))
+	for i, name := range inputNames {
+		if inputs[i], err = m.Input(name); err != nil {
+			return nil, err
+		}
+	}
+	var outputNames = make(map[string]bool)
+	for _, name := range m.OutputNames() {
+		outputNames[name] = true
+	}
+	for _, name := range r.outputNames {
+		if !outputNames[name] {
+			return nil, fmt.Errorf("output \"%s\" not found", name)
+		}
+	}
+
+	return &worker{
+		model:       m,
+		inputs:      inputs,
+		outputNames: r.outputNames,
+	}, nil
+}
+
+type worker struct {
+	model       Model
+	inputs      []variator.Variator
+	outputNames []string
+}
+
+func (w *worker) evaluate(id int, point []float64) Row {
+	var row = Row{ID: id, Inputs: point}
+	for i, v := range w.inputs {
+		v.SetValue(point[i])
+	}
+
+	if err := w.model.Solve(); err != nil {
+		row.Err = err
+		return row
+	}
+
+	var outputs = make([]float64, len(w.outputNames))
+	for i, name := range w.outputNames {
+		var value, err = w.model.Output(name)
+		if err != nil {
+			row.Err = err
+			return row
+		}
+		outputs[i] = value
+	}
+	row.Outputs = outputs
+	row.Converged = true
+	return row
+}
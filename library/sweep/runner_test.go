@@ -0,0 +1,178 @@
+package sweep
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/compose"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive/emission"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/library/schemes"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner_Analytic(t *testing.T) {
+	var plan, _ = FullFactorial([]Parameter{
+		NewParameter("x", 0, 3, 4),
+		NewParameter("y", 1, 2, 2),
+	})
+	var runner = NewRunner(getProductFactory(), []string{"product"}, 3)
+
+	var rows, err = runner.Run(plan)
+	assert.Nil(t, err)
+
+	var result = Collect(rows)
+	assert.Equal(t, plan.Len(), len(result))
+	for i, row := range result {
+		assert.Equal(t, i, row.ID)
+		if row.Inputs[0] == 0 {
+			assert.False(t, row.Converged)
+			assert.NotNil(t, row.Err)
+			continue
+		}
+		assert.True(t, row.Converged)
+		assert.Nil(t, row.Err)
+		assert.InDelta(t, row.Inputs[0]*row.Inputs[1], row.Outputs[0], 1e-10)
+	}
+}
+
+func TestRunner_UnknownNames(t *testing.T) {
+	var plan, _ = FullFactorial([]Parameter{NewParameter("z", 0, 1, 2)})
+	var _, err = NewRunner(getProductFactory(), []string{"product"}, 2).Run(plan)
+	assert.NotNil(t, err)
+
+	plan, _ = FullFactorial([]Parameter{NewParameter("x", 0, 1, 2)})
+	_, err = NewRunner(getProductFactory(), []string{"sum"}, 2).Run(plan)
+	assert.NotNil(t, err)
+}
+
+func TestRunner_TwoShaftsScheme(t *testing.T) {
+	var plan, _ = FullFactorial([]Parameter{
+		NewParameter("pi", 6, 10, 3),
+		NewParameter("t_gas", 1300, 1500, 2),
+	})
+	var runner = NewRunner(
+		getTwoShaftsFactory(), []string{EfficiencyOutput, SpecificPowerOutput}, 4,
+	)
+
+	var rows, err = runner.Run(plan)
+	assert.Nil(t, err)
+
+	var result = Collect(rows)
+	assert.Equal(t, plan.Len(), len(result))
+	for _, row := range result {
+		assert.True(t, row.Converged, "%v", row.Err)
+		assert.True(t, row.Outputs[0] > 0.2 && row.Outputs[0] < 0.5, "%v", row.Outputs)
+		assert.True(t, row.Outputs[1] > 0)
+	}
+
+	// specific power grows with gas temperature
+	assert.True(t, result[1].Outputs[1] > result[0].Outputs[1])
+}
+
+func TestRunner_EmissionMap(t *testing.T) {
+	var plan, _ = FullFactorial([]Parameter{
+		NewParameter("t_gas", 1300, 1500, 3),
+	})
+	var factory = func() (Model, error) {
+		var scheme = getTwoShaftsScheme()
+		var network, err = scheme.GetNetwork()
+		if err != nil {
+			return nil, err
+		}
+
+		var burner = scheme.Burner().(constructive.TemperatureDefinedBurner)
+		burner.SetResidenceTime(5e-3)
+		burner.AddEmissionCorrelation(emission.NewLefebvreNOx(10, 6e5, 480, 1400, 5e-3))
+
+		return NewModel(
+			NetworkSolveFunc(network, 0.5, 1, 100, 1e-3),
+			map[string]variator.Variator{
+				"t_gas": variator.FromCallables(burner.TgStag, burner.SetTgStag),
+			},
+			BurnerEmissionOutputs("burner", burner),
+		), nil
+	}
+
+	var rows, err = NewRunner(factory, []string{"burner_nox_ei", "burner_nox_ppm15"}, 2).Run(plan)
+	assert.Nil(t, err)
+
+	var result = Collect(rows)
+	for i, row := range result {
+		assert.True(t, row.Converged, "%v", row.Err)
+		assert.True(t, row.Outputs[1] > 0)
+		if i > 0 {
+			assert.True(t, row.Outputs[0] > result[i-1].Outputs[0])
+		}
+	}
+}
+
+func getProductFactory() Factory {
+	return func() (Model, error) {
+		var x, y, product float64
+		return NewModel(
+			func() error {
+				if x == 0 {
+					return errors.New("zero x")
+				}
+				product = x * y
+				return nil
+			},
+			map[string]variator.Variator{
+				"x": variator.FromPointer(&x),
+				"y": variator.FromPointer(&y),
+			},
+			map[string]func() float64{
+				"product": func() float64 { return product },
+			},
+		), nil
+	}
+}
+
+func getTwoShaftsFactory() Factory {
+	return func() (Model, error) {
+		var scheme = getTwoShaftsScheme()
+		var network, err = scheme.GetNetwork()
+		if err != nil {
+			return nil, err
+		}
+
+		var compressor = scheme.Compressor()
+		var burner = scheme.Burner().(constructive.TemperatureDefinedBurner)
+		return NewModel(
+			NetworkSolveFunc(network, 0.5, 1, 100, 1e-3),
+			map[string]variator.Variator{
+				"pi":    variator.FromCallables(compressor.PiStag, compressor.SetPiStag),
+				"t_gas": variator.FromCallables(burner.TgStag, burner.SetTgStag),
+			},
+			SchemeOutputs(scheme),
+		), nil
+	}
+}
+
+func getTwoShaftsScheme() schemes.TwoShaftsScheme {
+	var zeroFunc = func(node constructive.TurbineNode) float64 {
+		return 0
+	}
+	var gasSource = source.NewComplexGasSourceNode(gases.GetAir(), 288, 1e5, 1)
+	var inletPressureDrop = constructive.NewPressureLossNode(0.98)
+	var gasGenerator = compose.NewGasGeneratorNode(
+		0.86, 6, fuel.GetCH4(),
+		1400, 300, 0.99, 0.99, 3, 300,
+		0.9, 0.3, zeroFunc, zeroFunc, zeroFunc,
+		0.99, 0.05, 1, nodes.DefaultN,
+	)
+	var compressorTurbinePipe = constructive.NewPressureLossNode(0.98)
+	var freeTurbineBlock = compose.NewFreeTurbineBlock(
+		1e5,
+		0.92, 0.3, 0.05, zeroFunc, zeroFunc, zeroFunc, 0.9,
+	)
+	return schemes.NewTwoShaftsScheme(
+		gasSource, inletPressureDrop, gasGenerator, compressorTurbinePipe, freeTurbineBlock,
+	)
+}
@@ -0,0 +1,107 @@
+package sweep
+
+import "fmt"
+
+const sobolBits = 52
+
+// sobolDirections contains primitive polynomials and initial direction numbers
+// of Joe and Kuo (new-joe-kuo-6.21201) for dimensions 2..21.
+// First dimension uses van der Corput sequence
+var sobolDirections = []struct {
+	s int
+	a uint64
+	m []uint64
+}{
+	{1, 0, []uint64{1}},
+	{2, 1, []uint64{1, 3}},
+	{3, 1, []uint64{1, 3, 1}},
+	{3, 2, []uint64{1, 1, 1}},
+	{4, 1, []uint64{1, 1, 3, 3}},
+	{4, 4, []uint64{1, 3, 5, 13}},
+	{5, 2, []uint64{1, 1, 5, 5, 17}},
+	{5, 4, []uint64{1, 1, 5, 5, 5}},
+	{5, 7, []uint64{1, 1, 7, 11, 19}},
+	{5, 11, []uint64{1, 1, 5, 1, 1}},
+	{5, 13, []uint64{1, 1, 1, 3, 11}},
+	{5, 14, []uint64{1, 3, 5, 5, 31}},
+	{6, 1, []uint64{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint64{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint64{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint64{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint64{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint64{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint64{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint64{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// MaxSobolDim is the maximal dimension supported by SobolSequence
+var MaxSobolDim = len(sobolDirections) + 1
+
+type SobolSequence interface {
+	Dim() int
+	// Next returns next point of the sequence; first point is always zero
+	Next() []float64
+}
+
+// NewSobolSequence returns Sobol sequence generator using gray code ordering
+func NewSobolSequence(dim int) (SobolSequence, error) {
+	if dim < 1 || dim > MaxSobolDim {
+		return nil, fmt.Errorf("sobol sequence dimension must be in [1, %d], got %d", MaxSobolDim, dim)
+	}
+
+	var directions = make([][]uint64, dim)
+	directions[0] = make([]uint64, sobolBits)
+	for i := range directions[0] {
+		directions[0][i] = 1 << uint(sobolBits-1-i)
+	}
+
+	for j := 1; j < dim; j++ {
+		var d = sobolDirections[j-1]
+		var v = make([]uint64, sobolBits)
+		for i := 0; i < d.s; i++ {
+			v[i] = d.m[i] << uint(sobolBits-1-i)
+		}
+		for i := d.s; i < sobolBits; i++ {
+			v[i] = v[i-d.s] ^ (v[i-d.s] >> uint(d.s))
+			for k := 1; k < d.s; k++ {
+				v[i] ^= ((d.a >> uint(d.s-1-k)) & 1) * v[i-k]
+			}
+		}
+		directions[j] = v
+	}
+
+	return &sobolSequence{
+		directions: directions,
+		x:          make([]uint64, dim),
+	}, nil
+}
+
+type sobolSequence struct {
+	directions [][]uint64
+	x          []uint64
+	index      uint64
+}
+
+func (seq *sobolSequence) Dim() int {
+	return len(seq.directions)
+}
+
+func (seq *sobolSequence) Next() []float64 {
+	var result = make([]float64, len(seq.x))
+	var denom = float64(uint64(1) << sobolBits)
+	for j, x := range seq.x {
+		result[j] = float64(x) / denom
+	}
+
+	// index of the rightmost zero bit of the current index
+	var c = 0
+	for i := seq.index; i&1 == 1; i >>= 1 {
+		c++
+	}
+	for j := range seq.x {
+		seq.x[j] ^= seq.directions[j][c]
+	}
+	seq.index++
+
+	return result
+}
@@ -0,0 +1,97 @@
+package uncertainty
+
+import (
+	"fmt"
+	"math"
+)
+
+type Distribution interface {
+	// Quantile is the inverse of cumulative distribution function, p must be in (0, 1)
+	Quantile(p float64) float64
+	Mean() float64
+	Std() float64
+}
+
+func NewNormal(mean, std float64) (Distribution, error) {
+	if std <= 0 {
+		return nil, fmt.Errorf("invalid std %f", std)
+	}
+	return normal{mean: mean, std: std}, nil
+}
+
+func NewUniform(min, max float64) (Distribution, error) {
+	if max <= min {
+		return nil, fmt.Errorf("invalid range [%f, %f]", min, max)
+	}
+	return uniform{min: min, max: max}, nil
+}
+
+func NewTriangular(min, mode, max float64) (Distribution, error) {
+	if max <= min || mode < min || mode > max {
+		return nil, fmt.Errorf("invalid triangular parameters min = %f, mode = %f, max = %f", min, mode, max)
+	}
+	return triangular{min: min, mode: mode, max: max}, nil
+}
+
+// StdNormalCDF is the cumulative distribution function of the standard normal distribution
+func StdNormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+type normal struct {
+	mean float64
+	std  float64
+}
+
+func (d normal) Quantile(p float64) float64 {
+	return d.mean + d.std*math.Sqrt2*math.Erfinv(2*p-1)
+}
+
+func (d normal) Mean() float64 {
+	return d.mean
+}
+
+func (d normal) Std() float64 {
+	return d.std
+}
+
+type uniform struct {
+	min float64
+	max float64
+}
+
+func (d uniform) Quantile(p float64) float64 {
+	return d.min + p*(d.max-d.min)
+}
+
+func (d uniform) Mean() float64 {
+	return (d.min + d.max) / 2
+}
+
+func (d uniform) Std() float64 {
+	return (d.max - d.min) / math.Sqrt(12)
+}
+
+type triangular struct {
+	min  float64
+	mode float64
+	max  float64
+}
+
+func (d triangular) Quantile(p float64) float64 {
+	var width = d.max - d.min
+	var pMode = (d.mode - d.min) / width
+	if p < pMode {
+		return d.min + math.Sqrt(p*width*(d.mode-d.min))
+	}
+	return d.max - math.Sqrt((1-p)*width*(d.max-d.mode))
+}
+
+func (d triangular) Mean() float64 {
+	return (d.min + d.mode + d.max) / 3
+}
+
+func (d triangular) Std() float64 {
+	var a, b, c = d.min, d.max, d.mode
+	return math.Sqrt((a*a + b*b + c*c - a*b - a*c - b*c) / 18)
+}
@@ -0,0 +1,85 @@
+package uncertainty
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"gonum.org/v1/gonum/mat"
+)
+
+type MonteCarlo interface {
+	// Run evaluates sampleNum seeded samples of the inputs; samples which failed
+	// to converge are excluded from statistics and counted in the result
+	Run(inputs []Input, correlation mat.Symmetric, sampleNum int, seed int64) (MonteCarloResult, error)
+}
+
+type MonteCarloResult struct {
+	OutputNames []string
+	Rows        []sweep.Row
+	FailedNum   int
+	Statistics  []Statistics
+}
+
+func (r MonteCarloResult) OutputStatistics(name string) (Statistics, error) {
+	for i, outputName := range r.OutputNames {
+		if outputName == name {
+			return r.Statistics[i], nil
+		}
+	}
+	return nil, fmt.Errorf("output \"%s\" not found", name)
+}
+
+func NewMonteCarlo(factory sweep.Factory, outputNames []string, workerNum int) MonteCarlo {
+	return &monteCarlo{
+		runner:      sweep.NewRunner(factory, outputNames, workerNum),
+		outputNames: outputNames,
+	}
+}
+
+type monteCarlo struct {
+	runner      sweep.Runner
+	outputNames []string
+}
+
+func (mc *monteCarlo) Run(inputs []Input, correlation mat.Symmetric, sampleNum int, seed int64) (MonteCarloResult, error) {
+	var plan, err = Sample(inputs, correlation, sampleNum, seed)
+	if err != nil {
+		return MonteCarloResult{}, err
+	}
+
+	var rowChan, runErr = mc.runner.Run(plan)
+	if runErr != nil {
+		return MonteCarloResult{}, runErr
+	}
+	var rows = sweep.Collect(rowChan)
+
+	var values = make([][]float64, len(mc.outputNames))
+	var failedNum = 0
+	for _, row := range rows {
+		if !row.Converged {
+			failedNum++
+			continue
+		}
+		for i, y := range row.Outputs {
+			values[i] = append(values[i], y)
+		}
+	}
+	if failedNum == len(rows) {
+		return MonteCarloResult{}, errors.New("all the samples failed")
+	}
+
+	var stats = make([]Statistics, len(mc.outputNames))
+	for i := range stats {
+		if stats[i], err = NewStatistics(values[i]); err != nil {
+			return MonteCarloResult{}, err
+		}
+	}
+
+	return MonteCarloResult{
+		OutputNames: mc.outputNames,
+		Rows:        rows,
+		FailedNum:   failedNum,
+		Statistics:  stats,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package uncertainty
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"gonum.org/v1/gonum/mat"
+)
+
+// probabilities are clipped to avoid infinite quantiles of unbounded distributions
+const minProbability = 1e-12
+
+// Input is a named model input with attached probability distribution
+type Input struct {
+	Name         string
+	Distribution Distribution
+}
+
+func NewInput(name string, distribution Distribution) Input {
+	return Input{Name: name, Distribution: distribution}
+}
+
+// Sample draws sampleNum random points of the inputs.
+// Correlation is the matrix of correlation coefficients of the inputs
+// imposed with gaussian copula; nil correlation means independent inputs
+func Sample(inputs []Input, correlation mat.Symmetric, sampleNum int, seed int64) (sweep.Plan, error) {
+	if len(inputs) == 0 {
+		return sweep.Plan{}, errors.New("no inputs given")
+	}
+	if sampleNum < 1 {
+		return sweep.Plan{}, fmt.Errorf("invalid sample number %d", sampleNum)
+	}
+
+	var chol *mat.Cholesky
+	if correlation != nil {
+		var err error
+		if chol, err = getCholesky(correlation, len(inputs)); err != nil {
+			return sweep.Plan{}, err
+		}
+	}
+
+	var rnd = rand.New(rand.NewSource(seed))
+	var lower mat.TriDense
+	if chol != nil {
+		chol.LTo(&lower)
+	}
+
+	var names = make([]string, len(inputs))
+	for i, input := range inputs {
+		names[i] = input.Name
+	}
+
+	var points = make([][]float64, sampleNum)
+	var z = mat.NewVecDense(len(inputs), nil)
+	var zCorr = mat.NewVecDense(len(inputs), nil)
+	for k := range points {
+		for i := range inputs {
+			z.SetVec(i, rnd.NormFloat64())
+		}
+		if chol != nil {
+			zCorr.MulVec(&lower, z)
+		} else {
+			zCorr.CopyVec(z)
+		}
+
+		var point = make([]float64, len(inputs))
+		for i, input := range inputs {
+			point[i] = input.Distribution.Quantile(clipProbability(StdNormalCDF(zCorr.AtVec(i))))
+		}
+		points[k] = point
+	}
+	return sweep.NewPlan(names, points)
+}
+
+func getCholesky(correlation mat.Symmetric, dim int) (*mat.Cholesky, error) {
+	if n, _ := correlation.Dims(); n != dim {
+		return nil, fmt.Errorf("correlation matrix dimension %d does not match inputs number %d", n, dim)
+	}
+	for i := 0; i != dim; i++ {
+		if correlation.At(i, i) != 1 {
+			return nil, fmt.Errorf("correlation matrix diagonal element %d is %f != 1", i, correlation.At(i, i))
+		}
+	}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(correlation); !ok {
+		return nil, errors.New("correlation matrix is not positive definite")
+	}
+	return &chol, nil
+}
+
+func clipProbability(p float64) float64 {
+	if p < minProbability {
+		return minProbability
+	}
+	if p > 1-minProbability {
+		return 1 - minProbability
+	}
+	return p
+}
@@ -0,0 +1,130 @@
+package uncertainty
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Statistics describes sample of a single output
+type Statistics interface {
+	Len() int
+	Values() []float64
+	Mean() float64
+	Std() float64
+	Min() float64
+	Max() float64
+	// Percentile returns linearly interpolated percentile, p must be in [0, 100]
+	Percentile(p float64) (float64, error)
+	Histogram(binNum int) (Histogram, error)
+}
+
+// Histogram contains len(Counts) bins, bin i is [Edges[i], Edges[i + 1])
+// (the last bin includes its right edge)
+type Histogram struct {
+	Edges  []float64
+	Counts []int
+}
+
+func NewStatistics(values []float64) (Statistics, error) {
+	if len(values) == 0 {
+		return nil, errors.New("empty sample")
+	}
+
+	var sorted = make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	var mean float64
+	for _, x := range sorted {
+		mean += x
+	}
+	mean /= float64(len(sorted))
+
+	var variance float64
+	for _, x := range sorted {
+		variance += (x - mean) * (x - mean)
+	}
+	if len(sorted) > 1 {
+		variance /= float64(len(sorted) - 1)
+	}
+
+	return &statistics{
+		sorted: sorted,
+		mean:   mean,
+		std:    math.Sqrt(variance),
+	}, nil
+}
+
+type statistics struct {
+	sorted []float64
+	mean   float64
+	std    float64
+}
+
+func (s *statistics) Len() int {
+	return len(s.sorted)
+}
+
+func (s *statistics) Values() []float64 {
+	return s.sorted
+}
+
+func (s *statistics) Mean() float64 {
+	return s.mean
+}
+
+func (s *statistics) Std() float64 {
+	return s.std
+}
+
+func (s *statistics) Min() float64 {
+	return s.sorted[0]
+}
+
+func (s *statistics) Max() float64 {
+	return s.sorted[len(s.sorted)-1]
+}
+
+func (s *statistics) Percentile(p float64) (float64, error) {
+	if p < 0 || p > 100 {
+		return 0, fmt.Errorf("invalid percentile %f", p)
+	}
+
+	var pos = p / 100 * float64(len(s.sorted)-1)
+	var i = int(math.Floor(pos))
+	if i >= len(s.sorted)-1 {
+		return s.Max(), nil
+	}
+	var frac = pos - float64(i)
+	return s.sorted[i]*(1-frac) + s.sorted[i+1]*frac, nil
+}
+
+func (s *statistics) Histogram(binNum int) (Histogram, error) {
+	if binNum < 1 {
+		return Histogram{}, fmt.Errorf("invalid bin number %d", binNum)
+	}
+
+	var min, max = s.Min(), s.Max()
+	var width = (max - min) / float64(binNum)
+
+	var edges = make([]float64, binNum+1)
+	for i := range edges {
+		edges[i] = min + width*float64(i)
+	}
+	edges[binNum] = max
+
+	var counts = make([]int, binNum)
+	for _, x := range s.sorted {
+		var bin = binNum - 1
+		if width > 0 {
+			bin = int((x - min) / width)
+		}
+		if bin >= binNum {
+			bin = binNum - 1
+		}
+		counts[bin]++
+	}
+	return Histogram{Edges: edges, Counts: counts}, nil
+}
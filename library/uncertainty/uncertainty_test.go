@@ -0,0 +1,119 @@
+package uncertainty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/core/math/variator"
+	"github.com/Sovianum/turbocycle/library/sweep"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDistributions(t *testing.T) {
+	var n, _ = NewNormal(10, 2)
+	assert.InDelta(t, 10, n.Quantile(0.5), 1e-12)
+	assert.InDelta(t, 10+2*1.959964, n.Quantile(0.975), 1e-5)
+
+	var u, _ = NewUniform(2, 6)
+	assert.InDelta(t, 3, u.Quantile(0.25), 1e-12)
+	assert.InDelta(t, 4/math.Sqrt(12), u.Std(), 1e-12)
+
+	var tr, _ = NewTriangular(0, 1, 4)
+	assert.InDelta(t, 1, tr.Quantile(0.25), 1e-12)
+	assert.InDelta(t, 4, tr.Quantile(1), 1e-12)
+	assert.InDelta(t, 5./3, tr.Mean(), 1e-12)
+
+	var _, err = NewNormal(0, 0)
+	assert.NotNil(t, err)
+	_, err = NewTriangular(0, 5, 4)
+	assert.NotNil(t, err)
+}
+
+func TestStatistics(t *testing.T) {
+	var stats, err = NewStatistics([]float64{4, 1, 3, 2, 5})
+	assert.Nil(t, err)
+
+	assert.InDelta(t, 3, stats.Mean(), 1e-12)
+	assert.InDelta(t, math.Sqrt(2.5), stats.Std(), 1e-12)
+
+	var p, _ = stats.Percentile(50)
+	assert.InDelta(t, 3, p, 1e-12)
+	p, _ = stats.Percentile(90)
+	assert.InDelta(t, 4.6, p, 1e-12)
+	p, _ = stats.Percentile(100)
+	assert.InDelta(t, 5, p, 1e-12)
+
+	var hist, histErr = stats.Histogram(2)
+	assert.Nil(t, histErr)
+	assert.Equal(t, []float64{1, 3, 5}, hist.Edges)
+	assert.Equal(t, []int{2, 3}, hist.Counts)
+}
+
+func TestSample_Correlation(t *testing.T) {
+	var a, _ = NewNormal(0, 1)
+	var b, _ = NewUniform(0, 1)
+	var corr = mat.NewSymDense(2, []float64{1, 0.8, 0.8, 1})
+
+	var plan, err = Sample([]Input{NewInput("a", a), NewInput("b", b)}, corr, 5000, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 5000, plan.Len())
+
+	var x, y = make([]float64, plan.Len()), make([]float64, plan.Len())
+	for i, point := range plan.Points {
+		x[i], y[i] = point[0], point[1]
+		assert.True(t, point[1] >= 0 && point[1] <= 1)
+	}
+	assert.InDelta(t, 0.8, correlation(x, y), 0.03)
+
+	var samePlan, _ = Sample([]Input{NewInput("a", a), NewInput("b", b)}, corr, 5000, 1)
+	assert.Equal(t, plan.Points, samePlan.Points)
+
+	_, err = Sample([]Input{NewInput("a", a), NewInput("b", b)}, mat.NewSymDense(2, []float64{1, 2, 2, 1}), 10, 1)
+	assert.NotNil(t, err)
+}
+
+func TestMonteCarlo_Linear(t *testing.T) {
+	var factory = func() (sweep.Model, error) {
+		var a, b, y float64
+		return sweep.NewModel(
+			func() error {
+				y = a + 2*b
+				return nil
+			},
+			map[string]variator.Variator{
+				"a": variator.FromPointer(&a),
+				"b": variator.FromPointer(&b),
+			},
+			map[string]func() float64{"y": func() float64 { return y }},
+		), nil
+	}
+
+	var a, _ = NewNormal(1, 0.1)
+	var b, _ = NewNormal(2, 0.1)
+	var result, err = NewMonteCarlo(factory, []string{"y"}, 4).Run(
+		[]Input{NewInput("a", a), NewInput("b", b)}, nil, 4000, 7,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.FailedNum)
+
+	var stats, statsErr = result.OutputStatistics("y")
+	assert.Nil(t, statsErr)
+	assert.Equal(t, 4000, stats.Len())
+	assert.InDelta(t, 5, stats.Mean(), 0.01)
+	assert.InDelta(t, math.Sqrt(0.05), stats.Std(), 0.01)
+
+	var p95, _ = stats.Percentile(95)
+	assert.InDelta(t, 5+1.645*math.Sqrt(0.05), p95, 0.02)
+}
+
+func correlation(x, y []float64) float64 {
+	var xStats, _ = NewStatistics(x)
+	var yStats, _ = NewStatistics(y)
+	var cov float64
+	for i := range x {
+		cov += (x[i] - xStats.Mean()) * (y[i] - yStats.Mean())
+	}
+	cov /= float64(len(x) - 1)
+	return cov / (xStats.Std() * yStats.Std())
+}
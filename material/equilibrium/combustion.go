@@ -0,0 +1,131 @@
+package equilibrium
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+// species which table gases of the gases package are made of
+var tableGasesSpecies = map[string]gases.Gas{
+	gases.GetAir().String():       species.DryAir(),
+	gases.GetNitrogen().String():  species.MustGet(species.NitrogenName),
+	gases.GetOxygen().String():    species.MustGet(species.OxygenName),
+	gases.GetCO2().String():       species.MustGet(species.CarbonDioxideName),
+	gases.GetH2OVapour().String(): species.MustGet(species.WaterName),
+}
+
+// Elements returns moles of chemical elements in unit mass of gas.
+// Gas must be composed of species or table gases of the gases package
+func Elements(gas gases.Gas) (map[string]float64, error) {
+	var speciesList, massFractions, err = expand(gas)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make(map[string]float64)
+	for i, sp := range speciesList {
+		for element, num := range sp.Elements() {
+			result[element] += massFractions[i] * num / sp.MolarMass()
+		}
+	}
+	for element, amount := range result {
+		if amount < -1e-12 {
+			return nil, fmt.Errorf("gas %s contains negative amount of %s", gas, element)
+		}
+		result[element] = math.Max(amount, 0)
+	}
+	return result, nil
+}
+
+// expand represents gas as a mixture of species
+func expand(gas gases.Gas) ([]species.Species, []float64, error) {
+	var speciesList []species.Species
+	var massFractions []float64
+
+	var composition = gases.Flatten(gas)
+	for i, component := range composition.Components() {
+		var massFraction = composition.MassFractions()[i]
+		if sp, ok := component.(species.Species); ok {
+			speciesList = append(speciesList, sp)
+			massFractions = append(massFractions, massFraction)
+			continue
+		}
+
+		var tableGas, ok = tableGasesSpecies[component.String()]
+		if !ok {
+			return nil, nil, fmt.Errorf("gas %s is not composed of species", component)
+		}
+		var tableSpecies, tableFractions, _ = expand(tableGas)
+		for k, sp := range tableSpecies {
+			speciesList = append(speciesList, sp)
+			massFractions = append(massFractions, massFraction*tableFractions[k])
+		}
+	}
+	return speciesList, massFractions, nil
+}
+
+// CombustionElements returns moles of elements in unit mass of products of
+// burning fuelMassRel kg of fuel in unit mass of inletGas
+func CombustionElements(inletGas gases.Gas, f fuel.Fuel, fuelMassRel float64) (map[string]float64, error) {
+	var gasElements, err = Elements(inletGas)
+	if err != nil {
+		return nil, err
+	}
+	fuelElements, err := fuel.Elements(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make(map[string]float64)
+	for element, amount := range gasElements {
+		result[element] += amount / (1 + fuelMassRel)
+	}
+	for element, amount := range fuelElements {
+		result[element] += amount * fuelMassRel / (1 + fuelMassRel)
+	}
+	return result, nil
+}
+
+// CombustionGas returns equilibrium products of burning fuelMassRel kg of fuel
+// in unit mass of inletGas at temperature t and pressure p
+func CombustionGas(
+	solver Solver, inletGas gases.Gas, f fuel.Fuel, fuelMassRel, t, p float64,
+) (gases.Composition, error) {
+	var elements, err = CombustionElements(inletGas, f, fuelMassRel)
+	if err != nil {
+		return nil, err
+	}
+	return solver.Solve(elements, t, p)
+}
+
+// DissociationHeat returns chemical energy (J / kg) which is not released in gas
+// compared to the state where all carbon is oxidised to CO2, all hydrogen to H2O and
+// sulfur to SO2. It is positive for dissociated or incompletely burnt gases
+func DissociationHeat(gas gases.Gas) (float64, error) {
+	var speciesList, massFractions, err = expand(gas)
+	if err != nil {
+		return 0, err
+	}
+	elements, err := Elements(gas)
+	if err != nil {
+		return 0, err
+	}
+
+	var formation = 0.
+	for i, sp := range speciesList {
+		formation += massFractions[i] * sp.HFormation()
+	}
+	var referenceFormation = elements["C"]*molarFormation(species.CarbonDioxideName) +
+		elements["H"]/2*molarFormation(species.WaterName) +
+		elements["S"]*molarFormation(species.SulfurDioxideName)
+	return formation - referenceFormation, nil
+}
+
+func molarFormation(name string) float64 {
+	var s = species.MustGet(name)
+	return s.HFormation() * s.MolarMass()
+}
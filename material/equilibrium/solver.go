@@ -0,0 +1,255 @@
+package equilibrium
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// mole fraction below which species is considered as trace one while limiting Newton step
+	traceFraction = 1e-8
+	// ln of the mole fraction trace species are not allowed to step over at once
+	traceLog = -9.2103404
+)
+
+// Solver finds chemical equilibrium composition of ideal gas mixture of its species
+// by minimisation of Gibbs energy at constant temperature and pressure
+type Solver interface {
+	Species() []species.Species
+	// Solve returns equilibrium mixture of the solver species containing elements
+	// (mol of element per unit mass of mixture) at temperature t and pressure p.
+	// Species containing absent elements are excluded
+	Solve(elements map[string]float64, t, p float64) (gases.Composition, error)
+}
+
+// CombustionSpecies returns species of the products of C-H-O-N-S fuels combustion in air
+// including dissociation products
+func CombustionSpecies() []species.Species {
+	var names = []string{
+		species.NitrogenName, species.OxygenName, species.ArgonName,
+		species.CarbonDioxideName, species.WaterName, species.SulfurDioxideName,
+		species.CarbonMonoxideName, species.HydrogenName, species.HydroxylName,
+		species.AtomicOxygenName, species.AtomicHydrogenName, species.NitricOxideName,
+	}
+	var result = make([]species.Species, len(names))
+	for i, name := range names {
+		result[i] = species.MustGet(name)
+	}
+	return result
+}
+
+// NewCombustionSolver returns solver over CombustionSpecies
+func NewCombustionSolver() Solver {
+	return NewSolver(CombustionSpecies(), 1e-9, 200)
+}
+
+func NewSolver(speciesList []species.Species, precision float64, iterLimit int) Solver {
+	return &solver{
+		species:   speciesList,
+		precision: precision,
+		iterLimit: iterLimit,
+	}
+}
+
+type solver struct {
+	species   []species.Species
+	precision float64
+	iterLimit int
+}
+
+func (s *solver) Species() []species.Species {
+	return s.species
+}
+
+func (s *solver) Solve(elements map[string]float64, t, p float64) (gases.Composition, error) {
+	var problem, err = s.newProblem(elements)
+	if err != nil {
+		return nil, err
+	}
+	var moles, solveErr = problem.solve(t, p, s.precision, s.iterLimit)
+	if solveErr != nil {
+		return nil, solveErr
+	}
+
+	var gasList = make([]gases.Gas, len(problem.species))
+	var massFractions = make([]float64, len(problem.species))
+	var mass = 0.
+	for j, sp := range problem.species {
+		gasList[j] = sp
+		massFractions[j] = moles[j] * sp.MolarMass()
+		mass += massFractions[j]
+	}
+	for j := range massFractions {
+		massFractions[j] /= mass
+	}
+	return gases.NewMixture(gasList, massFractions), nil
+}
+
+// problem keeps only species all elements of which are present.
+// Element amounts are normalized, so that their sum is equal to 1
+type problem struct {
+	species  []species.Species
+	elements []string
+	a        [][]float64 // a[k][j] is number of atoms of element k in species j
+	b        []float64
+	scale    float64
+}
+
+func (s *solver) newProblem(elements map[string]float64) (*problem, error) {
+	var result = &problem{}
+	for element, amount := range elements {
+		if amount < 0 {
+			return nil, fmt.Errorf("negative amount %f of element %s", amount, element)
+		}
+		if amount > 0 {
+			result.elements = append(result.elements, element)
+			result.scale += amount
+		}
+	}
+	if result.scale == 0 {
+		return nil, fmt.Errorf("empty element composition")
+	}
+	sort.Strings(result.elements)
+
+	var present = make(map[string]bool)
+	for _, element := range result.elements {
+		present[element] = true
+	}
+	for _, sp := range s.species {
+		var ok = true
+		for element, num := range sp.Elements() {
+			if num != 0 && !present[element] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			result.species = append(result.species, sp)
+		}
+	}
+
+	result.a = make([][]float64, len(result.elements))
+	result.b = make([]float64, len(result.elements))
+	for k, element := range result.elements {
+		result.b[k] = elements[element] / result.scale
+		result.a[k] = make([]float64, len(result.species))
+		var found = false
+		for j, sp := range result.species {
+			result.a[k][j] = sp.Elements()[element]
+			found = found || result.a[k][j] != 0
+		}
+		if !found {
+			return nil, fmt.Errorf("element %s is not contained in any species", element)
+		}
+	}
+	return result, nil
+}
+
+// solve implements the iteration scheme of Gordon and McBride (NASA RP-1311)
+// for fixed temperature and pressure. Returns moles of species
+func (pr *problem) solve(t, p, precision float64, iterLimit int) ([]float64, error) {
+	var ns = len(pr.species)
+	var ne = len(pr.elements)
+
+	var g0 = make([]float64, ns)
+	for j, sp := range pr.species {
+		g0[j] = (sp.HAbs(t)-t*sp.S0Abs(t))/(sp.R()*t) + math.Log(p/gases.PRef)
+	}
+
+	var n = 0.1
+	var lnN = math.Log(n)
+	var lnNj = make([]float64, ns)
+	for j := range lnNj {
+		lnNj[j] = math.Log(n / float64(ns))
+	}
+
+	var nj = make([]float64, ns)
+	var mu = make([]float64, ns)
+	var dLnNj = make([]float64, ns)
+	var size = ne + 1
+
+	for iter := 0; iter < iterLimit; iter++ {
+		var nSum = 0.
+		for j := range nj {
+			nj[j] = math.Exp(lnNj[j])
+			mu[j] = g0[j] + lnNj[j] - lnN
+			nSum += nj[j]
+		}
+
+		var matrix = mat.NewDense(size, size, nil)
+		var rhs = mat.NewVecDense(size, nil)
+		for k := 0; k < ne; k++ {
+			var bk = 0.
+			var rk = 0.
+			var ak = 0.
+			for j := 0; j < ns; j++ {
+				bk += pr.a[k][j] * nj[j]
+				rk += pr.a[k][j] * nj[j] * mu[j]
+				ak += pr.a[k][j] * nj[j]
+			}
+			for i := 0; i < ne; i++ {
+				var value = 0.
+				for j := 0; j < ns; j++ {
+					value += pr.a[k][j] * pr.a[i][j] * nj[j]
+				}
+				matrix.Set(k, i, value)
+			}
+			matrix.Set(k, ne, ak)
+			matrix.Set(ne, k, ak)
+			rhs.SetVec(k, pr.b[k]-bk+rk)
+		}
+		var rTotal = 0.
+		for j := 0; j < ns; j++ {
+			rTotal += nj[j] * mu[j]
+		}
+		matrix.Set(ne, ne, nSum-n)
+		rhs.SetVec(ne, n-nSum+rTotal)
+
+		var x = mat.NewVecDense(size, nil)
+		if err := x.SolveVec(matrix, rhs); err != nil {
+			return nil, fmt.Errorf("equilibrium at t = %f, p = %f: %v", t, p, err)
+		}
+		var dLnN = x.AtVec(ne)
+
+		var maxMajor = 5 * math.Abs(dLnN)
+		var lambda2 = 1.
+		for j := 0; j < ns; j++ {
+			dLnNj[j] = -mu[j] + dLnN
+			for k := 0; k < ne; k++ {
+				dLnNj[j] += pr.a[k][j] * x.AtVec(k)
+			}
+			if nj[j]/n > traceFraction {
+				maxMajor = math.Max(maxMajor, math.Abs(dLnNj[j]))
+			} else if dLnNj[j] > 0 {
+				var limit = math.Abs((-lnNj[j] + lnN + traceLog) / (dLnNj[j] - dLnN))
+				lambda2 = math.Min(lambda2, limit)
+			}
+		}
+		var lambda = math.Min(1, lambda2)
+		if maxMajor > 2 {
+			lambda = math.Min(lambda, 2/maxMajor)
+		}
+
+		var converged = math.Abs(dLnN)*nSum/n <= precision
+		for j := 0; j < ns; j++ {
+			lnNj[j] += lambda * dLnNj[j]
+			converged = converged && nj[j]*math.Abs(dLnNj[j])/nSum <= precision
+		}
+		lnN += lambda * dLnN
+		n = math.Exp(lnN)
+
+		if converged {
+			var result = make([]float64, ns)
+			for j := range result {
+				result[j] = math.Exp(lnNj[j]) * pr.scale
+			}
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("equilibrium at t = %f, p = %f has not converged in %d iterations", t, p, iterLimit)
+}
@@ -0,0 +1,112 @@
+package equilibrium
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolver_ElementBalance(t *testing.T) {
+	var solver = NewCombustionSolver()
+	var air = gases.GetAir()
+	var ch4 = fuel.GetCH4()
+	var fuelMassRel = 1 / ch4.GasMassTheory(air)
+
+	var elements, err = CombustionElements(air, ch4, fuelMassRel)
+	assert.Nil(t, err)
+
+	gas, err := solver.Solve(elements, 2200, 1e5)
+	assert.Nil(t, err)
+
+	products, err := Elements(gas)
+	assert.Nil(t, err)
+	for element, amount := range elements {
+		// species molar masses are not exactly equal to sums of atomic masses
+		assert.InDelta(t, amount, products[element], 1e-5*amount, element)
+	}
+
+	for _, name := range []string{
+		species.CarbonMonoxideName, species.HydrogenName, species.HydroxylName,
+		species.AtomicOxygenName, species.AtomicHydrogenName, species.NitricOxideName,
+	} {
+		assert.True(t, gas.MassFraction(name) > 0, name)
+	}
+	// reference values of mole fractions for stoichiometric methane-air mixture (NASA CEA)
+	var moleFractions = moleFractionMap(gas)
+	assert.InDelta(t, 0.0089, moleFractions[species.CarbonMonoxideName], 0.003)
+	assert.InDelta(t, 0.0033, moleFractions[species.HydroxylName], 0.0015)
+}
+
+func TestSolver_EquilibriumConstant(t *testing.T) {
+	var solver = NewCombustionSolver()
+	var temp, p = 2500., 5e5
+
+	var gas, err = solver.Solve(map[string]float64{"C": 1, "O": 3, "N": 4}, temp, p)
+	assert.Nil(t, err)
+	var x = moleFractionMap(gas)
+
+	// CO2 = CO + O2 / 2
+	var g = func(name string) float64 {
+		var s = species.MustGet(name)
+		return (s.HAbs(temp) - temp*s.S0Abs(temp)) * s.MolarMass()
+	}
+	var dG = g(species.CarbonMonoxideName) + g(species.OxygenName)/2 - g(species.CarbonDioxideName)
+	var kp = math.Exp(-dG / (gases.UniversalGasConstant * temp))
+
+	var kpCalc = x[species.CarbonMonoxideName] * math.Sqrt(x[species.OxygenName]*p/gases.PRef) /
+		x[species.CarbonDioxideName]
+	assert.InDelta(t, 1, kpCalc/kp, 1e-6)
+}
+
+func TestSolver_Limits(t *testing.T) {
+	var solver = NewCombustionSolver()
+	var air = gases.GetAir()
+	var ch4 = fuel.GetCH4()
+	var fuelMassRel = 1 / (2 * ch4.GasMassTheory(air))
+
+	// dissociation vanishes at low temperatures
+	var cold, err = CombustionGas(solver, air, ch4, fuelMassRel, 800, 1e5)
+	assert.Nil(t, err)
+	heat, err := DissociationHeat(cold)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0, heat, 100)
+	assert.InDelta(t, ch4.GetCombustionGas(air, 2).OxygenMassFraction(), cold.OxygenMassFraction(), 1e-3)
+
+	// and is suppressed by pressure
+	var heatLow, heatHigh float64
+	for _, item := range []struct {
+		p    float64
+		heat *float64
+	}{{1e5, &heatLow}, {30e5, &heatHigh}} {
+		var gas, err = CombustionGas(solver, air, ch4, 2*fuelMassRel, 2400, item.p)
+		assert.Nil(t, err)
+		*item.heat, err = DissociationHeat(gas)
+		assert.Nil(t, err)
+	}
+	assert.True(t, heatLow > heatHigh && heatHigh > 0, "%f %f", heatLow, heatHigh)
+}
+
+func TestSolver_Errors(t *testing.T) {
+	var solver = NewCombustionSolver()
+
+	var _, err = solver.Solve(map[string]float64{"He": 1, "N": 1}, 1000, 1e5)
+	assert.NotNil(t, err)
+
+	_, err = solver.Solve(map[string]float64{"O": -1}, 1000, 1e5)
+	assert.NotNil(t, err)
+
+	_, err = Elements(gases.TestGas{RVal: 287, OFraction: 0.2})
+	assert.NotNil(t, err)
+}
+
+func moleFractionMap(gas gases.Composition) map[string]float64 {
+	var result = make(map[string]float64)
+	for i, component := range gas.Components() {
+		result[component.String()] = gas.MoleFractions()[i]
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package fuel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// NewBlend returns mixture of fuels with mass fractions (normalized)
+func NewBlend(fuels []Fuel, massFractions []float64) (Fuel, error) {
+	if len(fuels) != len(massFractions) {
+		return nil, fmt.Errorf("len(fuels) == %d; len(massFractions) == %d", len(fuels), len(massFractions))
+	}
+	if len(fuels) == 0 {
+		return nil, fmt.Errorf("empty blend")
+	}
+
+	var fracSum = 0.
+	for _, y := range massFractions {
+		if y < 0 {
+			return nil, fmt.Errorf("negative mass fraction %f", y)
+		}
+		fracSum += y
+	}
+	if fracSum <= 0 {
+		return nil, fmt.Errorf("zero blend composition")
+	}
+
+	var result = &blend{
+		fuels:         fuels,
+		massFractions: make([]float64, len(massFractions)),
+	}
+	for i, y := range massFractions {
+		result.massFractions[i] = y / fracSum
+	}
+	return result, nil
+}
+
+type blend struct {
+	fuels         []Fuel
+	massFractions []float64
+}
+
+func (b *blend) Cp(t float64) float64 {
+	return b.average(func(f Fuel) float64 {
+		return f.Cp(t)
+	})
+}
+
+func (b *blend) QLower() float64 {
+	return b.average(func(f Fuel) float64 {
+		return f.QLower()
+	})
+}
+
+func (b *blend) GasMassTheory(gas gases.Gas) float64 {
+	return b.average(func(f Fuel) float64 {
+		return f.GasMassTheory(gas)
+	})
+}
+
+// GetCombustionGas superposes combustion products of the blend components:
+// each component is burnt in the same unit mass of inletGas with its own share of fuel.
+// If alpha < 1 only the part of fuel which oxygen is enough for is burnt
+func (b *blend) GetCombustionGas(inletGas gases.Gas, alpha float64) gases.Gas {
+	var gasMassTheory = b.GasMassTheory(inletGas)
+	var fuelMass = 1 / (math.Max(alpha, 1) * gasMassTheory)
+
+	var products = []gases.Gas{inletGas}
+	var fractions = []float64{1}
+	for i, f := range b.fuels {
+		var componentMass = fuelMass * b.massFractions[i]
+		if componentMass == 0 {
+			continue
+		}
+		var componentAlpha = 1 / (componentMass * f.GasMassTheory(inletGas))
+
+		// products of burning componentMass in unit mass of inletGas minus inletGas itself
+		products = append(products, f.GetCombustionGas(inletGas, componentAlpha), inletGas)
+		fractions = append(fractions, 1+componentMass, -1)
+	}
+	return gases.NewMixture(products, fractions)
+}
+
+// formula returns average formula of one mole of the blend
+func (b *blend) formula() (Formula, error) {
+	var result Formula
+	var molesPerKg = 0.
+	for i, f := range b.fuels {
+		var formula, err = FormulaOf(f)
+		if err != nil {
+			return Formula{}, err
+		}
+		var moles = b.massFractions[i] / formula.MolarMass()
+		result = result.Plus(formula, moles)
+		molesPerKg += moles
+	}
+	return result.scale(1 / molesPerKg), nil
+}
+
+func (b *blend) average(f func(Fuel) float64) float64 {
+	var result = 0.
+	for i, fuel := range b.fuels {
+		result += b.massFractions[i] * f(fuel)
+	}
+	return result
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/Sovianum/turbocycle/material/gases"
 )
 
-func GetCH4() GasFuel {
+func GetCH4() Fuel {
 	return ch4{
 		ch: NewHydroCarbon(1, 4),
 	}
@@ -37,6 +37,10 @@ func (fuel ch4) Cp(t float64) float64 {
 	return cp
 }
 
+func (fuel ch4) Formula() Formula {
+	return NewFormula(fuel.ch.C, fuel.ch.H, 0, 0, 0)
+}
+
 func (fuel ch4) GasMassTheory(gas gases.Gas) float64 {
 	return 2 / gas.OxygenMassFraction() * common.O2Weight / common.CH4Weight
 }
@@ -0,0 +1,127 @@
+package fuel
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+// atomic masses, kg / mol
+const (
+	cAtomMass = 12.011e-3
+	hAtomMass = 1.008e-3
+	oAtomMass = 15.999e-3
+	nAtomMass = 14.007e-3
+	sAtomMass = 32.06e-3
+)
+
+// Formula is a fuel molecule CxHyOzNwSv. Numbers of atoms may be fractional,
+// so that formula can describe average composition of a multicomponent fuel
+type Formula struct {
+	C float64
+	H float64
+	O float64
+	N float64
+	S float64
+}
+
+func NewFormula(c, h, o, n, s float64) Formula {
+	return Formula{C: c, H: h, O: o, N: n, S: s}
+}
+
+// FormulaFromElements builds formula from element numbers of a species.
+// Only C, H, O, N and S are allowed
+func FormulaFromElements(elements map[string]float64) (Formula, error) {
+	var result Formula
+	for element, num := range elements {
+		switch element {
+		case "C":
+			result.C += num
+		case "H":
+			result.H += num
+		case "O":
+			result.O += num
+		case "N":
+			result.N += num
+		case "S":
+			result.S += num
+		default:
+			return result, fmt.Errorf("element %s is not supported in fuels", element)
+		}
+	}
+	return result, nil
+}
+
+func (f Formula) String() string {
+	return fmt.Sprintf("C%gH%gO%gN%gS%g", f.C, f.H, f.O, f.N, f.S)
+}
+
+// MolarMass is in kg / mol
+func (f Formula) MolarMass() float64 {
+	return f.C*cAtomMass + f.H*hAtomMass + f.O*oAtomMass + f.N*nAtomMass + f.S*sAtomMass
+}
+
+// OxygenDemand returns number of O2 moles required to burn one mole of fuel completely
+func (f Formula) OxygenDemand() float64 {
+	return f.C + f.H/4 + f.S - f.O/2
+}
+
+// Plus returns formula of num moles of another added to one mole of f
+func (f Formula) Plus(another Formula, num float64) Formula {
+	return Formula{
+		C: f.C + num*another.C,
+		H: f.H + num*another.H,
+		O: f.O + num*another.O,
+		N: f.N + num*another.N,
+		S: f.S + num*another.S,
+	}
+}
+
+// FormulaOf returns formula of one mole of fuel. Blends are averaged by moles.
+// Fuels which are not defined by formula return error
+func FormulaOf(fuel Fuel) (Formula, error) {
+	switch f := fuel.(type) {
+	case interface{ Formula() Formula }:
+		return f.Formula(), nil
+	case *blend:
+		return f.formula()
+	}
+	return Formula{}, fmt.Errorf("fuel %T is not defined by formula", fuel)
+}
+
+// Elements returns moles of chemical elements in unit mass of fuel
+func Elements(fuel Fuel) (map[string]float64, error) {
+	var formula, err = FormulaOf(fuel)
+	if err != nil {
+		return nil, err
+	}
+	var moles = 1 / formula.MolarMass()
+	var result = make(map[string]float64)
+	for element, num := range map[string]float64{
+		"C": formula.C, "H": formula.H, "O": formula.O, "N": formula.N, "S": formula.S,
+	} {
+		if num != 0 {
+			result[element] = num * moles
+		}
+	}
+	return result, nil
+}
+
+func (f Formula) scale(factor float64) Formula {
+	return Formula{C: f.C * factor, H: f.H * factor, O: f.O * factor, N: f.N * factor, S: f.S * factor}
+}
+
+// HeatOfCombustion returns lower heating value (J / kg) of fuel with this formula
+// and molar enthalpy of formation hFormation (J / mol) at gases.TRef.
+// Water in products is vapour; nitrogen is released as N2, sulfur is burnt to SO2
+func (f Formula) HeatOfCombustion(hFormation float64) float64 {
+	var productsFormation = f.C*molarFormation(species.CarbonDioxideName) +
+		f.H/2*molarFormation(species.WaterName) +
+		f.S*molarFormation(species.SulfurDioxideName)
+	return (hFormation - productsFormation) / f.MolarMass()
+}
+
+func molarFormation(name string) float64 {
+	var s = species.MustGet(name)
+	return s.HFormation() * s.MolarMass()
+}
@@ -0,0 +1,75 @@
+package fuel
+
+import (
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+// NewFormulaFuel returns fuel with formula, molar enthalpy of formation hFormation (J / mol)
+// at gases.TRef in the supplied state and heat capacity cp of the supplied state.
+// Lower heating value is calculated from formation enthalpies
+func NewFormulaFuel(formula Formula, hFormation float64, cp func(t float64) float64) Fuel {
+	return &formulaFuel{
+		formula:    formula,
+		hFormation: hFormation,
+		cp:         cp,
+		qLower:     formula.HeatOfCombustion(hFormation),
+	}
+}
+
+type formulaFuel struct {
+	formula    Formula
+	hFormation float64
+	cp         func(t float64) float64
+	qLower     float64
+}
+
+func (f *formulaFuel) Formula() Formula {
+	return f.formula
+}
+
+func (f *formulaFuel) Cp(t float64) float64 {
+	return f.cp(t)
+}
+
+func (f *formulaFuel) QLower() float64 {
+	return f.qLower
+}
+
+func (f *formulaFuel) GasMassTheory(gas gases.Gas) float64 {
+	var oxygenMass = f.formula.OxygenDemand() * 2 * oAtomMass / f.formula.MolarMass()
+	return oxygenMass / gas.OxygenMassFraction()
+}
+
+// GetCombustionGas burns fuel in unit mass of inletGas completely.
+// If alpha < 1 only the part of fuel which oxygen is enough for is burnt
+// and the rest of fuel is not included in the combustion gas
+func (f *formulaFuel) GetCombustionGas(inletGas gases.Gas, alpha float64) gases.Gas {
+	if inletGas.OxygenMassFraction() < 1e-6 {
+		panic("gas can not be burnt")
+	}
+
+	var fuelMass = 1 / (alpha * f.GasMassTheory(inletGas)) * math.Min(alpha, 1)
+	var fuelMoles = fuelMass / f.formula.MolarMass()
+	var formula = f.formula
+
+	// masses are calculated from the atom balance, so that mass is conserved exactly
+	var oxygenMass = fuelMoles * formula.OxygenDemand() * 2 * oAtomMass
+	var co2Mass = fuelMoles * formula.C * (cAtomMass + 2*oAtomMass)
+	var h2oMass = fuelMoles * formula.H / 2 * (2*hAtomMass + oAtomMass)
+	var n2Mass = fuelMoles * formula.N * nAtomMass
+	var so2Mass = fuelMoles * formula.S * (sAtomMass + 2*oAtomMass)
+
+	return gases.NewMixture(
+		[]gases.Gas{
+			inletGas, gases.GetOxygen(),
+			gases.GetCO2(), gases.GetH2OVapour(), gases.GetNitrogen(), species.MustGet(species.SulfurDioxideName),
+		},
+		[]float64{
+			1, -oxygenMass,
+			co2Mass, h2oMass, n2Mass, so2Mass,
+		},
+	)
+}
@@ -0,0 +1,110 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNaturalGas_Methane(t *testing.T) {
+	var methane, err = NewNaturalGas(map[string]float64{species.MethaneName: 1})
+	assert.Nil(t, err)
+
+	assert.InDelta(t, 50.0e6, methane.QLower(), 0.1e6)
+	assert.InDelta(t, GetCH4().GasMassTheory(gases.GetAir()), methane.GasMassTheory(gases.GetAir()), 0.05)
+	assert.InDelta(t, species.MustGet(species.MethaneName).Cp(300), methane.Cp(300), 1e-9)
+}
+
+func TestNewNaturalGas_Mixture(t *testing.T) {
+	var ng, err = NewNaturalGas(map[string]float64{
+		species.MethaneName:       0.90,
+		species.EthaneName:        0.04,
+		species.PropaneName:       0.01,
+		species.NitrogenName:      0.03,
+		species.CarbonDioxideName: 0.01,
+		species.HydrogenName:      0.01,
+	})
+	assert.Nil(t, err)
+	// inerts reduce heating value per unit mass
+	assert.True(t, 44e6 < ng.QLower() && ng.QLower() < 49e6, "%f", ng.QLower())
+
+	var gas = ng.GetCombustionGas(gases.GetAir(), 1)
+	assert.InDelta(t, 0, gas.OxygenMassFraction(), 1e-9)
+
+	_, err = NewNaturalGas(map[string]float64{species.NitrogenName: 1})
+	assert.NotNil(t, err)
+
+	_, err = NewNaturalGas(map[string]float64{species.ArgonName: 0.1, species.MethaneName: 0.9})
+	assert.NotNil(t, err)
+
+	_, err = NewNaturalGas(map[string]float64{"C8H18": 1})
+	assert.NotNil(t, err)
+}
+
+func TestLiquidFuels(t *testing.T) {
+	var jetA = GetJetA()
+	assert.InDelta(t, 43.0e6, jetA.QLower(), 0.2e6)
+
+	var diesel = GetDiesel()
+	assert.InDelta(t, 42.8e6, diesel.QLower(), 0.2e6)
+
+	// heating value of vapour is greater by the heat of evaporation
+	var vapour = NewFormulaFuel(jetA.Formula(), -249657, func(float64) float64 { return 0 })
+	assert.InDelta(t, vapour.QLower()-jetA.LatentHeat(), jetA.QLower(), 1e-6)
+
+	assert.InDelta(t, 14.7, jetA.GasMassTheory(gases.GetAir()), 0.2)
+}
+
+func TestFormulaFuel_GetCombustionGas(t *testing.T) {
+	var formula = NewFormula(1, 3.6, 0.1, 0.02, 0.01)
+	var f = NewFormulaFuel(formula, -80e3, func(float64) float64 { return 2000 })
+
+	var air = gases.GetAir()
+	var alpha = 2.
+	var gas = gases.Flatten(f.GetCombustionGas(air, alpha))
+
+	var sum = 0.
+	for _, fraction := range gas.MassFractions() {
+		sum += fraction
+	}
+	assert.InDelta(t, 1, sum, 1e-12)
+	assert.InDelta(t, air.OxygenMassFraction()*(1-1/alpha)/(1+1/(alpha*f.GasMassTheory(air))), gas.OxygenMassFraction(), 1e-9)
+	assert.True(t, gas.MassFraction(species.SulfurDioxideName) > 0)
+
+	var rich = f.GetCombustionGas(air, 0.8)
+	assert.InDelta(t, 0, rich.OxygenMassFraction(), 1e-9)
+
+	assert.Panics(t, func() {
+		f.GetCombustionGas(gases.GetOxyFreeGas(air), 1)
+	})
+}
+
+func TestFormulaFromElements(t *testing.T) {
+	var formula, err = FormulaFromElements(map[string]float64{"C": 2, "H": 6})
+	assert.Nil(t, err)
+	assert.Equal(t, NewFormula(2, 6, 0, 0, 0), formula)
+	assert.InDelta(t, 3.5, formula.OxygenDemand(), 1e-12)
+
+	_, err = FormulaFromElements(map[string]float64{"He": 1})
+	assert.NotNil(t, err)
+}
+
+func TestFormulaOf(t *testing.T) {
+	var formula, err = FormulaOf(GetCH4())
+	assert.Nil(t, err)
+	assert.Equal(t, NewFormula(1, 4, 0, 0, 0), formula)
+
+	blend, err := NewH2CH4BlendByVolume(0.5)
+	assert.Nil(t, err)
+	formula, err = FormulaOf(blend)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.5, formula.C, 1e-4)
+	assert.InDelta(t, 3, formula.H, 1e-4)
+
+	elements, err := Elements(GetJetA())
+	assert.Nil(t, err)
+	var mass = elements["C"]*cAtomMass + elements["H"]*hAtomMass
+	assert.InDelta(t, 1, mass, 1e-12)
+}
@@ -5,13 +5,19 @@ import (
 	"github.com/Sovianum/turbocycle/material/gases"
 )
 
-type GasFuel interface {
+// Fuel describes fuel in the state it is supplied to the burner (gaseous or liquid)
+type Fuel interface {
+	// Cp is heat capacity of the fuel in the supplied state
 	Cp(t float64) float64
 	GasMassTheory(gas gases.Gas) float64
+	// QLower is lower heating value of the fuel in the supplied state
 	QLower() float64
 	GetCombustionGas(inletGas gases.Gas, alpha float64) gases.Gas
 }
 
-func CpMean(fuel GasFuel, t1, t2 float64, n int) float64 {
+// GasFuel is the former name of Fuel kept for compatibility
+type GasFuel = Fuel
+
+func CpMean(fuel Fuel, t1, t2 float64, n int) float64 {
 	return common.Average(fuel.Cp, t1, t2, n)
 }
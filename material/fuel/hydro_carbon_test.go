@@ -22,3 +22,25 @@ func TestHydroCarbon_GetCombustionGas(t *testing.T) {
 		hc.GetCombustionGas(gas2, 1)
 	})
 }
+
+func TestHydroCarbon_GetCombustionGas_HumidAir(t *testing.T) {
+	var q = 0.01
+	var gas, err = gases.NewHumidAirFromSpecificHumidity(q)
+	assert.Nil(t, err)
+
+	var hc = NewHydroCarbon(1, 4)
+	var alpha = 2.5
+	var exhaust = gases.Flatten(hc.GetCombustionGas(gas, alpha))
+
+	// inlet vapour and combustion water are merged into the single component
+	var waterName = gases.GetH2OVapour().String()
+	var exhaustComplex = hc.getExhaustComplex(gas, alpha)
+	var expectedWater = (hc.getH2OComplex(gas, alpha) + q) / exhaustComplex
+	assert.InDelta(t, expectedWater, exhaust.MassFraction(waterName), 1e-12)
+
+	var sum = 0.
+	for _, fraction := range exhaust.MassFractions() {
+		sum += fraction
+	}
+	assert.InDelta(t, 1, sum, 1e-12)
+}
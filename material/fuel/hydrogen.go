@@ -0,0 +1,36 @@
+package fuel
+
+import (
+	"fmt"
+
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+// GetH2 returns gaseous hydrogen
+func GetH2() Fuel {
+	var result, err = NewNaturalGas(map[string]float64{species.HydrogenName: 1})
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// NewH2CH4BlendByVolume returns blend of hydrogen and methane (GetCH4)
+// with volume (mole) fraction of hydrogen h2Fraction in [0, 1]
+func NewH2CH4BlendByVolume(h2Fraction float64) (Fuel, error) {
+	if h2Fraction < 0 || h2Fraction > 1 {
+		return nil, fmt.Errorf("invalid hydrogen volume fraction %f", h2Fraction)
+	}
+	var h2Mass = h2Fraction * species.MustGet(species.HydrogenName).MolarMass()
+	var ch4Mass = (1 - h2Fraction) * species.MustGet(species.MethaneName).MolarMass()
+	return NewH2CH4BlendByMass(h2Mass / (h2Mass + ch4Mass))
+}
+
+// NewH2CH4BlendByMass returns blend of hydrogen and methane (GetCH4)
+// with mass fraction of hydrogen h2Fraction in [0, 1]
+func NewH2CH4BlendByMass(h2Fraction float64) (Fuel, error) {
+	if h2Fraction < 0 || h2Fraction > 1 {
+		return nil, fmt.Errorf("invalid hydrogen mass fraction %f", h2Fraction)
+	}
+	return NewBlend([]Fuel{GetH2(), GetCH4()}, []float64{h2Fraction, 1 - h2Fraction})
+}
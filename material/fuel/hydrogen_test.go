@@ -0,0 +1,58 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetH2(t *testing.T) {
+	var h2 = GetH2()
+	var air = gases.GetAir()
+
+	assert.InDelta(t, 120.0e6, h2.QLower(), 0.3e6)
+	assert.InDelta(t, 7.937/air.OxygenMassFraction(), h2.GasMassTheory(air), 0.01)
+
+	var products = gases.Flatten(h2.GetCombustionGas(air, 1))
+	assert.InDelta(t, 0, products.OxygenMassFraction(), 1e-9)
+	assert.InDelta(t, 0, products.MassFraction(gases.GetCO2().String()), 1e-12)
+	assert.True(t, products.MassFraction(gases.GetH2OVapour().String()) > 0.2)
+}
+
+func TestH2CH4Blend_Limits(t *testing.T) {
+	var air = gases.GetAir()
+	var ch4 = GetCH4()
+	var h2 = GetH2()
+
+	var pureCH4, err = NewH2CH4BlendByVolume(0)
+	assert.Nil(t, err)
+	assert.InDelta(t, ch4.QLower(), pureCH4.QLower(), 1e-6)
+	assert.InDelta(t, ch4.GasMassTheory(air), pureCH4.GasMassTheory(air), 1e-9)
+	assert.True(t, gases.Equal(ch4.GetCombustionGas(air, 2.5), pureCH4.GetCombustionGas(air, 2.5), 1e-9))
+
+	pureH2, err := NewH2CH4BlendByMass(1)
+	assert.Nil(t, err)
+	assert.InDelta(t, h2.QLower(), pureH2.QLower(), 1e-6)
+	assert.True(t, gases.Equal(h2.GetCombustionGas(air, 2.5), pureH2.GetCombustionGas(air, 2.5), 1e-9))
+
+	_, err = NewH2CH4BlendByVolume(1.1)
+	assert.NotNil(t, err)
+}
+
+func TestH2CH4Blend_ByVolume(t *testing.T) {
+	var air = gases.GetAir()
+	var blend, err = NewH2CH4BlendByVolume(0.5)
+	assert.Nil(t, err)
+
+	// 50 % by volume is about 11 % by mass
+	var byMass, _ = NewH2CH4BlendByMass(2.016 / (2.016 + 16.043))
+	assert.InDelta(t, byMass.QLower(), blend.QLower(), 1e3)
+
+	var products = gases.Flatten(blend.GetCombustionGas(air, 1))
+	assert.InDelta(t, 0, products.OxygenMassFraction(), 1e-6)
+
+	var lean = gases.Flatten(blend.GetCombustionGas(air, 2))
+	var fuelMass = 1 / (2 * blend.GasMassTheory(air))
+	assert.InDelta(t, air.OxygenMassFraction()/2/(1+fuelMass), lean.OxygenMassFraction(), 1e-6)
+}
@@ -0,0 +1,45 @@
+package fuel
+
+// LiquidFuel is supplied to the burner in liquid state, so
+// its heating value is reduced by the heat of evaporation
+type LiquidFuel interface {
+	Fuel
+	Formula() Formula
+	// LatentHeat is heat of evaporation at gases.TRef, J / kg
+	LatentHeat() float64
+}
+
+// NewLiquidFuel returns liquid fuel with formula, molar enthalpy of formation of
+// the vapour hFormationVapour (J / mol), heat of evaporation latentHeat (J / kg)
+// and liquid heat capacity cpLiquid (J / (kg * K))
+func NewLiquidFuel(formula Formula, hFormationVapour, latentHeat, cpLiquid float64) LiquidFuel {
+	var hFormationLiquid = hFormationVapour - latentHeat*formula.MolarMass()
+	return &liquidFuel{
+		formulaFuel: NewFormulaFuel(
+			formula, hFormationLiquid,
+			func(t float64) float64 {
+				return cpLiquid
+			},
+		).(*formulaFuel),
+		latentHeat: latentHeat,
+	}
+}
+
+// GetJetA returns Jet-A kerosene represented by C12H23 surrogate (NASA CEA data)
+func GetJetA() LiquidFuel {
+	return NewLiquidFuel(NewFormula(12, 23, 0, 0, 0), -249657, 321e3, 2010)
+}
+
+// GetDiesel returns diesel fuel represented by C12.3H22.2 surrogate
+func GetDiesel() LiquidFuel {
+	return NewLiquidFuel(NewFormula(12.3, 22.2, 0, 0, 0), -201300, 250e3, 1900)
+}
+
+type liquidFuel struct {
+	*formulaFuel
+	latentHeat float64
+}
+
+func (f *liquidFuel) LatentHeat() float64 {
+	return f.latentHeat
+}
@@ -0,0 +1,71 @@
+package fuel
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+// NewNaturalGas returns gaseous fuel defined by volume (mole) fractions of
+// species of the species database, e.g. {"CH4": 0.92, "C2H6": 0.05, "N2": 0.03}.
+// Fractions are normalized
+func NewNaturalGas(volumeFractions map[string]float64) (Fuel, error) {
+	if len(volumeFractions) == 0 {
+		return nil, fmt.Errorf("empty natural gas composition")
+	}
+
+	var names = make([]string, 0, len(volumeFractions))
+	var fracSum = 0.
+	for name, fraction := range volumeFractions {
+		if fraction < 0 {
+			return nil, fmt.Errorf("negative fraction %f of %s", fraction, name)
+		}
+		names = append(names, name)
+		fracSum += fraction
+	}
+	if fracSum <= 0 {
+		return nil, fmt.Errorf("zero natural gas composition")
+	}
+	sort.Strings(names)
+
+	var components = make([]species.Species, len(names))
+	var moleFractions = make([]float64, len(names))
+	var formula Formula
+	var hFormation = 0.
+	var molarMass = 0.
+	for i, name := range names {
+		var s, err = species.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		componentFormula, err := FormulaFromElements(s.Elements())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+
+		var x = volumeFractions[name] / fracSum
+		components[i] = s
+		moleFractions[i] = x
+
+		formula = formula.Plus(componentFormula, x)
+		hFormation += x * s.HFormation() * s.MolarMass()
+		molarMass += x * s.MolarMass()
+	}
+
+	var massFractions = make([]float64, len(names))
+	for i, s := range components {
+		massFractions[i] = moleFractions[i] * s.MolarMass() / molarMass
+	}
+	if formula.OxygenDemand() <= 0 {
+		return nil, fmt.Errorf("natural gas does not contain combustibles")
+	}
+
+	return NewFormulaFuel(formula, hFormation, func(t float64) float64 {
+		var cp = 0.
+		for i, s := range components {
+			cp += massFractions[i] * s.Cp(t)
+		}
+		return cp
+	}), nil
+}
@@ -0,0 +1,107 @@
+package gases
+
+import (
+	"math"
+	"sort"
+)
+
+// cpTable is a piecewise linear heat capacity dependency (constant out of the table range).
+// Its enthalpy and entropy function are integrated analytically and are
+// exactly consistent with the heat capacity
+type cpTable struct {
+	t  []float64
+	cp []float64
+
+	// enthalpy and entropy function at table nodes relative to the first node
+	h  []float64
+	s0 []float64
+
+	hRef  float64
+	s0Ref float64
+}
+
+func newCPTable(t, cp []float64) *cpTable {
+	var result = &cpTable{
+		t:  t,
+		cp: cp,
+		h:  make([]float64, len(t)),
+		s0: make([]float64, len(t)),
+	}
+	for i := 1; i < len(t); i++ {
+		result.h[i] = result.h[i-1] + result.segmentEnthalpy(i-1, t[i])
+		result.s0[i] = result.s0[i-1] + result.segmentEntropy(i-1, t[i])
+	}
+	result.hRef = result.enthalpy(TRef)
+	result.s0Ref = result.entropy(TRef)
+	return result
+}
+
+func (tab *cpTable) Cp(t float64) float64 {
+	var n = len(tab.t)
+	if t <= tab.t[0] {
+		return tab.cp[0]
+	}
+	if t >= tab.t[n-1] {
+		return tab.cp[n-1]
+	}
+	var i = tab.segment(t)
+	return tab.cp[i] + tab.slope(i)*(t-tab.t[i])
+}
+
+// H returns enthalpy relative to TRef
+func (tab *cpTable) H(t float64) float64 {
+	return tab.enthalpy(t) - tab.hRef
+}
+
+// S0 returns entropy function relative to TRef
+func (tab *cpTable) S0(t float64) float64 {
+	return tab.entropy(t) - tab.s0Ref
+}
+
+func (tab *cpTable) enthalpy(t float64) float64 {
+	var n = len(tab.t)
+	if t <= tab.t[0] {
+		return tab.cp[0] * (t - tab.t[0])
+	}
+	if t >= tab.t[n-1] {
+		return tab.h[n-1] + tab.cp[n-1]*(t-tab.t[n-1])
+	}
+	var i = tab.segment(t)
+	return tab.h[i] + tab.segmentEnthalpy(i, t)
+}
+
+func (tab *cpTable) entropy(t float64) float64 {
+	var n = len(tab.t)
+	if t <= tab.t[0] {
+		return tab.cp[0] * math.Log(t/tab.t[0])
+	}
+	if t >= tab.t[n-1] {
+		return tab.s0[n-1] + tab.cp[n-1]*math.Log(t/tab.t[n-1])
+	}
+	var i = tab.segment(t)
+	return tab.s0[i] + tab.segmentEntropy(i, t)
+}
+
+// segmentEnthalpy integrates cp from t[i] to t within segment i
+func (tab *cpTable) segmentEnthalpy(i int, t float64) float64 {
+	var dt = t - tab.t[i]
+	return tab.cp[i]*dt + tab.slope(i)*dt*dt/2
+}
+
+// segmentEntropy integrates cp / t from t[i] to t within segment i
+func (tab *cpTable) segmentEntropy(i int, t float64) float64 {
+	var b = tab.slope(i)
+	var a = tab.cp[i] - b*tab.t[i]
+	return a*math.Log(t/tab.t[i]) + b*(t-tab.t[i])
+}
+
+func (tab *cpTable) slope(i int) float64 {
+	return (tab.cp[i+1] - tab.cp[i]) / (tab.t[i+1] - tab.t[i])
+}
+
+// segment returns i such that t[i] <= t < t[i + 1]
+func (tab *cpTable) segment(t float64) int {
+	return sort.Search(len(tab.t), func(i int) bool {
+		return tab.t[i] > t
+	}) - 1
+}
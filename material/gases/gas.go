@@ -2,10 +2,19 @@ package gases
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/Sovianum/turbocycle/common"
 )
 
+const (
+	TRef = 298.15
+	PRef = 1e5
+
+	// UniversalGasConstant in J / (mol * K)
+	UniversalGasConstant = 8.314462618
+)
+
 type Oxidizer interface {
 	OxygenMassFraction() float64
 }
@@ -28,12 +37,29 @@ type Gas interface {
 	fmt.Stringer
 	Oxidizer
 	Cp(t float64) float64
+	// H is specific enthalpy relative to TRef
+	H(t float64) float64
+	// S0 is specific entropy function at PRef relative to TRef
+	S0(t float64) float64
 	R() float64
 	Mu(t float64) float64
 	Lambda(t float64) float64
 }
 
+// S returns specific entropy relative to (TRef, PRef)
+func S(gas Gas, t float64, p float64) float64 {
+	return gas.S0(t) - gas.R()*math.Log(p/PRef)
+}
+
+// MolarMass returns molar mass of the gas in kg / mol
+func MolarMass(gas Gas) float64 {
+	return UniversalGasConstant / gas.R()
+}
+
 func Density(gas Gas, t float64, p float64) float64 {
+	if realGas, ok := gas.(RealGas); ok {
+		return realGas.DensityP(t, p)
+	}
 	return p / (gas.R() * t)
 }
 
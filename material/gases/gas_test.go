@@ -16,3 +16,12 @@ func TestGetOxyFreeGas(t *testing.T) {
 	var oxyFreeAir = GetOxyFreeGas(air)
 	assert.InDelta(t, 0, oxyFreeAir.OxygenMassFraction(), 1e-7)
 }
+
+func TestH2OVapour_Cp(t *testing.T) {
+	var h2o = GetH2OVapour()
+	// ideal gas heat capacity of water vapour, J / (kg * K)
+	assert.InDelta(t, 1865, h2o.Cp(300), 5)
+	assert.InDelta(t, 2292, h2o.Cp(1000), 5)
+	assert.InDelta(t, 2873, h2o.Cp(2000), 5)
+	assert.True(t, h2o.Cp(1000) > GetCO2().Cp(1000)*1.5)
+}
@@ -0,0 +1,69 @@
+package gases
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/water"
+)
+
+// NewHumidAir returns mixture of dry air and water vapour for temperature t, pressure p
+// and relative humidity relHumidity in [0, 1]
+func NewHumidAir(t, p, relHumidity float64) (Composition, error) {
+	var q, err = SpecificHumidity(t, p, relHumidity)
+	if err != nil {
+		return nil, err
+	}
+	return NewHumidAirFromSpecificHumidity(q)
+}
+
+// NewHumidAirFromSpecificHumidity returns mixture of dry air and water vapour
+// with specific humidity q (mass of vapour per unit mass of humid air)
+func NewHumidAirFromSpecificHumidity(q float64) (Composition, error) {
+	if q < 0 || q >= 1 {
+		return nil, fmt.Errorf("invalid specific humidity %f", q)
+	}
+	return NewMixture(
+		[]Gas{GetAir(), GetH2OVapour()},
+		[]float64{1 - q, q},
+	), nil
+}
+
+// SpecificHumidity returns mass of vapour per unit mass of humid air
+// for temperature t, pressure p and relative humidity relHumidity in [0, 1]
+func SpecificHumidity(t, p, relHumidity float64) (float64, error) {
+	if relHumidity < 0 || relHumidity > 1 {
+		return 0, fmt.Errorf("invalid relative humidity %f", relHumidity)
+	}
+	var pVapour = relHumidity * SaturationPressure(t)
+	if pVapour >= p {
+		return 0, fmt.Errorf("vapour pressure %f exceeds mixture pressure %f", pVapour, p)
+	}
+
+	var mAir = MolarMass(GetAir())
+	var mVapour = MolarMass(GetH2OVapour())
+	var xVapour = pVapour / p
+	return xVapour * mVapour / (xVapour*mVapour + (1-xVapour)*mAir), nil
+}
+
+// RelativeHumidity returns relative humidity of humid air with
+// specific humidity q at temperature t and pressure p
+func RelativeHumidity(q, t, p float64) float64 {
+	var mAir = MolarMass(GetAir())
+	var mVapour = MolarMass(GetH2OVapour())
+	var xVapour = q / mVapour / (q/mVapour + (1-q)/mAir)
+	return xVapour * p / SaturationPressure(t)
+}
+
+// SaturationPressure returns saturation pressure of water vapour (Pa).
+// Above the triple point IAPWS-IF97 saturation line is used, below it
+// the Magnus formula over ice. Above the critical point critical pressure is returned
+func SaturationPressure(t float64) float64 {
+	if t < water.TTriple {
+		var tc = t - 273.15
+		return 611.15 * math.Exp(22.452*tc/(272.55+tc))
+	}
+	// error is impossible since temperature is within the saturation line
+	var p, _ = water.SaturationPressure(math.Min(t, water.TCrit))
+	return p
+}
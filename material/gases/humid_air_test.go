@@ -0,0 +1,45 @@
+package gases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaturationPressure(t *testing.T) {
+	// IAPWS-IF97 verification values
+	assert.InDelta(t, 0.353658941e-2*1e6, SaturationPressure(300), 1e-2)
+	assert.InDelta(t, 0.263889776e1*1e6, SaturationPressure(500), 1)
+	// normal boiling point
+	assert.InDelta(t, 101325, SaturationPressure(373.124), 10)
+	// ice
+	assert.InDelta(t, 103.2, SaturationPressure(253.15), 1)
+}
+
+func TestNewHumidAir(t *testing.T) {
+	var t0, p0 = 288.15, 101325.
+	var dry, err = NewHumidAir(t0, p0, 0)
+	assert.Nil(t, err)
+	assert.True(t, Equal(dry, GetAir(), 1e-12))
+
+	humid, err := NewHumidAir(t0, p0, 0.6)
+	assert.Nil(t, err)
+
+	var q = humid.MassFraction(GetH2OVapour().String())
+	// psychrometric value for 15 C, 60 %
+	assert.InDelta(t, 0.0063, q, 2e-4)
+	assert.InDelta(t, 0.6, RelativeHumidity(q, t0, p0), 1e-9)
+	assert.True(t, humid.R() > GetAir().R())
+	assert.InDelta(t, GetAir().OxygenMassFraction()*(1-q), humid.OxygenMassFraction(), 1e-12)
+}
+
+func TestNewHumidAir_Invalid(t *testing.T) {
+	var _, err = NewHumidAir(288, 1e5, 1.2)
+	assert.NotNil(t, err)
+
+	_, err = NewHumidAir(400, 1e5, 1)
+	assert.NotNil(t, err)
+
+	_, err = NewHumidAirFromSpecificHumidity(-0.1)
+	assert.NotNil(t, err)
+}
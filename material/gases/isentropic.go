@@ -0,0 +1,144 @@
+package gases
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+)
+
+const (
+	inversionPrecision  = 1e-10
+	inversionIterLimit  = 100
+	inversionGrowFactor = 1.6
+	inversionStep       = 0.05
+)
+
+// TFromH returns temperature at which gas has enthalpy h.
+// tGuess is used as the initial approximation
+func TFromH(gas Gas, h, tGuess float64) (float64, error) {
+	return invert(gas.H, gas.Cp, h, tGuess)
+}
+
+// TFromS0 returns temperature at which gas has entropy function s0.
+// tGuess is used as the initial approximation
+func TFromS0(gas Gas, s0, tGuess float64) (float64, error) {
+	return invert(
+		gas.S0,
+		func(t float64) float64 {
+			return gas.Cp(t) / t
+		},
+		s0, tGuess,
+	)
+}
+
+// IsentropicT returns temperature after isentropic process from t1 with pressure ratio pi = p2 / p1
+func IsentropicT(gas Gas, t1, pi float64) (float64, error) {
+	if pi <= 0 {
+		return 0, fmt.Errorf("invalid pressure ratio %f", pi)
+	}
+	var k = K(gas, t1)
+	return TFromS0(gas, gas.S0(t1)+gas.R()*math.Log(pi), t1*math.Pow(pi, (k-1)/k))
+}
+
+// PolytropicCompressionT returns compressor outlet temperature for
+// pressure ratio piStag > 1 and polytropic efficiency etaPol
+func PolytropicCompressionT(gas Gas, tIn, piStag, etaPol float64) (float64, error) {
+	if piStag <= 0 {
+		return 0, fmt.Errorf("invalid pressure ratio %f", piStag)
+	}
+	var k = K(gas, tIn)
+	return TFromS0(gas, gas.S0(tIn)+gas.R()*math.Log(piStag)/etaPol, tIn*math.Pow(piStag, (k-1)/(k*etaPol)))
+}
+
+// CompressionT returns compressor outlet temperature for
+// pressure ratio piStag > 1 and adiabatic efficiency etaAd
+func CompressionT(gas Gas, tIn, piStag, etaAd float64) (float64, error) {
+	var tAd, err = IsentropicT(gas, tIn, piStag)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = gas.H(tIn)
+	return TFromH(gas, hIn+(gas.H(tAd)-hIn)/etaAd, tIn+(tAd-tIn)/etaAd)
+}
+
+// ExpansionT returns turbine outlet temperature for
+// pressure ratio piT = pIn / pOut > 1 and adiabatic efficiency etaT
+func ExpansionT(gas Gas, tIn, piT, etaT float64) (float64, error) {
+	if piT <= 0 {
+		return 0, fmt.Errorf("invalid pressure ratio %f", piT)
+	}
+	var tAd, err = IsentropicT(gas, tIn, 1/piT)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = gas.H(tIn)
+	return TFromH(gas, hIn-(hIn-gas.H(tAd))*etaT, tIn-(tIn-tAd)*etaT)
+}
+
+// AdiabaticEfficiency returns adiabatic efficiency of compression (piStag > 1)
+// or expansion (piStag < 1) process from tIn to tOut with pressure ratio piStag = pOut / pIn
+func AdiabaticEfficiency(gas Gas, tIn, tOut, piStag float64) (float64, error) {
+	var tAd, err = IsentropicT(gas, tIn, piStag)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = gas.H(tIn)
+	if piStag >= 1 {
+		return (gas.H(tAd) - hIn) / (gas.H(tOut) - hIn), nil
+	}
+	return (hIn - gas.H(tOut)) / (hIn - gas.H(tAd)), nil
+}
+
+// CompressionPressureRatio returns pressure ratio pOut / pIn of the compression
+// from tIn to tOut with adiabatic efficiency etaAd
+func CompressionPressureRatio(gas Gas, tIn, tOut, etaAd float64) (float64, error) {
+	var hIn = gas.H(tIn)
+	return isentropicPressureRatio(gas, tIn, hIn+(gas.H(tOut)-hIn)*etaAd, tOut)
+}
+
+// ExpansionPressureRatio returns pressure ratio pIn / pOut of the expansion
+// from tIn to tOut with adiabatic efficiency etaT
+func ExpansionPressureRatio(gas Gas, tIn, tOut, etaT float64) (float64, error) {
+	var hIn = gas.H(tIn)
+	var pi, err = isentropicPressureRatio(gas, tIn, hIn-(hIn-gas.H(tOut))/etaT, tOut)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / pi, nil
+}
+
+// isentropicPressureRatio returns pOut / pIn of the isentropic process from tIn to the enthalpy hAd
+func isentropicPressureRatio(gas Gas, tIn, hAd, tGuess float64) (float64, error) {
+	var tAd, err = TFromH(gas, hAd, tGuess)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp((gas.S0(tAd) - gas.S0(tIn)) / gas.R()), nil
+}
+
+func invert(f, df func(float64) float64, y, xGuess float64) (float64, error) {
+	var residual = func(x float64) (float64, error) {
+		if x <= 0 {
+			return 0, fmt.Errorf("non-positive temperature %f", x)
+		}
+		return f(x) - y, nil
+	}
+
+	var a, b, err = root.Bracket(residual, xGuess*(1-inversionStep), xGuess*(1+inversionStep), inversionGrowFactor, inversionIterLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	var solver = root.NewSafeNewtonSolver(
+		func(x float64) (float64, error) {
+			return df(x), nil
+		},
+		0, inversionPrecision, inversionIterLimit,
+	)
+	var report, solveErr = solver.Solve(residual, a, b)
+	if solveErr != nil {
+		return 0, solveErr
+	}
+	return report.X, nil
+}
@@ -0,0 +1,102 @@
+package gases
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sovianum/turbocycle/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestH_ConsistentWithCp(t *testing.T) {
+	var air = GetAir()
+	var mixture = NewMixture([]Gas{GetAir(), GetCO2(), GetH2OVapour()}, []float64{0.9, 0.06, 0.04})
+
+	for _, gas := range []Gas{air, mixture, GetNitrogen(), GetOxygen()} {
+		assert.InDelta(t, 0, gas.H(TRef), 1e-9)
+		assert.InDelta(t, 0, gas.S0(TRef), 1e-9)
+
+		for _, temp := range []float64{250, 400, 900, 1500, 2500} {
+			var dt = 1e-3
+			var cpH = (gas.H(temp+dt) - gas.H(temp-dt)) / (2 * dt)
+			var cpS = (gas.S0(temp+dt) - gas.S0(temp-dt)) / (2 * dt) * temp
+
+			assert.InDelta(t, gas.Cp(temp), cpH, 1e-4, gas.String())
+			assert.InDelta(t, gas.Cp(temp), cpS, 1e-4, gas.String())
+		}
+
+		var t1, t2 = 300., 1200.
+		assert.InDelta(t, CpMean(gas, t1, t2, 1000)*(t2-t1), gas.H(t2)-gas.H(t1), 1e-3*gas.H(t2))
+	}
+}
+
+func TestS(t *testing.T) {
+	var air = GetAir()
+	assert.InDelta(t, 0, S(air, TRef, PRef), 1e-9)
+	assert.InDelta(t, -air.R()*math.Log(2), S(air, TRef, 2*PRef), 1e-9)
+}
+
+func TestTFromH(t *testing.T) {
+	var air = GetAir()
+	for _, temp := range []float64{200, 288, 800, 1600, 3000} {
+		var result, err = TFromH(air, air.H(temp), 500)
+		assert.Nil(t, err)
+		assert.InDelta(t, temp, result, 1e-6)
+
+		result, err = TFromS0(air, air.S0(temp), 500)
+		assert.Nil(t, err)
+		assert.InDelta(t, temp, result, 1e-6)
+	}
+}
+
+func TestIsentropicT_ConstantCp(t *testing.T) {
+	var gas = TestGas{CpVal: 1005, RVal: 287}
+	var k = K(gas, 300)
+
+	var tOut, err = IsentropicT(gas, 300, 10)
+	assert.Nil(t, err)
+	assert.InDelta(t, 300*math.Pow(10, (k-1)/k), tOut, 1e-6)
+
+	tOut, err = CompressionT(gas, 300, 10, 0.85)
+	assert.Nil(t, err)
+	assert.InDelta(t, 300*(1+(math.Pow(10, (k-1)/k)-1)/0.85), tOut, 1e-6)
+
+	tOut, err = PolytropicCompressionT(gas, 300, 10, 0.9)
+	assert.Nil(t, err)
+	assert.InDelta(t, 300*math.Pow(10, (k-1)/(k*0.9)), tOut, 1e-6)
+
+	tOut, err = ExpansionT(gas, 1200, 4, 0.9)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1200*(1-(1-math.Pow(4, (1-k)/k))*0.9), tOut, 1e-6)
+}
+
+func TestIsentropicHelpers_Consistency(t *testing.T) {
+	var air = GetAir()
+
+	var tOut, err = CompressionT(air, 288, 20, 0.86)
+	assert.Nil(t, err)
+
+	eta, err := AdiabaticEfficiency(air, 288, tOut, 20)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.86, eta, 1e-8)
+
+	pi, err := CompressionPressureRatio(air, 288, tOut, 0.86)
+	assert.Nil(t, err)
+	assert.True(t, common.ApproxEqual(20, pi, 1e-8))
+
+	tOut, err = ExpansionT(air, 1500, 5, 0.9)
+	assert.Nil(t, err)
+
+	eta, err = AdiabaticEfficiency(air, 1500, tOut, 1./5)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.9, eta, 1e-8)
+
+	pi, err = ExpansionPressureRatio(air, 1500, tOut, 0.9)
+	assert.Nil(t, err)
+	assert.True(t, common.ApproxEqual(5, pi, 1e-8))
+}
+
+func TestIsentropicT_InvalidPressureRatio(t *testing.T) {
+	var _, err = IsentropicT(GetAir(), 300, -1)
+	assert.NotNil(t, err)
+}
@@ -2,11 +2,43 @@ package gases
 
 import (
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
 )
 
-func NewMixture(gases []Gas, fractions []float64) Gas {
+// Composition is a flat mixture of pure gases with mass fractions.
+// Components are unique and sorted by name, so compositions can be compared
+type Composition interface {
+	Gas
+	Components() []Gas
+	MassFractions() []float64
+	// MassFraction returns summary mass fraction of components with the given name
+	MassFraction(name string) float64
+	MoleFractions() []float64
+	// MolarMass is in kg / mol
+	MolarMass() float64
+}
+
+// NewMixture mixes gases with mass fractions. Mixtures among gases are flattened,
+// so the result never contains nested mixtures.
+// Fractions are normalized; negative fractions are allowed to subtract a component.
+// NewMixture panics if gases can not be mixed, use Mix to get an error instead
+func NewMixture(gases []Gas, fractions []float64) Composition {
+	var result, err = Mix(gases, fractions)
+	if err != nil {
+		panic(err.Error())
+	}
+	return result
+}
+
+// Mix is NewMixture returning an error if gases can not be mixed.
+// Mixture of a real gas with itself is the real gas composition; real gas can not be
+// mixed with other gases since mixing rules of equations of state are not implemented
+func Mix(gases []Gas, fractions []float64) (Composition, error) {
 	if len(gases) != len(fractions) {
-		panic(fmt.Sprintf("len(gases) == %d; len(fractions) == %d", len(gases), len(fractions)))
+		return nil, fmt.Errorf("len(gases) == %d; len(fractions) == %d", len(gases), len(fractions))
 	}
 
 	var fracSum float64 = 0
@@ -14,15 +46,84 @@ func NewMixture(gases []Gas, fractions []float64) Gas {
 		fracSum += v
 	}
 
-	var normFracs = make([]float64, len(fractions))
-	for i, v := range fractions {
-		normFracs[i] = v / fracSum
+	var result = mixture{}
+	for i, gas := range gases {
+		var flat = Flatten(gas)
+		for j, component := range flat.Components() {
+			result.add(component, flat.MassFractions()[j]*fractions[i]/fracSum)
+		}
+	}
+	result.normalize()
+
+	for _, gas := range result.gases {
+		var realGas, ok = gas.(RealGas)
+		if !ok {
+			continue
+		}
+		if len(result.gases) != 1 {
+			return nil, fmt.Errorf("real gas %s can not be mixed with other gases: %s", gas, result)
+		}
+		return realComposition{mixture: result, real: realGas}, nil
+	}
+	return result, nil
+}
+
+// Flatten returns composition of the gas. Pure gas gives single component composition
+func Flatten(gas Gas) Composition {
+	switch v := gas.(type) {
+	case mixture:
+		return v
+	case realComposition:
+		return v
+	default:
+		return mixture{
+			gases:     []Gas{gas},
+			fractions: []float64{1},
+		}
 	}
+}
 
-	return mixture{
-		gases:     gases,
-		fractions: normFracs,
+// Residual returns maximal absolute difference of mass fractions of two gases
+func Residual(gas1, gas2 Gas) float64 {
+	var c1, c2 = Flatten(gas1), Flatten(gas2)
+
+	var result = 0.
+	var visit = func(a, b Composition) {
+		var fractions = a.MassFractions()
+		for i, gas := range a.Components() {
+			result = math.Max(result, math.Abs(fractions[i]-massFraction(b, gas)))
+		}
 	}
+	visit(c1, c2)
+	visit(c2, c1)
+	return result
+}
+
+// Equal checks if compositions of two gases match within precision
+func Equal(gas1, gas2 Gas, precision float64) bool {
+	return Residual(gas1, gas2) <= precision
+}
+
+// realComposition is a composition of a single real gas. It keeps properties depending on pressure
+type realComposition struct {
+	mixture
+	real RealGas
+}
+
+func (c realComposition) HP(t, p float64) float64 {
+	return c.real.HP(t, p)
+}
+
+func (c realComposition) SP(t, p float64) float64 {
+	return c.real.SP(t, p)
+}
+
+func (c realComposition) CpP(t, p float64) float64 {
+	return c.real.CpP(t, p)
+}
+
+func (c realComposition) DensityP(t, p float64) float64 {
+	return c.real.DensityP(t, p)
 }
 
 type mixture struct {
@@ -31,13 +132,48 @@ type mixture struct {
 }
 
 func (m mixture) String() string {
-	result := "Mixture: "
+	var parts = make([]string, len(m.gases))
 	for i := range m.gases {
-		result += fmt.Sprintf("%s (%.1f)", m.gases[i].String(), m.fractions[i]*100)
+		parts[i] = fmt.Sprintf("%s (%.1f)", m.gases[i].String(), m.fractions[i]*100)
+	}
+	return "Mixture: " + strings.Join(parts, ", ")
+}
+
+func (m mixture) Components() []Gas {
+	var result = make([]Gas, len(m.gases))
+	copy(result, m.gases)
+	return result
+}
+
+func (m mixture) MassFractions() []float64 {
+	var result = make([]float64, len(m.fractions))
+	copy(result, m.fractions)
+	return result
+}
+
+func (m mixture) MassFraction(name string) float64 {
+	var result = 0.
+	for i, gas := range m.gases {
+		if gas.String() == name {
+			result += m.fractions[i]
+		}
 	}
 	return result
 }
 
+func (m mixture) MoleFractions() []float64 {
+	var molarMass = m.MolarMass()
+	var result = make([]float64, len(m.fractions))
+	for i, gas := range m.gases {
+		result[i] = m.fractions[i] * molarMass / MolarMass(gas)
+	}
+	return result
+}
+
+func (m mixture) MolarMass() float64 {
+	return UniversalGasConstant / m.R()
+}
+
 func (m mixture) OxygenMassFraction() float64 {
 	return m.getParameter(func(gas Gas) float64 {
 		return gas.OxygenMassFraction()
@@ -50,6 +186,19 @@ func (m mixture) Cp(t float64) float64 {
 	})
 }
 
+func (m mixture) H(t float64) float64 {
+	return m.getParameter(func(gas Gas) float64 {
+		return gas.H(t)
+	})
+}
+
+// entropy of mixing is not taken into account, cos it is constant for the fixed composition
+func (m mixture) S0(t float64) float64 {
+	return m.getParameter(func(gas Gas) float64 {
+		return gas.S0(t)
+	})
+}
+
 func (m mixture) Mu(t float64) float64 {
 	return m.getParameter(func(gas Gas) float64 {
 		return gas.Mu(t)
@@ -76,3 +225,70 @@ func (m mixture) getParameter(f func(gas Gas) float64) float64 {
 
 	return result
 }
+
+func (m *mixture) add(gas Gas, fraction float64) {
+	for i, g := range m.gases {
+		if sameGas(g, gas) {
+			m.fractions[i] += fraction
+			return
+		}
+	}
+	m.gases = append(m.gases, gas)
+	m.fractions = append(m.fractions, fraction)
+}
+
+// normalize drops vanishing components and sorts the rest by name
+func (m *mixture) normalize() {
+	var gases = m.gases[:0]
+	var fractions = m.fractions[:0]
+	for i, gas := range m.gases {
+		// NaN fractions are kept to make invalid mixtures noticeable
+		if !(math.Abs(m.fractions[i]) <= vanishingFraction) {
+			gases = append(gases, gas)
+			fractions = append(fractions, m.fractions[i])
+		}
+	}
+	m.gases, m.fractions = gases, fractions
+	sort.Stable(byName{m})
+}
+
+const vanishingFraction = 1e-12
+
+type byName struct {
+	m *mixture
+}
+
+func (b byName) Len() int {
+	return len(b.m.gases)
+}
+
+func (b byName) Less(i, j int) bool {
+	return b.m.gases[i].String() < b.m.gases[j].String()
+}
+
+func (b byName) Swap(i, j int) {
+	b.m.gases[i], b.m.gases[j] = b.m.gases[j], b.m.gases[i]
+	b.m.fractions[i], b.m.fractions[j] = b.m.fractions[j], b.m.fractions[i]
+}
+
+func massFraction(c Composition, gas Gas) float64 {
+	var fractions = c.MassFractions()
+	for i, g := range c.Components() {
+		if sameGas(g, gas) {
+			return fractions[i]
+		}
+	}
+	return 0
+}
+
+// sameGas compares gases by value if possible and by name otherwise
+func sameGas(g1, g2 Gas) bool {
+	var t = reflect.TypeOf(g1)
+	if t != reflect.TypeOf(g2) {
+		return false
+	}
+	if !t.Comparable() {
+		return g1.String() == g2.String()
+	}
+	return g1 == g2
+}
@@ -0,0 +1,65 @@
+package gases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMixture_Flattening(t *testing.T) {
+	var inner = NewMixture([]Gas{GetCO2(), GetH2OVapour()}, []float64{1, 1})
+	var outer = NewMixture([]Gas{inner, GetAir(), GetCO2()}, []float64{0.4, 0.5, 0.1})
+
+	assert.Equal(t, 3, len(outer.Components()))
+	for _, gas := range outer.Components() {
+		var _, isMixture = gas.(mixture)
+		assert.False(t, isMixture)
+	}
+
+	assert.InDelta(t, 0.3, outer.MassFraction("CO2"), 1e-12)
+	assert.InDelta(t, 0.2, outer.MassFraction("H2O vapour"), 1e-12)
+	assert.InDelta(t, 0.5, outer.MassFraction("Air"), 1e-12)
+
+	var expectedCp = 0.3*GetCO2().Cp(800) + 0.2*GetH2OVapour().Cp(800) + 0.5*GetAir().Cp(800)
+	assert.InDelta(t, expectedCp, outer.Cp(800), 1e-9)
+}
+
+func TestNewMixture_CanonicalOrder(t *testing.T) {
+	var m1 = NewMixture([]Gas{GetAir(), GetCO2()}, []float64{0.7, 0.3})
+	var m2 = NewMixture([]Gas{GetCO2(), GetAir()}, []float64{0.3, 0.7})
+
+	assert.Equal(t, m1.String(), m2.String())
+	assert.True(t, Equal(m1, m2, 1e-12))
+	assert.InDelta(t, 0, Residual(m1, m2), 1e-12)
+}
+
+func TestNewMixture_Subtraction(t *testing.T) {
+	var oxyFree = GetOxyFreeGas(GetAir())
+	var mixed = NewMixture([]Gas{oxyFree, GetOxygen()}, []float64{1 - 0.2315, 0.2315})
+
+	assert.Equal(t, 1, len(mixed.Components()))
+	assert.True(t, Equal(mixed, GetAir(), 1e-9))
+}
+
+func TestResidual(t *testing.T) {
+	var m1 = NewMixture([]Gas{GetAir(), GetCO2()}, []float64{0.7, 0.3})
+	var m2 = NewMixture([]Gas{GetAir(), GetH2OVapour()}, []float64{0.8, 0.2})
+
+	assert.InDelta(t, 0.3, Residual(m1, m2), 1e-12)
+	assert.InDelta(t, 0.3, Residual(m2, m1), 1e-12)
+	assert.InDelta(t, 0.3, Residual(m1, GetAir()), 1e-12)
+	assert.False(t, Equal(m1, m2, 0.1))
+}
+
+func TestComposition_MoleFractions(t *testing.T) {
+	var m = NewMixture([]Gas{GetNitrogen(), GetOxygen()}, []float64{0.5, 0.5})
+	var moleFractions = m.MoleFractions()
+
+	var mN2, mO2 = MolarMass(GetNitrogen()), MolarMass(GetOxygen())
+	var expectedMolarMass = 1 / (0.5/mN2 + 0.5/mO2)
+
+	assert.InDelta(t, expectedMolarMass, m.MolarMass(), 1e-12)
+	assert.InDelta(t, 1, moleFractions[0]+moleFractions[1], 1e-12)
+	// components are sorted by name: Nitrogen, Oxygen
+	assert.InDelta(t, 0.5*expectedMolarMass/mN2, moleFractions[0], 1e-12)
+}
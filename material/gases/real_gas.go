@@ -0,0 +1,234 @@
+package gases
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+)
+
+// number of stages polytropic compression of real gas is split into
+const polytropicStageNum = 50
+
+// RealGas is a fluid which properties depend on pressure.
+// Methods of Gas describe its ideal gas limit (p -> 0)
+type RealGas interface {
+	Gas
+	// HP is specific enthalpy relative to the ideal gas at TRef
+	HP(t, p float64) float64
+	// SP is specific entropy relative to the ideal gas at (TRef, PRef)
+	SP(t, p float64) float64
+	CpP(t, p float64) float64
+	DensityP(t, p float64) float64
+}
+
+// HP returns specific enthalpy of gas at temperature t and pressure p
+func HP(gas Gas, t, p float64) float64 {
+	if realGas, ok := gas.(RealGas); ok {
+		return realGas.HP(t, p)
+	}
+	return gas.H(t)
+}
+
+// SP returns specific entropy of gas at temperature t and pressure p
+func SP(gas Gas, t, p float64) float64 {
+	if realGas, ok := gas.(RealGas); ok {
+		return realGas.SP(t, p)
+	}
+	return S(gas, t, p)
+}
+
+// CpP returns heat capacity of gas at temperature t and pressure p
+func CpP(gas Gas, t, p float64) float64 {
+	if realGas, ok := gas.(RealGas); ok {
+		return realGas.CpP(t, p)
+	}
+	return gas.Cp(t)
+}
+
+// CpMeanP returns mean heat capacity of gas between t1 and t2 at pressure p.
+// For real gases it is calculated from enthalpy difference
+func CpMeanP(gas Gas, t1, t2, p float64, n int) float64 {
+	var realGas, ok = gas.(RealGas)
+	if !ok {
+		return CpMean(gas, t1, t2, n)
+	}
+	if t1 == t2 {
+		return realGas.CpP(t1, p)
+	}
+	return (realGas.HP(t2, p) - realGas.HP(t1, p)) / (t2 - t1)
+}
+
+// TFromHP returns temperature at which gas has enthalpy h at pressure p
+func TFromHP(gas Gas, h, p, tGuess float64) (float64, error) {
+	var realGas, ok = gas.(RealGas)
+	if !ok {
+		return TFromH(gas, h, tGuess)
+	}
+	return invert(
+		func(t float64) float64 {
+			return realGas.HP(t, p)
+		},
+		func(t float64) float64 {
+			return realGas.CpP(t, p)
+		},
+		h, tGuess,
+	)
+}
+
+// TFromSP returns temperature at which gas has entropy s at pressure p
+func TFromSP(gas Gas, s, p, tGuess float64) (float64, error) {
+	var realGas, ok = gas.(RealGas)
+	if !ok {
+		return TFromS0(gas, s+gas.R()*math.Log(p/PRef), tGuess)
+	}
+	return invert(
+		func(t float64) float64 {
+			return realGas.SP(t, p)
+		},
+		func(t float64) float64 {
+			return realGas.CpP(t, p) / t
+		},
+		s, tGuess,
+	)
+}
+
+// IsentropicTP returns temperature after isentropic process from (tIn, pIn) to pOut
+func IsentropicTP(gas Gas, tIn, pIn, pOut float64) (float64, error) {
+	if pIn <= 0 || pOut <= 0 {
+		return 0, fmt.Errorf("invalid pressures %f, %f", pIn, pOut)
+	}
+	var tIdeal, err = IsentropicT(gas, tIn, pOut/pIn)
+	if _, ok := gas.(RealGas); !ok || err != nil {
+		return tIdeal, err
+	}
+	return TFromSP(gas, SP(gas, tIn, pIn), pOut, tIdeal)
+}
+
+// PolytropicCompressionTP returns outlet temperature of compression
+// from (tIn, pIn) to pOut with polytropic efficiency etaPol
+func PolytropicCompressionTP(gas Gas, tIn, pIn, pOut, etaPol float64) (float64, error) {
+	if pIn <= 0 || pOut <= 0 {
+		return 0, fmt.Errorf("invalid pressures %f, %f", pIn, pOut)
+	}
+	if _, ok := gas.(RealGas); !ok {
+		return PolytropicCompressionT(gas, tIn, pOut/pIn, etaPol)
+	}
+
+	// polytropic efficiency is adiabatic efficiency of an infinitesimal stage
+	var stagePi = math.Pow(pOut/pIn, 1./polytropicStageNum)
+	var t, p = tIn, pIn
+	for i := 0; i != polytropicStageNum; i++ {
+		var err error
+		t, err = CompressionTP(gas, t, p, p*stagePi, etaPol)
+		if err != nil {
+			return 0, err
+		}
+		p *= stagePi
+	}
+	return t, nil
+}
+
+// CompressionTP returns outlet temperature of compression
+// from (tIn, pIn) to pOut with adiabatic efficiency etaAd
+func CompressionTP(gas Gas, tIn, pIn, pOut, etaAd float64) (float64, error) {
+	if _, ok := gas.(RealGas); !ok {
+		return CompressionT(gas, tIn, pOut/pIn, etaAd)
+	}
+	var tAd, err = IsentropicTP(gas, tIn, pIn, pOut)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = HP(gas, tIn, pIn)
+	return TFromHP(gas, hIn+(HP(gas, tAd, pOut)-hIn)/etaAd, pOut, tIn+(tAd-tIn)/etaAd)
+}
+
+// ExpansionTP returns outlet temperature of expansion
+// from (tIn, pIn) to pOut with adiabatic efficiency etaT
+func ExpansionTP(gas Gas, tIn, pIn, pOut, etaT float64) (float64, error) {
+	if _, ok := gas.(RealGas); !ok {
+		return ExpansionT(gas, tIn, pIn/pOut, etaT)
+	}
+	var tAd, err = IsentropicTP(gas, tIn, pIn, pOut)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = HP(gas, tIn, pIn)
+	return TFromHP(gas, hIn-(hIn-HP(gas, tAd, pOut))*etaT, pOut, tIn-(tIn-tAd)*etaT)
+}
+
+// AdiabaticEfficiencyP returns adiabatic efficiency of compression (pOut > pIn)
+// or expansion (pOut < pIn) process from (tIn, pIn) to (tOut, pOut)
+func AdiabaticEfficiencyP(gas Gas, tIn, pIn, tOut, pOut float64) (float64, error) {
+	if _, ok := gas.(RealGas); !ok {
+		return AdiabaticEfficiency(gas, tIn, tOut, pOut/pIn)
+	}
+	var tAd, err = IsentropicTP(gas, tIn, pIn, pOut)
+	if err != nil {
+		return 0, err
+	}
+	var hIn = HP(gas, tIn, pIn)
+	if pOut >= pIn {
+		return (HP(gas, tAd, pOut) - hIn) / (HP(gas, tOut, pOut) - hIn), nil
+	}
+	return (hIn - HP(gas, tOut, pOut)) / (hIn - HP(gas, tAd, pOut)), nil
+}
+
+// CompressionPressureRatioP returns pressure ratio pOut / pIn of the compression
+// from (tIn, pIn) to tOut with adiabatic efficiency etaAd
+func CompressionPressureRatioP(gas Gas, tIn, pIn, tOut, etaAd float64) (float64, error) {
+	if _, ok := gas.(RealGas); !ok {
+		return CompressionPressureRatio(gas, tIn, tOut, etaAd)
+	}
+	var piGuess, err = CompressionPressureRatio(gas, tIn, tOut, etaAd)
+	if err != nil {
+		return 0, err
+	}
+	return pressureRatio(func(pOut float64) (float64, error) {
+		return CompressionTP(gas, tIn, pIn, pOut, etaAd)
+	}, pIn, tOut, piGuess)
+}
+
+// ExpansionPressureRatioP returns pressure ratio pIn / pOut of the expansion
+// from (tIn, pIn) to tOut with adiabatic efficiency etaT
+func ExpansionPressureRatioP(gas Gas, tIn, pIn, tOut, etaT float64) (float64, error) {
+	if _, ok := gas.(RealGas); !ok {
+		return ExpansionPressureRatio(gas, tIn, tOut, etaT)
+	}
+	var piGuess, err = ExpansionPressureRatio(gas, tIn, tOut, etaT)
+	if err != nil {
+		return 0, err
+	}
+	pi, err := pressureRatio(func(pOut float64) (float64, error) {
+		return ExpansionTP(gas, tIn, pIn, pOut, etaT)
+	}, pIn, tOut, 1/piGuess)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / pi, nil
+}
+
+// pressureRatio returns pOut / pIn at which process tOutFunc gives temperature tOut.
+// Logarithm of the pressure ratio is searched for
+func pressureRatio(tOutFunc func(pOut float64) (float64, error), pIn, tOut, piGuess float64) (float64, error) {
+	var residual = func(lnPi float64) (float64, error) {
+		var t, err = tOutFunc(pIn * math.Exp(lnPi))
+		if err != nil {
+			return 0, err
+		}
+		return t - tOut, nil
+	}
+
+	var lnGuess = math.Log(piGuess)
+	var a, b, err = root.Bracket(
+		residual, lnGuess-inversionStep, lnGuess+inversionStep, inversionGrowFactor, inversionIterLimit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	var report, solveErr = root.NewBrentSolver(inversionPrecision, inversionIterLimit).Solve(residual, a, b)
+	if solveErr != nil {
+		return 0, solveErr
+	}
+	return math.Exp(report.X), nil
+}
@@ -24,6 +24,65 @@ func GetH2OVapour() Gas {
 	return h2oVapour{}
 }
 
+// TODO check last value (taken at random)
+var airCpTable = newCPTable(
+	[]float64{
+		260, 333, 393, 413, 433, 453, 473, 523, 573, 623,
+		673, 773, 873, 973, 1073, 1173, 1273, 1373, 1473, 2000,
+	},
+	[]float64{
+		1000, 1005, 1009, 1013, 1017, 1022, 1026, 1038, 1047, 1059, 1068, 1093, 1114, 1135, 1156, 1172, 1185, 1197, 1210, 1300,
+	},
+)
+
+var nitrogenCpTable = newCPTable(
+	[]float64{
+		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
+		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
+	},
+	[]float64{
+		1039, 1040, 1040, 1041, 1042, 1044, 1049, 1056, 1065, 1075, 1086, 1098, 1110, 1122, 1134, 1146,
+		1157, 1167, 1177, 1187, 1196, 1204, 1212, 1219, 1226, 1232, 1244, 1254, 1263, 1271, 1278, 1284,
+	},
+)
+
+var oxygenCpTable = newCPTable(
+	[]float64{
+		300,
+		350, 400, 450, 500, 550,
+		600, 700, 800, 900, 1000,
+		1100, 1200, 1300,
+	},
+	[]float64{
+		920,
+		929, 942, 956, 972, 988,
+		1003, 1031, 1054, 1074, 1090,
+		1103, 1115, 1125,
+	},
+)
+
+var co2CpTable = newCPTable(
+	[]float64{
+		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
+		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
+	},
+	[]float64{
+		819, 846, 871, 895, 918, 939, 978, 1014, 1046, 1075, 1102, 1126, 1148, 1168, 1187, 1204, 1220, 1234,
+		1247, 1259, 1270, 1280, 1290, 1298, 1306, 1313, 1326, 1338, 1348, 1356, 1364, 1371,
+	},
+)
+
+var h2oVapourCpTable = newCPTable(
+	[]float64{
+		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
+		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
+	},
+	[]float64{
+		1859, 1865, 1872, 1881, 1891, 1902, 1927, 1955, 1985, 2016, 2049, 2082, 2116, 2150, 2185, 2220, 2255, 2292,
+		2329, 2366, 2401, 2436, 2469, 2502, 2534, 2565, 2625, 2681, 2734, 2784, 2830, 2873,
+	},
+)
+
 type air struct{}
 
 func (air) String() string {
@@ -35,17 +94,15 @@ func (air) OxygenMassFraction() float64 {
 }
 
 func (air) Cp(t float64) float64 {
-	// TODO check last value (taken at random)
-	var tArr = []float64{
-		260, 333, 393, 413, 433, 453, 473, 523, 573, 623,
-		673, 773, 873, 973, 1073, 1173, 1273, 1373, 1473, 2000,
-	}
-	var cpArr = []float64{
-		1000, 1005, 1009, 1013, 1017, 1022, 1026, 1038, 1047, 1059, 1068, 1093, 1114, 1135, 1156, 1172, 1185, 1197, 1210, 1300,
-	}
+	return airCpTable.Cp(t)
+}
 
-	var cp = common.InterpTolerate(t, tArr, cpArr)
-	return cp
+func (air) H(t float64) float64 {
+	return airCpTable.H(t)
+}
+
+func (air) S0(t float64) float64 {
+	return airCpTable.S0(t)
 }
 
 func (air) R() float64 {
@@ -101,17 +158,15 @@ func (nitrogen) String() string {
 }
 
 func (nitrogen) Cp(t float64) float64 {
-	var tArr = []float64{
-		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
-		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
-	}
-	var cpArr = []float64{
-		1039, 1040, 1040, 1041, 1042, 1044, 1049, 1056, 1065, 1075, 1086, 1098, 1110, 1122, 1134, 1146,
-		1157, 1167, 1177, 1187, 1196, 1204, 1212, 1219, 1226, 1232, 1244, 1254, 1263, 1271, 1278, 1284,
-	}
+	return nitrogenCpTable.Cp(t)
+}
 
-	var cp = common.InterpTolerate(t, tArr, cpArr)
-	return cp
+func (nitrogen) H(t float64) float64 {
+	return nitrogenCpTable.H(t)
+}
+
+func (nitrogen) S0(t float64) float64 {
+	return nitrogenCpTable.S0(t)
 }
 
 func (nitrogen) R() float64 {
@@ -161,21 +216,15 @@ func (oxygen) OxygenMassFraction() float64 {
 }
 
 func (oxygen) Cp(t float64) float64 {
-	var tArr = []float64{
-		300,
-		350, 400, 450, 500, 550,
-		600, 700, 800, 900, 1000,
-		1100, 1200, 1300,
-	}
-	var cpArr = []float64{
-		920,
-		929, 942, 956, 972, 988,
-		1003, 1031, 1054, 1074, 1090,
-		1103, 1115, 1125,
-	}
+	return oxygenCpTable.Cp(t)
+}
 
-	var cp = common.InterpTolerate(t, tArr, cpArr)
-	return cp
+func (oxygen) H(t float64) float64 {
+	return oxygenCpTable.H(t)
+}
+
+func (oxygen) S0(t float64) float64 {
+	return oxygenCpTable.S0(t)
 }
 
 func (oxygen) R() float64 {
@@ -227,17 +276,15 @@ func (co2) String() string {
 }
 
 func (co2) Cp(t float64) float64 {
-	var tArr = []float64{
-		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
-		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
-	}
-	var cpArr = []float64{
-		819, 846, 871, 895, 918, 939, 978, 1014, 1046, 1075, 1102, 1126, 1148, 1168, 1187, 1204, 1220, 1234,
-		1247, 1259, 1270, 1280, 1290, 1298, 1306, 1313, 1326, 1338, 1348, 1356, 1364, 1371,
-	}
+	return co2CpTable.Cp(t)
+}
 
-	var cp = common.InterpTolerate(t, tArr, cpArr)
-	return cp
+func (co2) H(t float64) float64 {
+	return co2CpTable.H(t)
+}
+
+func (co2) S0(t float64) float64 {
+	return co2CpTable.S0(t)
 }
 
 func (co2) R() float64 {
@@ -281,17 +328,15 @@ func (h2oVapour) String() string {
 }
 
 func (h2oVapour) Cp(t float64) float64 {
-	var tArr = []float64{
-		275, 300, 325, 350, 375, 400, 450, 500, 550, 600, 650, 700, 750, 800, 850, 900, 950, 1000, 1050,
-		1100, 1150, 1200, 1250, 1300, 1350, 1400, 1500, 1600, 1700, 1800, 1900, 2000,
-	}
-	var cpArr = []float64{
-		819, 846, 871, 895, 918, 939, 978, 1014, 1046, 1075, 1102, 1126, 1148, 1168, 1187, 1204, 1220, 1234,
-		1247, 1259, 1270, 1280, 1290, 1298, 1306, 1313, 1326, 1338, 1348, 1356, 1364, 1371,
-	}
+	return h2oVapourCpTable.Cp(t)
+}
 
-	var cp = common.InterpTolerate(t, tArr, cpArr)
-	return cp
+func (h2oVapour) H(t float64) float64 {
+	return h2oVapourCpTable.H(t)
+}
+
+func (h2oVapour) S0(t float64) float64 {
+	return h2oVapourCpTable.S0(t)
 }
 
 func (h2oVapour) R() float64 {
@@ -0,0 +1,144 @@
+package realgas
+
+import (
+	"math"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbocycle/material/gases/species"
+)
+
+const (
+	sqrt2 = math.Sqrt2
+
+	// relative temperature step of numerical differentiation of enthalpy
+	cpRelStep = 1e-5
+)
+
+// GetCO2 returns carbon dioxide described by Peng-Robinson equation of state
+// (critical point of Span and Wagner) with ideal gas part from the species database.
+// The same instance is returned every time, so that compositions of CO2 flows match
+func GetCO2() gases.RealGas {
+	return co2
+}
+
+var co2 = NewPengRobinson(species.MustGet(species.CarbonDioxideName), 304.1282, 7.3773e6, 0.22394)
+
+// NewPengRobinson returns real gas described by Peng-Robinson equation of state
+// with critical temperature tCrit, critical pressure pCrit and acentric factor omega.
+// ideal describes ideal gas limit of the fluid. Transport properties are taken from ideal
+func NewPengRobinson(ideal gases.Gas, tCrit, pCrit, omega float64) gases.RealGas {
+	var ru = gases.UniversalGasConstant
+	return &pengRobinson{
+		Gas:   ideal,
+		tCrit: tCrit,
+		a:     0.45723553 * ru * ru * tCrit * tCrit / pCrit,
+		b:     0.07779607 * ru * tCrit / pCrit,
+		kappa: 0.37464 + 1.54226*omega - 0.26992*omega*omega,
+	}
+}
+
+type pengRobinson struct {
+	gases.Gas
+
+	tCrit float64
+	a     float64 // J * m^3 / mol^2
+	b     float64 // m^3 / mol
+	kappa float64
+}
+
+func (pr *pengRobinson) String() string {
+	return pr.Gas.String() + " (PR)"
+}
+
+func (pr *pengRobinson) HP(t, p float64) float64 {
+	var z, bigB = pr.z(t, p)
+	var aAlpha, daAlpha = pr.aAlpha(t)
+	var departure = gases.UniversalGasConstant*t*(z-1) + (t*daAlpha-aAlpha)/(2*sqrt2*pr.b)*pr.logTerm(z, bigB)
+	return pr.Gas.H(t) + departure/gases.MolarMass(pr.Gas)
+}
+
+func (pr *pengRobinson) SP(t, p float64) float64 {
+	var z, bigB = pr.z(t, p)
+	var _, daAlpha = pr.aAlpha(t)
+	var departure = gases.UniversalGasConstant*math.Log(z-bigB) + daAlpha/(2*sqrt2*pr.b)*pr.logTerm(z, bigB)
+	return gases.S(pr.Gas, t, p) + departure/gases.MolarMass(pr.Gas)
+}
+
+func (pr *pengRobinson) CpP(t, p float64) float64 {
+	var dt = t * cpRelStep
+	return (pr.HP(t+dt, p) - pr.HP(t-dt, p)) / (2 * dt)
+}
+
+func (pr *pengRobinson) DensityP(t, p float64) float64 {
+	var z, _ = pr.z(t, p)
+	return p / (z * pr.R() * t)
+}
+
+// Z returns compressibility factor
+func (pr *pengRobinson) Z(t, p float64) float64 {
+	var z, _ = pr.z(t, p)
+	return z
+}
+
+// aAlpha returns temperature-dependent attraction parameter and its temperature derivative
+func (pr *pengRobinson) aAlpha(t float64) (float64, float64) {
+	var sqrtAlpha = 1 + pr.kappa*(1-math.Sqrt(t/pr.tCrit))
+	var aAlpha = pr.a * sqrtAlpha * sqrtAlpha
+	var daAlpha = -pr.a * pr.kappa * sqrtAlpha / math.Sqrt(t*pr.tCrit)
+	return aAlpha, daAlpha
+}
+
+func (pr *pengRobinson) logTerm(z, bigB float64) float64 {
+	return math.Log((z + (1+sqrt2)*bigB) / (z + (1-sqrt2)*bigB))
+}
+
+// z returns compressibility factor and dimensionless covolume B.
+// If equation of state has several roots, the one with the least Gibbs energy is chosen
+func (pr *pengRobinson) z(t, p float64) (float64, float64) {
+	var rt = gases.UniversalGasConstant * t
+	var aAlpha, _ = pr.aAlpha(t)
+	var bigA = aAlpha * p / (rt * rt)
+	var bigB = pr.b * p / rt
+
+	var roots = cubicRoots(
+		-(1 - bigB),
+		bigA-3*bigB*bigB-2*bigB,
+		-(bigA*bigB - bigB*bigB - bigB*bigB*bigB),
+	)
+
+	var result = math.NaN()
+	var gMin = math.Inf(1)
+	for _, z := range roots {
+		if z <= bigB {
+			continue
+		}
+		// dimensionless Gibbs energy departure
+		var g = z - 1 - math.Log(z-bigB) - bigA/(2*sqrt2*bigB)*pr.logTerm(z, bigB)
+		if g < gMin {
+			gMin = g
+			result = z
+		}
+	}
+	return result, bigB
+}
+
+// cubicRoots returns real roots of x^3 + c2 * x^2 + c1 * x + c0 = 0
+func cubicRoots(c2, c1, c0 float64) []float64 {
+	var q = (3*c1 - c2*c2) / 9
+	var r = (9*c2*c1 - 27*c0 - 2*c2*c2*c2) / 54
+	var d = q*q*q + r*r
+	var shift = -c2 / 3
+
+	if d > 0 || q == 0 {
+		var sqrtD = math.Sqrt(d)
+		return []float64{shift + math.Cbrt(r+sqrtD) + math.Cbrt(r-sqrtD)}
+	}
+
+	var theta = math.Acos(r / math.Sqrt(-q*q*q))
+	var m = 2 * math.Sqrt(-q)
+	return []float64{
+		shift + m*math.Cos(theta/3),
+		shift + m*math.Cos((theta+2*math.Pi)/3),
+		shift + m*math.Cos((theta+4*math.Pi)/3),
+	}
+}
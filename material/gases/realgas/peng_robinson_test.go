@@ -0,0 +1,117 @@
+package realgas
+
+import (
+	"testing"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPengRobinson_IdealLimit(t *testing.T) {
+	var co2 = GetCO2()
+	var temp, p = 500., 1.
+
+	assert.InDelta(t, gases.Density(co2.(gases.Gas), temp, p), p/(co2.R()*temp), 1e-9)
+	assert.InDelta(t, co2.H(temp), co2.HP(temp, p), 1e-2)
+	assert.InDelta(t, gases.S(co2, temp, p), co2.SP(temp, p), 1e-5)
+	assert.InDelta(t, co2.Cp(temp), co2.CpP(temp, p), 1e-3)
+}
+
+func TestPengRobinson_CriticalPoint(t *testing.T) {
+	var co2 = GetCO2().(*pengRobinson)
+	// Peng-Robinson critical compressibility factor
+	assert.InDelta(t, 0.3074, co2.Z(304.1282, 7.3773e6), 5e-3)
+}
+
+func TestPengRobinson_Supercritical(t *testing.T) {
+	var co2 = GetCO2()
+
+	// reference density of CO2 (NIST): 20 MPa, 873.15 K and 8 MPa, 350 K
+	assert.InDelta(t, 117.9, co2.DensityP(873.15, 20e6), 0.03*117.9)
+	assert.InDelta(t, 175.9, co2.DensityP(350, 8e6), 0.05*175.9)
+
+	// dense fluid near compressor inlet is far from ideal gas
+	var temp, p = 308., 7.8e6
+	assert.True(t, co2.DensityP(temp, p) > 2*gases.Density(gasOf(co2), temp, p))
+	// and its heat capacity is several times greater
+	assert.True(t, co2.CpP(temp, p) > 2*co2.Cp(temp))
+}
+
+func TestPengRobinson_Consistency(t *testing.T) {
+	var co2 = GetCO2()
+	for _, point := range []struct{ t, p float64 }{{350, 10e6}, {700, 20e6}, {320, 8e6}} {
+		var dt = 1e-3
+		var cpS = point.t * (co2.SP(point.t+dt, point.p) - co2.SP(point.t-dt, point.p)) / (2 * dt)
+		assert.InDelta(t, co2.CpP(point.t, point.p), cpS, 1e-3*cpS)
+
+		// (dH / dp) at constant entropy is specific volume
+		var dp = point.p * 1e-5
+		var s = co2.SP(point.t, point.p)
+		var t2, err = gases.TFromSP(co2, s, point.p+dp, point.t)
+		assert.Nil(t, err)
+		var dh = co2.HP(t2, point.p+dp) - co2.HP(point.t, point.p)
+		assert.InDelta(t, 1/co2.DensityP(point.t, point.p), dh/dp, 1e-4/co2.DensityP(point.t, point.p))
+	}
+}
+
+func TestRealGasProcesses(t *testing.T) {
+	var co2 = GetCO2()
+	var tIn, pIn, pOut, eta = 308., 7.8e6, 20e6, 0.85
+
+	var tOut, err = gases.CompressionTP(co2, tIn, pIn, pOut, eta)
+	assert.Nil(t, err)
+	etaCalc, err := gases.AdiabaticEfficiencyP(co2, tIn, pIn, tOut, pOut)
+	assert.Nil(t, err)
+	assert.InDelta(t, eta, etaCalc, 1e-6)
+
+	pi, err := gases.CompressionPressureRatioP(co2, tIn, pIn, tOut, eta)
+	assert.Nil(t, err)
+	assert.InDelta(t, pOut/pIn, pi, 1e-6)
+
+	// compression of dense fluid requires much less work than of ideal gas
+	tOutIdeal, _ := gases.CompressionT(gasOf(co2), tIn, pOut/pIn, eta)
+	var labour = co2.HP(tOut, pOut) - co2.HP(tIn, pIn)
+	var labourIdeal = co2.H(tOutIdeal) - co2.H(tIn)
+	assert.True(t, labour < 0.5*labourIdeal, "%f %f", labour, labourIdeal)
+
+	tPol, err := gases.PolytropicCompressionTP(co2, tIn, pIn, pOut, eta)
+	assert.Nil(t, err)
+	assert.True(t, tPol > tOut) // polytropic efficiency is greater than adiabatic one of the same process
+
+	var tTurbIn = 823.
+	tTurbOut, err := gases.ExpansionTP(co2, tTurbIn, pOut, pIn, eta)
+	assert.Nil(t, err)
+	piT, err := gases.ExpansionPressureRatioP(co2, tTurbIn, pOut, tTurbOut, eta)
+	assert.Nil(t, err)
+	assert.InDelta(t, pOut/pIn, piT, 1e-6)
+}
+
+// gasOf returns ideal gas limit of the real gas
+func gasOf(gas gases.RealGas) gases.Gas {
+	return gas.(*pengRobinson).Gas
+}
+
+func TestPengRobinson_Mixing(t *testing.T) {
+	var co2 = GetCO2()
+	assert.Equal(t, co2, GetCO2())
+	assert.Equal(t, 0., gases.Residual(GetCO2(), GetCO2()))
+
+	// mixing of equal flows keeps properties depending on pressure
+	var temp, p = 320., 20e6
+	var mixed = gases.NewMixture([]gases.Gas{co2, GetCO2()}, []float64{0.3, 0.7})
+	var realMixed, ok = mixed.(gases.RealGas)
+	assert.True(t, ok)
+	assert.InDelta(t, co2.HP(temp, p), gases.HP(mixed, temp, p), 1e-9)
+	assert.InDelta(t, co2.HP(temp, p), realMixed.HP(temp, p), 1e-9)
+	assert.Equal(t, 0., gases.Residual(mixed, co2))
+
+	// mixtures of mixtures remain real
+	var remixed = gases.NewMixture([]gases.Gas{mixed, co2}, []float64{0.5, 0.5})
+	assert.InDelta(t, co2.SP(temp, p), gases.SP(remixed, temp, p), 1e-9)
+
+	assert.Panics(t, func() {
+		gases.NewMixture([]gases.Gas{co2, gases.GetAir()}, []float64{0.5, 0.5})
+	})
+	var _, err = gases.Mix([]gases.Gas{co2, gases.GetAir()}, []float64{0.5, 0.5})
+	assert.NotNil(t, err)
+}
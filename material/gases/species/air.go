@@ -0,0 +1,13 @@
+package species
+
+import "github.com/Sovianum/turbocycle/material/gases"
+
+// DryAir returns standard dry air composed of the database species (mass fractions)
+func DryAir() gases.Gas {
+	return gases.NewMixture(
+		[]gases.Gas{
+			MustGet(NitrogenName), MustGet(OxygenName), MustGet(ArgonName), MustGet(CarbonDioxideName),
+		},
+		[]float64{0.75518, 0.23135, 0.01288, 0.00059},
+	)
+}
@@ -0,0 +1,181 @@
+package species
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+const (
+	NitrogenName       = "N2"
+	OxygenName         = "O2"
+	ArgonName          = "Ar"
+	CarbonDioxideName  = "CO2"
+	WaterName          = "H2O"
+	CarbonMonoxideName = "CO"
+	HydrogenName       = "H2"
+	MethaneName        = "CH4"
+	NitricOxideName    = "NO"
+	HydroxylName       = "OH"
+	HeliumName         = "He"
+	XenonName          = "Xe"
+	EthaneName         = "C2H6"
+	PropaneName        = "C3H8"
+	SulfurDioxideName  = "SO2"
+	AtomicOxygenName   = "O"
+	AtomicHydrogenName = "H"
+)
+
+//go:embed data/*.json
+var dataFS embed.FS
+
+var defaultDatabase = mustReadDefaultDatabase()
+
+// Database is a set of species which can be extended by adding data files
+type Database interface {
+	Get(name string) (Species, error)
+	Names() []string
+}
+
+// Get returns species from the embedded database
+func Get(name string) (Species, error) {
+	return defaultDatabase.Get(name)
+}
+
+// MustGet returns species from the embedded database and panics if it is absent
+func MustGet(name string) Species {
+	var result, err = Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Names returns sorted names of the species of the embedded database
+func Names() []string {
+	return defaultDatabase.Names()
+}
+
+// DefaultDatabase returns database built from the embedded data files
+func DefaultDatabase() Database {
+	return defaultDatabase
+}
+
+// ReadDatabase reads all *.json files from the root of fsys. Each file describes one species
+func ReadDatabase(fsys fs.FS) (Database, error) {
+	var fileNames, err = fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &database{speciesMap: make(map[string]Species)}
+	for _, fileName := range fileNames {
+		var data, readErr = fs.ReadFile(fsys, fileName)
+		if readErr != nil {
+			return nil, readErr
+		}
+		var s, parseErr = Parse(data)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s: %v", path.Base(fileName), parseErr)
+		}
+		if _, ok := result.speciesMap[s.Name()]; ok {
+			return nil, fmt.Errorf("%s: duplicate species %s", path.Base(fileName), s.Name())
+		}
+		result.speciesMap[s.Name()] = s
+	}
+	return result, nil
+}
+
+// Parse builds species from its json description
+func Parse(data []byte) (Species, error) {
+	var desc speciesDescription
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return nil, err
+	}
+	return desc.build()
+}
+
+type database struct {
+	speciesMap map[string]Species
+}
+
+func (db *database) Get(name string) (Species, error) {
+	var result, ok = db.speciesMap[name]
+	if !ok {
+		return nil, fmt.Errorf("species %s not found", name)
+	}
+	return result, nil
+}
+
+func (db *database) Names() []string {
+	var result = make([]string, 0, len(db.speciesMap))
+	for name := range db.speciesMap {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+type speciesDescription struct {
+	Name         string             `json:"name"`
+	Source       string             `json:"source"`
+	Elements     map[string]float64 `json:"elements"`
+	MolarMass    float64            `json:"molar_mass"` // g / mol
+	Format       string             `json:"format"`
+	Ranges       []rangeDescription `json:"ranges"`
+	LennardJones struct {
+		EpsilonK float64 `json:"epsilon_k"`
+		Sigma    float64 `json:"sigma"`
+	} `json:"lennard_jones"`
+}
+
+type rangeDescription struct {
+	TMin  float64   `json:"t_min"`
+	TMax  float64   `json:"t_max"`
+	Coefs []float64 `json:"coefs"`
+}
+
+func (desc speciesDescription) build() (Species, error) {
+	if desc.Name == "" {
+		return nil, fmt.Errorf("species name is not set")
+	}
+	if desc.MolarMass <= 0 {
+		return nil, fmt.Errorf("%s: invalid molar mass %f", desc.Name, desc.MolarMass)
+	}
+	if desc.LennardJones.EpsilonK <= 0 || desc.LennardJones.Sigma <= 0 {
+		return nil, fmt.Errorf("%s: invalid Lennard-Jones parameters", desc.Name)
+	}
+
+	var ranges = make([]nasaRange, len(desc.Ranges))
+	for i, rd := range desc.Ranges {
+		var r, err = newNasaRange(desc.Format, rd.TMin, rd.TMax, rd.Coefs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", desc.Name, err)
+		}
+		ranges[i] = r
+	}
+	var polynomial, err = newNasaPolynomial(ranges)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", desc.Name, err)
+	}
+
+	return newSpecies(
+		desc.Name, desc.Elements, desc.MolarMass*1e-3, polynomial,
+		lennardJones{epsilonK: desc.LennardJones.EpsilonK, sigma: desc.LennardJones.Sigma},
+	), nil
+}
+
+func mustReadDefaultDatabase() Database {
+	var sub, err = fs.Sub(dataFS, "data")
+	if err != nil {
+		panic(err)
+	}
+	db, err := ReadDatabase(sub)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
@@ -0,0 +1,126 @@
+package species
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	nasa7Format = "nasa7"
+	nasa9Format = "nasa9"
+
+	nasa7CoefNum = 7
+	nasa9CoefNum = 9
+)
+
+// nasaRange holds NASA polynomial in the 9-coefficient form:
+// cp / R = a1 / T^2 + a2 / T + a3 + a4 * T + a5 * T^2 + a6 * T^3 + a7 * T^4;
+// h / (R * T) = -a1 / T^2 + a2 * ln(T) / T + a3 + a4 * T / 2 + a5 * T^2 / 3 + a6 * T^3 / 4 + a7 * T^4 / 5 + b1 / T;
+// s / R = -a1 / (2 * T^2) - a2 / T + a3 * ln(T) + a4 * T + a5 * T^2 / 2 + a6 * T^3 / 3 + a7 * T^4 / 4 + b2.
+// 7-coefficient polynomials are converted to this form with a1 = a2 = 0
+type nasaRange struct {
+	tMin float64
+	tMax float64
+	a    [7]float64
+	b1   float64
+	b2   float64
+}
+
+func newNasaRange(format string, tMin, tMax float64, coefs []float64) (nasaRange, error) {
+	var result = nasaRange{tMin: tMin, tMax: tMax}
+	if tMin >= tMax {
+		return result, fmt.Errorf("invalid temperature range [%f, %f]", tMin, tMax)
+	}
+
+	switch format {
+	case nasa7Format:
+		if len(coefs) != nasa7CoefNum {
+			return result, fmt.Errorf("%s range requires %d coefficients, got %d", format, nasa7CoefNum, len(coefs))
+		}
+		copy(result.a[2:], coefs[:5])
+		result.b1, result.b2 = coefs[5], coefs[6]
+	case nasa9Format:
+		if len(coefs) != nasa9CoefNum {
+			return result, fmt.Errorf("%s range requires %d coefficients, got %d", format, nasa9CoefNum, len(coefs))
+		}
+		copy(result.a[:], coefs[:7])
+		result.b1, result.b2 = coefs[7], coefs[8]
+	default:
+		return result, fmt.Errorf("unknown polynomial format \"%s\"", format)
+	}
+	return result, nil
+}
+
+// cpR returns cp / R
+func (r nasaRange) cpR(t float64) float64 {
+	var a = r.a
+	return a[0]/(t*t) + a[1]/t + a[2] + t*(a[3]+t*(a[4]+t*(a[5]+t*a[6])))
+}
+
+// hRT returns h / (R * T) where h includes enthalpy of formation
+func (r nasaRange) hRT(t float64) float64 {
+	var a = r.a
+	return -a[0]/(t*t) + a[1]*math.Log(t)/t + a[2] +
+		t*(a[3]/2+t*(a[4]/3+t*(a[5]/4+t*a[6]/5))) + r.b1/t
+}
+
+// sR returns s / R at reference pressure
+func (r nasaRange) sR(t float64) float64 {
+	var a = r.a
+	return -a[0]/(2*t*t) - a[1]/t + a[2]*math.Log(t) +
+		t*(a[3]+t*(a[4]/2+t*(a[5]/3+t*a[6]/4))) + r.b2
+}
+
+// nasaPolynomial is a set of adjacent temperature ranges.
+// Out of the covered interval the nearest range is extrapolated
+type nasaPolynomial []nasaRange
+
+func newNasaPolynomial(ranges []nasaRange) (nasaPolynomial, error) {
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no temperature ranges")
+	}
+	var result = make(nasaPolynomial, len(ranges))
+	copy(result, ranges)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].tMin < result[j].tMin
+	})
+	for i := 1; i < len(result); i++ {
+		if math.Abs(result[i].tMin-result[i-1].tMax) > 1e-6 {
+			return nil, fmt.Errorf(
+				"temperature ranges [%f, %f] and [%f, %f] are not adjacent",
+				result[i-1].tMin, result[i-1].tMax, result[i].tMin, result[i].tMax,
+			)
+		}
+	}
+	return result, nil
+}
+
+func (p nasaPolynomial) tMin() float64 {
+	return p[0].tMin
+}
+
+func (p nasaPolynomial) tMax() float64 {
+	return p[len(p)-1].tMax
+}
+
+func (p nasaPolynomial) get(t float64) nasaRange {
+	for _, r := range p[:len(p)-1] {
+		if t < r.tMax {
+			return r
+		}
+	}
+	return p[len(p)-1]
+}
+
+func (p nasaPolynomial) cpR(t float64) float64 {
+	return p.get(t).cpR(t)
+}
+
+func (p nasaPolynomial) hRT(t float64) float64 {
+	return p.get(t).hRT(t)
+}
+
+func (p nasaPolynomial) sR(t float64) float64 {
+	return p.get(t).sR(t)
+}
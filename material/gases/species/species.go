@@ -0,0 +1,129 @@
+package species
+
+import (
+	"github.com/Sovianum/turbocycle/material/gases"
+)
+
+// Species is an ideal gas with thermodynamic properties defined by NASA polynomials.
+// Its H and S0 are relative to gases.TRef to be consistent with the other gases;
+// absolute values (enthalpy including the enthalpy of formation and the
+// third-law entropy) are available separately
+type Species interface {
+	gases.Gas
+
+	Name() string
+	// MolarMass is in kg / mol
+	MolarMass() float64
+	// Elements returns number of atoms of each element in the molecule
+	Elements() map[string]float64
+	// HAbs is specific enthalpy including enthalpy of formation, J / kg
+	HAbs(t float64) float64
+	// S0Abs is absolute specific entropy at gases.PRef, J / (kg * K)
+	S0Abs(t float64) float64
+	// HFormation is specific enthalpy of formation at gases.TRef, J / kg
+	HFormation() float64
+	// TMin and TMax bound the temperature range covered by the data
+	TMin() float64
+	TMax() float64
+}
+
+func newSpecies(
+	name string, elements map[string]float64, molarMass float64,
+	polynomial nasaPolynomial, lj lennardJones,
+) Species {
+	var result = &species{
+		name:       name,
+		elements:   elements,
+		molarMass:  molarMass,
+		r:          gases.UniversalGasConstant / molarMass,
+		polynomial: polynomial,
+		lj:         lj,
+	}
+	result.hRef = result.HAbs(gases.TRef)
+	result.s0Ref = result.S0Abs(gases.TRef)
+	return result
+}
+
+type species struct {
+	name      string
+	elements  map[string]float64
+	molarMass float64
+	r         float64
+
+	polynomial nasaPolynomial
+	lj         lennardJones
+
+	hRef  float64
+	s0Ref float64
+}
+
+func (s *species) String() string {
+	return s.name
+}
+
+func (s *species) Name() string {
+	return s.name
+}
+
+func (s *species) MolarMass() float64 {
+	return s.molarMass
+}
+
+func (s *species) Elements() map[string]float64 {
+	var result = make(map[string]float64, len(s.elements))
+	for element, num := range s.elements {
+		result[element] = num
+	}
+	return result
+}
+
+func (s *species) OxygenMassFraction() float64 {
+	if s.name == OxygenName {
+		return 1
+	}
+	return 0
+}
+
+func (s *species) Cp(t float64) float64 {
+	return s.polynomial.cpR(t) * s.r
+}
+
+func (s *species) H(t float64) float64 {
+	return s.HAbs(t) - s.hRef
+}
+
+func (s *species) S0(t float64) float64 {
+	return s.S0Abs(t) - s.s0Ref
+}
+
+func (s *species) HAbs(t float64) float64 {
+	return s.polynomial.hRT(t) * s.r * t
+}
+
+func (s *species) S0Abs(t float64) float64 {
+	return s.polynomial.sR(t) * s.r
+}
+
+func (s *species) HFormation() float64 {
+	return s.hRef
+}
+
+func (s *species) TMin() float64 {
+	return s.polynomial.tMin()
+}
+
+func (s *species) TMax() float64 {
+	return s.polynomial.tMax()
+}
+
+func (s *species) R() float64 {
+	return s.r
+}
+
+func (s *species) Mu(t float64) float64 {
+	return s.lj.viscosity(s.molarMass, t)
+}
+
+func (s *species) Lambda(t float64) float64 {
+	return eucken(s.Mu(t), s.Cp(t), s.r)
+}
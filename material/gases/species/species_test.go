@@ -0,0 +1,135 @@
+package species
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/stretchr/testify/assert"
+)
+
+// reference values at 298.15 K (JANAF): cp, J / (mol * K); hf, kJ / mol; s0, J / (mol * K)
+var referenceData = []struct {
+	name string
+	cp   float64
+	hf   float64
+	s0   float64
+}{
+	{NitrogenName, 29.12, 0, 191.61},
+	{OxygenName, 29.38, 0, 205.15},
+	{ArgonName, 20.79, 0, 154.85},
+	{CarbonDioxideName, 37.12, -393.52, 213.79},
+	{WaterName, 33.59, -241.83, 188.83},
+	{CarbonMonoxideName, 29.14, -110.53, 197.66},
+	{HydrogenName, 28.84, 0, 130.68},
+	{MethaneName, 35.69, -74.87, 186.25},
+	{NitricOxideName, 29.86, 91.27, 210.76},
+	{HydroxylName, 29.89, 38.99, 183.71},
+	{HeliumName, 20.79, 0, 126.15},
+	{XenonName, 20.79, 0, 169.69},
+	{EthaneName, 52.49, -83.85, 229.16},
+	{PropaneName, 73.6, -103.85, 270.3},
+	{SulfurDioxideName, 39.87, -296.84, 248.22},
+	{AtomicOxygenName, 21.91, 249.18, 161.06},
+	{AtomicHydrogenName, 20.79, 218.0, 114.72},
+}
+
+func TestDatabase_ReferenceValues(t *testing.T) {
+	assert.Equal(t, len(referenceData), len(Names()))
+
+	for _, ref := range referenceData {
+		var s, err = Get(ref.name)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		var m = s.MolarMass()
+		assert.InDelta(t, ref.cp, s.Cp(gases.TRef)*m, 0.1, ref.name)
+		assert.InDelta(t, ref.hf, s.HFormation()*m*1e-3, 0.5, ref.name)
+		assert.InDelta(t, ref.s0, s.S0Abs(gases.TRef)*m, 0.3, ref.name)
+		assert.InDelta(t, gases.UniversalGasConstant/m, s.R(), 1e-9, ref.name)
+	}
+}
+
+func TestSpecies_Consistency(t *testing.T) {
+	for _, name := range Names() {
+		var s = MustGet(name)
+		assert.InDelta(t, 0, s.H(gases.TRef), 1e-6, name)
+		assert.InDelta(t, 0, s.S0(gases.TRef), 1e-9, name)
+
+		for _, temp := range []float64{300, 700, 1500, 2500} {
+			var dt = 1e-3
+			var cpH = (s.HAbs(temp+dt) - s.HAbs(temp-dt)) / (2 * dt)
+			var cpS = (s.S0Abs(temp+dt) - s.S0Abs(temp-dt)) / (2 * dt) * temp
+			assert.InDelta(t, s.Cp(temp), cpH, 1e-4*s.Cp(temp), name)
+			assert.InDelta(t, s.Cp(temp), cpS, 1e-4*s.Cp(temp), name)
+		}
+
+		// adjacent ranges must match
+		var cpLeft, cpRight = s.Cp(999.999), s.Cp(1000.001)
+		assert.InDelta(t, cpLeft, cpRight, 1e-2*cpLeft, name)
+	}
+}
+
+func TestSpecies_Transport(t *testing.T) {
+	// reference values at 300 K
+	var n2 = MustGet(NitrogenName)
+	assert.InDelta(t, 1.79e-5, n2.Mu(300), 0.05*1.79e-5)
+	assert.InDelta(t, 0.026, n2.Lambda(300), 0.1*0.026)
+
+	var he = MustGet(HeliumName)
+	assert.InDelta(t, 2.0e-5, he.Mu(300), 0.05*2.0e-5)
+	assert.InDelta(t, 0.156, he.Lambda(300), 0.1*0.156)
+}
+
+func TestDryAir(t *testing.T) {
+	var air = DryAir()
+	assert.InDelta(t, 287.05, air.R(), 0.1)
+	assert.InDelta(t, gases.GetAir().Cp(300), air.Cp(300), 10)
+	assert.InDelta(t, 0.23135, air.OxygenMassFraction(), 1e-5)
+}
+
+func TestReadDatabase(t *testing.T) {
+	var fsys = fstest.MapFS{
+		"kr.json": &fstest.MapFile{Data: []byte(`{
+			"name": "Kr", "elements": {"Kr": 1}, "molar_mass": 83.798, "format": "nasa9",
+			"ranges": [{"t_min": 200, "t_max": 6000, "coefs": [0, 0, 2.5, 0, 0, 0, 0, -745.375, 5.49095651]}],
+			"lennard_jones": {"epsilon_k": 178.9, "sigma": 3.655}
+		}`)},
+	}
+	var db, err = ReadDatabase(fsys)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Kr"}, db.Names())
+
+	kr, err := db.Get("Kr")
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.5*gases.UniversalGasConstant/0.083798, kr.Cp(1000), 1e-9)
+
+	_, err = db.Get("Ne")
+	assert.NotNil(t, err)
+}
+
+func TestParse_Errors(t *testing.T) {
+	var _, err = Parse([]byte(`{
+		"name": "X", "molar_mass": 1, "format": "nasa7",
+		"ranges": [{"t_min": 200, "t_max": 1000, "coefs": [1, 2, 3]}],
+		"lennard_jones": {"epsilon_k": 1, "sigma": 1}
+	}`))
+	assert.NotNil(t, err)
+
+	_, err = Parse([]byte(`{
+		"name": "X", "molar_mass": 1, "format": "nasa7",
+		"ranges": [
+			{"t_min": 200, "t_max": 1000, "coefs": [1, 0, 0, 0, 0, 0, 0]},
+			{"t_min": 1500, "t_max": 3000, "coefs": [1, 0, 0, 0, 0, 0, 0]}
+		],
+		"lennard_jones": {"epsilon_k": 1, "sigma": 1}
+	}`))
+	assert.NotNil(t, err)
+
+	_, err = Parse([]byte(`{
+		"name": "X", "molar_mass": 1, "format": "shomate",
+		"ranges": [{"t_min": 200, "t_max": 1000, "coefs": [1, 0, 0, 0, 0, 0, 0]}],
+		"lennard_jones": {"epsilon_k": 1, "sigma": 1}
+	}`))
+	assert.NotNil(t, err)
+}
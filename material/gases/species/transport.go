@@ -0,0 +1,30 @@
+package species
+
+import "math"
+
+// lennardJones holds Lennard-Jones potential parameters used to
+// estimate transport properties with the Chapman-Enskog theory
+type lennardJones struct {
+	epsilonK float64 // potential well depth divided by Boltzmann constant, K
+	sigma    float64 // collision diameter, angstrom
+}
+
+// viscosity returns dynamic viscosity (Pa * s) of the gas with molar mass (kg / mol)
+func (lj lennardJones) viscosity(molarMass, t float64) float64 {
+	var mGram = molarMass * 1e3
+	return 2.6693e-6 * math.Sqrt(mGram*t) / (lj.sigma * lj.sigma * lj.collisionIntegral(t))
+}
+
+// collisionIntegral is the Neufeld approximation of the viscosity collision integral
+func (lj lennardJones) collisionIntegral(t float64) float64 {
+	var tStar = t / lj.epsilonK
+	return 1.16145*math.Pow(tStar, -0.14874) +
+		0.52487*math.Exp(-0.77320*tStar) +
+		2.16178*math.Exp(-2.43787*tStar)
+}
+
+// eucken returns thermal conductivity with the Eucken correlation
+// (exact 15 / 4 * R * mu for monatomic gases)
+func eucken(mu, cp, r float64) float64 {
+	return mu * (cp + 1.25*r)
+}
@@ -1,5 +1,7 @@
 package gases
 
+import "math"
+
 type TestGas struct {
 	CpVal     float64
 	LambdaVal float64
@@ -20,6 +22,14 @@ func (gas TestGas) Cp(t float64) float64 {
 	return gas.CpVal
 }
 
+func (gas TestGas) H(t float64) float64 {
+	return gas.CpVal * (t - TRef)
+}
+
+func (gas TestGas) S0(t float64) float64 {
+	return gas.CpVal * math.Log(t/TRef)
+}
+
 func (gas TestGas) R() float64 {
 	return gas.RVal
 }
@@ -0,0 +1,95 @@
+package water
+
+// term is a term n * x^i * y^j of IAPWS-IF97 fundamental equations
+type term struct {
+	i, j float64
+	n    float64
+}
+
+// region 1 (Table 2 of IAPWS-IF97)
+var region1Terms = []term{
+	{0, -2, 0.14632971213167}, {0, -1, -0.84548187169114}, {0, 0, -0.37563603672040e1},
+	{0, 1, 0.33855169168385e1}, {0, 2, -0.95791963387872}, {0, 3, 0.15772038513228},
+	{0, 4, -0.16616417199501e-1}, {0, 5, 0.81214629983568e-3}, {1, -9, 0.28319080123804e-3},
+	{1, -7, -0.60706301565874e-3}, {1, -1, -0.18990068218419e-1}, {1, 0, -0.32529748770505e-1},
+	{1, 1, -0.21841717175414e-1}, {1, 3, -0.52838357969930e-4}, {2, -3, -0.47184321073267e-3},
+	{2, 0, -0.30001780793026e-3}, {2, 1, 0.47661393906987e-4}, {2, 3, -0.44141845330846e-5},
+	{2, 17, -0.72694996297594e-15}, {3, -4, -0.31679644845054e-4}, {3, 0, -0.28270797985312e-5},
+	{3, 6, -0.85205128120103e-9}, {4, -5, -0.22425281908000e-5}, {4, -2, -0.65171222895601e-6},
+	{4, 10, -0.14341729937924e-12}, {5, -8, -0.40516996860117e-6}, {8, -11, -0.12734301741641e-8},
+	{8, -6, -0.17424871230634e-9}, {21, -29, -0.68762131295531e-18}, {23, -31, 0.14478307828521e-19},
+	{29, -38, 0.26335781662795e-22}, {30, -39, -0.11947622640071e-22}, {31, -40, 0.18228094581404e-23},
+	{32, -41, -0.93537087292458e-25},
+}
+
+// ideal gas part of region 2 (Table 10), i is not used
+var region2IdealTerms = []term{
+	{0, 0, -0.96927686500217e1}, {0, 1, 0.10086655968018e2}, {0, -5, -0.56087911283020e-2},
+	{0, -4, 0.71452738081455e-1}, {0, -3, -0.40710498223928}, {0, -2, 0.14240819171444e1},
+	{0, -1, -0.43839511319450e1}, {0, 2, -0.28408632460772}, {0, 3, 0.21268463753307e-1},
+}
+
+// residual part of region 2 (Table 11)
+var region2ResidualTerms = []term{
+	{1, 0, -0.17731742473213e-2}, {1, 1, -0.17834862292358e-1}, {1, 2, -0.45996013696365e-1},
+	{1, 3, -0.57581259083432e-1}, {1, 6, -0.50325278727930e-1}, {2, 1, -0.33032641670203e-4},
+	{2, 2, -0.18948987516315e-3}, {2, 4, -0.39392777243355e-2}, {2, 7, -0.43797295650573e-1},
+	{2, 36, -0.26674547914087e-4}, {3, 0, 0.20481737692309e-7}, {3, 1, 0.43870667284435e-6},
+	{3, 3, -0.32277677238570e-4}, {3, 6, -0.15033924542148e-2}, {3, 35, -0.40668253562649e-1},
+	{4, 1, -0.78847309559367e-9}, {4, 2, 0.12790717852285e-7}, {4, 3, 0.48225372718507e-6},
+	{5, 7, 0.22922076337661e-5}, {6, 3, -0.16714766451061e-10}, {6, 16, -0.21171472321355e-2},
+	{6, 35, -0.23895741934104e2}, {7, 0, -0.59059564324270e-17}, {7, 11, -0.12621808899101e-5},
+	{7, 25, -0.38946842435739e-1}, {8, 8, 0.11256211360459e-10}, {8, 36, -0.82311340897998e1},
+	{9, 13, 0.19809712802088e-7}, {10, 4, 0.10406965210174e-18}, {10, 10, -0.10234747095929e-12},
+	{10, 14, -0.10018179379511e-8}, {16, 29, -0.80882908646985e-10}, {16, 50, 0.10693031879409},
+	{18, 57, -0.33662250574171}, {20, 20, 0.89185845355421e-24}, {20, 35, 0.30629316876232e-12},
+	{20, 48, -0.42002467698208e-5}, {21, 21, -0.59056029685639e-25}, {22, 53, 0.37826947613457e-5},
+	{23, 39, -0.12768608934681e-14}, {24, 26, 0.73087610595061e-28}, {24, 40, 0.55414715350778e-16},
+	{24, 58, -0.94369707241210e-6},
+}
+
+// coefficient of ln(delta) term of region 3 (Table 30)
+const region3LogCoef = 0.10658070028513e1
+
+// polynomial terms of region 3 (Table 30)
+var region3Terms = []term{
+	{0, 0, -0.15732845290239e2}, {0, 1, 0.20944396974307e2}, {0, 2, -0.76867707878716e1},
+	{0, 7, 0.26185947787954e1}, {0, 10, -0.28080781148620e1}, {0, 12, 0.12053369696517e1},
+	{0, 23, -0.84566812812502e-2}, {1, 2, -0.12654315477714e1}, {1, 6, -0.11524407806681e1},
+	{1, 15, 0.88521043984318}, {1, 17, -0.64207765181607}, {2, 0, 0.38493460186671},
+	{2, 2, -0.85214708824206}, {2, 6, 0.48972281541877e1}, {2, 7, -0.30502617256965e1},
+	{2, 22, 0.39420536879154e-1}, {2, 26, 0.12558408424308}, {3, 0, -0.27999329698710},
+	{3, 2, 0.13899799569460e1}, {3, 4, -0.20189915023570e1}, {3, 16, -0.82147637173963e-2},
+	{3, 26, -0.47596035734923}, {4, 0, 0.43984074473500e-1}, {4, 2, -0.44476435428739},
+	{4, 4, 0.90572070719733}, {4, 26, 0.70522450087967}, {5, 1, 0.10770512626332},
+	{5, 3, -0.32913623258954}, {5, 26, -0.50871062041158}, {6, 0, -0.22175400873096e-1},
+	{6, 2, 0.94260751665092e-1}, {6, 26, 0.16436278447961}, {7, 2, -0.13503372241348e-1},
+	{8, 26, -0.14834345352472e-1}, {9, 2, 0.57922953628084e-3}, {9, 26, 0.32308904703711e-2},
+	{10, 0, 0.80964802996215e-4}, {10, 1, -0.16557679795037e-3}, {11, 26, -0.44923899061815e-4},
+}
+
+// ideal gas part of region 5 (Table 37), i is not used
+var region5IdealTerms = []term{
+	{0, 0, -0.13179983674201e2}, {0, 1, 0.68540841634434e1}, {0, -3, -0.24805148933466e-1},
+	{0, -2, 0.36901534980333}, {0, -1, -0.31161318213925e1}, {0, 2, -0.32961626538917},
+}
+
+// residual part of region 5 (Table 38)
+var region5ResidualTerms = []term{
+	{1, 1, 0.15736404855259e-2}, {1, 2, 0.90153761673944e-3}, {1, 3, -0.50270077677648e-2},
+	{2, 3, 0.22440037409485e-5}, {2, 9, -0.41163275453471e-5}, {3, 7, 0.37919454822955e-7},
+}
+
+// boundary between regions 2 and 3 (B23 equation)
+var b23Coefs = [5]float64{
+	0.34805185628969e3, -0.11671859879975e1, 0.10192970039326e-2,
+	0.57254459862746e3, 0.13918839778870e2,
+}
+
+// saturation line (region 4)
+var saturationCoefs = [10]float64{
+	0.11670521452767e4, -0.72421316703206e6, -0.17073846940092e2,
+	0.12020824702470e5, -0.32325550322333e7, 0.14915108613530e2,
+	-0.48232657361591e4, 0.40511340542057e6, -0.23855557567849,
+	0.65017534844798e3,
+}
@@ -0,0 +1,136 @@
+package water
+
+import (
+	"math"
+)
+
+const (
+	region1PRef = 16.53e6
+	region1TRef = 1386.
+	region2PRef = 1e6
+	region2TRef = 540.
+	region5PRef = 1e6
+	region5TRef = 1000.
+)
+
+// derivatives holds dimensionless free energy f(x, y) and its derivatives
+type derivatives struct {
+	f, fx, fy, fxx, fyy, fxy float64
+}
+
+func (d derivatives) add(other derivatives) derivatives {
+	return derivatives{
+		f: d.f + other.f, fx: d.fx + other.fx, fy: d.fy + other.fy,
+		fxx: d.fxx + other.fxx, fyy: d.fyy + other.fyy, fxy: d.fxy + other.fxy,
+	}
+}
+
+// polynomial returns sum of terms n * x^i * y^j and its derivatives
+func polynomial(terms []term, x, y float64) derivatives {
+	var result derivatives
+	for _, t := range terms {
+		var xi, yj = math.Pow(x, t.i), math.Pow(y, t.j)
+		result.f += t.n * xi * yj
+		if t.i != 0 {
+			var xi1 = math.Pow(x, t.i-1)
+			result.fx += t.n * t.i * xi1 * yj
+			if t.i != 1 {
+				result.fxx += t.n * t.i * (t.i - 1) * math.Pow(x, t.i-2) * yj
+			}
+			if t.j != 0 {
+				result.fxy += t.n * t.i * t.j * xi1 * math.Pow(y, t.j-1)
+			}
+		}
+		if t.j != 0 {
+			result.fy += t.n * t.j * xi * math.Pow(y, t.j-1)
+			if t.j != 1 {
+				result.fyy += t.n * t.j * (t.j - 1) * xi * math.Pow(y, t.j-2)
+			}
+		}
+	}
+	return result
+}
+
+// gibbsState returns state described by dimensionless Gibbs free energy g(pi, tau)
+func gibbsState(g derivatives, p, t, pi, tau float64, region int) State {
+	return State{
+		P:      p,
+		T:      t,
+		V:      R * t * pi * g.fx / p,
+		H:      R * t * tau * g.fy,
+		S:      R * (tau*g.fy - g.f),
+		Cp:     -R * tau * tau * g.fyy,
+		Region: region,
+	}
+}
+
+func region1(p, t float64) State {
+	var pi, tau = p / region1PRef, region1TRef / t
+	var g = polynomial(region1Terms, 7.1-pi, tau-1.222)
+	// derivatives over 7.1 - pi are converted to derivatives over pi
+	g.fx, g.fxy = -g.fx, -g.fxy
+
+	var state = gibbsState(g, p, t, pi, tau, Region1)
+	state.X = 0
+	return state
+}
+
+func region2(p, t float64) State {
+	var pi, tau = p / region2PRef, region2TRef / t
+	var ideal = polynomial(region2IdealTerms, 1, tau)
+	ideal.f += math.Log(pi)
+	ideal.fx = 1 / pi
+	ideal.fxx = -1 / (pi * pi)
+
+	var state = gibbsState(ideal.add(polynomial(region2ResidualTerms, pi, tau-0.5)), p, t, pi, tau, Region2)
+	state.X = 1
+	return state
+}
+
+func region5(p, t float64) State {
+	var pi, tau = p / region5PRef, region5TRef / t
+	var ideal = polynomial(region5IdealTerms, 1, tau)
+	ideal.f += math.Log(pi)
+	ideal.fx = 1 / pi
+	ideal.fxx = -1 / (pi * pi)
+
+	var state = gibbsState(ideal.add(polynomial(region5ResidualTerms, pi, tau)), p, t, pi, tau, Region5)
+	state.X = 1
+	return state
+}
+
+// region3 returns state for density rho and temperature t.
+// Quality is set by caller
+func region3(rho, t float64) State {
+	var delta, tau = rho / RhoCrit, TCrit / t
+	var phi = polynomial(region3Terms, delta, tau)
+	phi.f += region3LogCoef * math.Log(delta)
+	phi.fx += region3LogCoef / delta
+	phi.fxx -= region3LogCoef / (delta * delta)
+
+	var dPhiD = delta * phi.fx
+	var cv = -tau * tau * phi.fyy
+	var numerator = dPhiD - delta*tau*phi.fxy
+
+	return State{
+		P:      rho * R * t * dPhiD,
+		T:      t,
+		V:      1 / rho,
+		H:      R * t * (tau*phi.fy + dPhiD),
+		S:      R * (tau*phi.fy - phi.f),
+		Cp:     R * (cv + numerator*numerator/(2*dPhiD+delta*delta*phi.fxx)),
+		Region: Region3,
+	}
+}
+
+// b23Pressure returns pressure on the boundary between regions 2 and 3
+func b23Pressure(t float64) float64 {
+	var n = b23Coefs
+	return (n[0] + n[1]*t + n[2]*t*t) * 1e6
+}
+
+// b23Temperature returns temperature on the boundary between regions 2 and 3
+func b23Temperature(p float64) float64 {
+	var n = b23Coefs
+	return n[3] + math.Sqrt((p*1e-6-n[4])/n[2])
+}
@@ -0,0 +1,70 @@
+package water
+
+import (
+	"fmt"
+	"math"
+)
+
+// SaturationPressure returns pressure of saturated water at temperature t
+func SaturationPressure(t float64) (float64, error) {
+	if t < TMin || t > TCrit {
+		return 0, fmt.Errorf("temperature %g K is out of range of the saturation line", t)
+	}
+	var n = saturationCoefs
+	var theta = t + n[8]/(t-n[9])
+	var a = theta*theta + n[0]*theta + n[1]
+	var b = n[2]*theta*theta + n[3]*theta + n[4]
+	var c = n[5]*theta*theta + n[6]*theta + n[7]
+	var x = 2 * c / (-b + math.Sqrt(b*b-4*a*c))
+	return x * x * x * x * 1e6, nil
+}
+
+// SaturationTemperature returns temperature of saturated water at pressure p
+func SaturationTemperature(p float64) (float64, error) {
+	if p < pSatMin || p > PCrit {
+		return 0, fmt.Errorf("pressure %g Pa is out of range of the saturation line", p)
+	}
+	var n = saturationCoefs
+	var beta = math.Pow(p*1e-6, 0.25)
+	var e = beta*beta + n[2]*beta + n[5]
+	var f = n[0]*beta*beta + n[3]*beta + n[6]
+	var g = n[1]*beta*beta + n[4]*beta + n[7]
+	var d = 2 * g / (-f - math.Sqrt(f*f-4*e*g))
+	return (n[9] + d - math.Sqrt((n[9]+d)*(n[9]+d)-4*(n[8]+n[9]*d))) / 2, nil
+}
+
+// SaturatedLiquid returns state of saturated liquid at pressure p
+func SaturatedLiquid(p float64) (State, error) {
+	var liquid, _, err = saturatedStates(p)
+	return liquid, err
+}
+
+// SaturatedVapour returns state of saturated vapour at pressure p
+func SaturatedVapour(p float64) (State, error) {
+	var _, vapour, err = saturatedStates(p)
+	return vapour, err
+}
+
+// pSatMin is saturation pressure at TMin
+var pSatMin, _ = SaturationPressure(TMin)
+
+func saturatedStates(p float64) (State, State, error) {
+	var t, err = SaturationTemperature(p)
+	if err != nil {
+		return State{}, State{}, err
+	}
+	if t <= t13 {
+		var liquid, vapour = region1(p, t), region2(p, t)
+		return liquid, vapour, nil
+	}
+
+	liquid, err := region3PT(p, t, false)
+	if err != nil {
+		return State{}, State{}, err
+	}
+	vapour, err := region3PT(p, t, true)
+	if err != nil {
+		return State{}, State{}, err
+	}
+	return liquid, vapour, nil
+}
@@ -0,0 +1,256 @@
+// Package water implements IAPWS-IF97 formulation of water and steam properties.
+// All values are in SI units: Pa, K, J / kg, J / (kg * K), m^3 / kg
+package water
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Sovianum/turbocycle/core/math/solvers/root"
+)
+
+const (
+	// R is specific gas constant of water, J / (kg * K)
+	R = 461.526
+
+	TCrit   = 647.096
+	PCrit   = 22.064e6
+	RhoCrit = 322.
+
+	TTriple = 273.16
+	PTriple = 611.657
+
+	TMin = 273.15
+	// TMax is upper temperature limit of regions 1 - 3
+	TMax = 1073.15
+	// THighMax is upper temperature limit of region 5
+	THighMax = 2273.15
+	PMax     = 100e6
+	// PHighMax is upper pressure limit of region 5
+	PHighMax = 50e6
+)
+
+const (
+	Region1 = 1 // compressed liquid
+	Region2 = 2 // superheated vapour
+	Region3 = 3 // near-critical region
+	Region4 = 4 // two-phase region
+	Region5 = 5 // high-temperature vapour
+)
+
+const (
+	// temperature of the boundary between regions 1, 3 and regions 2, 3
+	t13 = 623.15
+	// upper temperature of the boundary between regions 2 and 3
+	t23Max = 863.15
+
+	inversionPrecision = 1e-12
+	inversionIterLimit = 200
+	// density step of search of region 3 roots, kg / m^3
+	densityScanStep = 1.
+	// upper density of search of region 3 roots, kg / m^3
+	densityScanMax = 800.
+)
+
+// State describes thermodynamic state of water or steam
+type State struct {
+	P float64
+	T float64
+	// V is specific volume
+	V float64
+	H float64
+	S float64
+	// Cp is infinite in the two-phase region
+	Cp float64
+	// X is vapour mass fraction. It is 0 for liquid and 1 for vapour and supercritical fluid
+	X      float64
+	Region int
+}
+
+func (s State) Density() float64 {
+	return 1 / s.V
+}
+
+func (s State) String() string {
+	return fmt.Sprintf(
+		"region %d: p = %g Pa, T = %g K, v = %g m^3/kg, h = %g J/kg, s = %g J/(kg K), x = %g",
+		s.Region, s.P, s.T, s.V, s.H, s.S, s.X,
+	)
+}
+
+// PT returns single-phase state at pressure p and temperature t.
+// On the saturation line state of liquid is returned
+func PT(p, t float64) (State, error) {
+	var vapour = t >= TCrit
+	if !vapour {
+		var pSat, err = SaturationPressure(t)
+		if err != nil {
+			return State{}, err
+		}
+		vapour = p < pSat
+	}
+	return stateTP(p, t, vapour)
+}
+
+// PH returns state at pressure p and specific enthalpy h
+func PH(p, h float64) (State, error) {
+	return inverse(p, h, func(s State) float64 { return s.H })
+}
+
+// PS returns state at pressure p and specific entropy s
+func PS(p, s float64) (State, error) {
+	return inverse(p, s, func(s State) float64 { return s.S })
+}
+
+// inverse returns state at pressure p where property prop has value
+func inverse(p, value float64, prop func(s State) float64) (State, error) {
+	if err := checkPressure(p); err != nil {
+		return State{}, err
+	}
+	var tMax = TMax
+	if p <= PHighMax {
+		tMax = THighMax
+	}
+	if p >= PCrit {
+		return solveT(p, value, prop, TMin, tMax, func(t float64) (State, error) {
+			return PT(p, t)
+		})
+	}
+
+	var liquid, vapour, err = saturatedStates(p)
+	if err != nil {
+		return State{}, err
+	}
+	var valueLiquid, valueVapour = prop(liquid), prop(vapour)
+	switch {
+	case value < valueLiquid:
+		return solveT(p, value, prop, TMin, liquid.T, func(t float64) (State, error) {
+			return stateTP(p, t, false)
+		})
+	case value > valueVapour:
+		return solveT(p, value, prop, vapour.T, tMax, func(t float64) (State, error) {
+			return stateTP(p, t, true)
+		})
+	default:
+		return mix(liquid, vapour, (value-valueLiquid)/(valueVapour-valueLiquid)), nil
+	}
+}
+
+// solveT finds temperature in [tMin, tMax] at which state given by stateFunc has value of property prop
+func solveT(
+	p, value float64, prop func(s State) float64, tMin, tMax float64, stateFunc func(t float64) (State, error),
+) (State, error) {
+	var residual = func(t float64) (float64, error) {
+		var state, err = stateFunc(t)
+		if err != nil {
+			return 0, err
+		}
+		return prop(state) - value, nil
+	}
+
+	var fMin, errMin = residual(tMin)
+	if errMin != nil {
+		return State{}, errMin
+	}
+	var fMax, errMax = residual(tMax)
+	if errMax != nil {
+		return State{}, errMax
+	}
+	if fMin*fMax > 0 {
+		return State{}, fmt.Errorf("state with p = %g Pa is out of range of IAPWS-IF97", p)
+	}
+
+	var report, err = root.NewBrentSolver(inversionPrecision, inversionIterLimit).Solve(residual, tMin, tMax)
+	if err != nil {
+		return State{}, err
+	}
+	return stateFunc(report.X)
+}
+
+// stateTP returns state of the given phase at pressure p and temperature t.
+// Phase matters only below the critical temperature
+func stateTP(p, t float64, vapour bool) (State, error) {
+	if err := checkPressure(p); err != nil {
+		return State{}, err
+	}
+	switch {
+	case t < TMin:
+		return State{}, fmt.Errorf("temperature %g K is below range of IAPWS-IF97", t)
+	case t <= t13:
+		if vapour {
+			return region2(p, t), nil
+		}
+		return region1(p, t), nil
+	case t <= t23Max && p > b23Pressure(t):
+		return region3PT(p, t, vapour)
+	case t <= TMax:
+		return region2(p, t), nil
+	case t <= THighMax && p <= PHighMax:
+		return region5(p, t), nil
+	default:
+		return State{}, fmt.Errorf("state with p = %g Pa, T = %g K is out of range of IAPWS-IF97", p, t)
+	}
+}
+
+// region3PT returns state of region 3 at pressure p and temperature t.
+// The equation of region 3 is explicit in density and may have several roots below
+// the critical temperature: the largest root is taken for liquid and the least one for vapour
+func region3PT(p, t float64, vapour bool) (State, error) {
+	var residual = func(rho float64) (float64, error) {
+		return region3(rho, t).P - p, nil
+	}
+
+	var rhoMin, rhoMax float64
+	if vapour && t < TCrit {
+		// compressibility factor of the vapour is less than unity
+		rhoMin = p / (R * t)
+		for rhoMax = rhoMin + densityScanStep; region3(rhoMax, t).P < p; rhoMax += densityScanStep {
+			if rhoMax > densityScanMax {
+				return State{}, fmt.Errorf("failed to find vapour density at p = %g Pa, T = %g K", p, t)
+			}
+		}
+		rhoMin = rhoMax - densityScanStep
+	} else {
+		for rhoMin = densityScanMax; region3(rhoMin, t).P > p; rhoMin -= densityScanStep {
+			if rhoMin <= densityScanStep {
+				return State{}, fmt.Errorf("failed to find liquid density at p = %g Pa, T = %g K", p, t)
+			}
+		}
+		rhoMax = rhoMin + densityScanStep
+	}
+
+	var report, err = root.NewBrentSolver(inversionPrecision, inversionIterLimit).Solve(residual, rhoMin, rhoMax)
+	if err != nil {
+		return State{}, err
+	}
+	var state = region3(report.X, t)
+	state.P = p
+	if vapour || t >= TCrit {
+		state.X = 1
+	}
+	return state, nil
+}
+
+// mix returns state of two-phase mixture with vapour mass fraction x
+func mix(liquid, vapour State, x float64) State {
+	var interpolate = func(l, v float64) float64 {
+		return l + x*(v-l)
+	}
+	return State{
+		P:      liquid.P,
+		T:      liquid.T,
+		V:      interpolate(liquid.V, vapour.V),
+		H:      interpolate(liquid.H, vapour.H),
+		S:      interpolate(liquid.S, vapour.S),
+		Cp:     math.Inf(1),
+		X:      x,
+		Region: Region4,
+	}
+}
+
+func checkPressure(p float64) error {
+	if p <= 0 || p > PMax {
+		return fmt.Errorf("pressure %g Pa is out of range of IAPWS-IF97", p)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package water
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// verification values of IAPWS-IF97 (Tables 5, 15, 33, 42)
+func TestPT_Verification(t *testing.T) {
+	var testCases = []struct {
+		p, t   float64
+		region int
+		v      float64
+		h      float64
+		s      float64
+		cp     float64
+	}{
+		{3e6, 300, Region1, 0.100215168e-2, 0.115331273e3, 0.392294792, 0.417301218e1},
+		{80e6, 300, Region1, 0.971180894e-3, 0.184142828e3, 0.368563852, 0.401008987e1},
+		{3e6, 500, Region1, 0.120241800e-2, 0.975542239e3, 0.258041912e1, 0.465580682e1},
+		{3.5e3, 300, Region2, 0.394913866e2, 0.254991145e4, 0.852238967e1, 0.191300162e1},
+		{3.5e3, 700, Region2, 0.923015898e2, 0.333568375e4, 0.101749996e2, 0.208141274e1},
+		{30e6, 700, Region2, 0.542946619e-2, 0.263149474e4, 0.517540298e1, 0.103505092e2},
+		{0.5e6, 1500, Region5, 0.138455090e1, 0.521976855e4, 0.965408875e1, 0.261609445e1},
+		{30e6, 1500, Region5, 0.230761299e-1, 0.516723514e4, 0.772970133e1, 0.272724317e1},
+		{30e6, 2000, Region5, 0.311385219e-1, 0.657122604e4, 0.853640523e1, 0.288569882e1},
+	}
+
+	for _, tc := range testCases {
+		var state, err = PT(tc.p, tc.t)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.region, state.Region)
+		assert.InDelta(t, 1, state.V/tc.v, 1e-8, state.String())
+		assert.InDelta(t, 1, state.H/(tc.h*1e3), 1e-8, state.String())
+		assert.InDelta(t, 1, state.S/(tc.s*1e3), 1e-8, state.String())
+		assert.InDelta(t, 1, state.Cp/(tc.cp*1e3), 1e-8, state.String())
+	}
+}
+
+func TestRegion3_Verification(t *testing.T) {
+	var testCases = []struct {
+		rho, t float64
+		p      float64
+		h      float64
+		s      float64
+		cp     float64
+	}{
+		{500, 650, 0.255837018e2, 0.186343019e4, 0.405427273e1, 0.138935717e2},
+		{200, 650, 0.222930643e2, 0.237512401e4, 0.485438792e1, 0.446579342e2},
+		{500, 750, 0.783095639e2, 0.225868845e4, 0.446971906e1, 0.634165359e1},
+	}
+
+	for _, tc := range testCases {
+		var state = region3(tc.rho, tc.t)
+		assert.InDelta(t, 1, state.P/(tc.p*1e6), 1e-8)
+		assert.InDelta(t, 1, state.H/(tc.h*1e3), 1e-8)
+		assert.InDelta(t, 1, state.S/(tc.s*1e3), 1e-8)
+		assert.InDelta(t, 1, state.Cp/(tc.cp*1e3), 1e-8)
+
+		var inverse, err = PT(state.P, tc.t)
+		assert.Nil(t, err)
+		assert.Equal(t, Region3, inverse.Region)
+		assert.InDelta(t, tc.rho, inverse.Density(), 1e-6)
+	}
+}
+
+func TestSaturation(t *testing.T) {
+	var pSat, err = SaturationPressure(300)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.353658941e-2, pSat*1e-6, 1e-11)
+
+	for _, tc := range []struct{ p, t float64 }{
+		{0.1e6, 0.372755919e3}, {1e6, 0.453035632e3}, {10e6, 0.584149488e3},
+	} {
+		var tSat, err = SaturationTemperature(tc.p)
+		assert.Nil(t, err)
+		assert.InDelta(t, tc.t, tSat, 1e-6)
+	}
+
+	// latent heat of vaporization at atmospheric pressure
+	liquid, err := SaturatedLiquid(101325)
+	assert.Nil(t, err)
+	vapour, err := SaturatedVapour(101325)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2256.4e3, vapour.H-liquid.H, 1e3)
+	assert.InDelta(t, 373.12, liquid.T, 0.01)
+
+	// phases converge towards the critical point
+	liquid, err = SaturatedLiquid(21e6)
+	assert.Nil(t, err)
+	vapour, err = SaturatedVapour(21e6)
+	assert.Nil(t, err)
+	assert.Equal(t, Region3, liquid.Region)
+	assert.True(t, liquid.Density() > vapour.Density()+100, "%f %f", liquid.Density(), vapour.Density())
+	// Clausius-Clapeyron equation
+	pSatPlus, _ := SaturationPressure(liquid.T + 0.01)
+	pSatMinus, _ := SaturationPressure(liquid.T - 0.01)
+	var dpdt = (pSatPlus - pSatMinus) / 0.02
+	assert.InDelta(t, 1, (vapour.H-liquid.H)/(liquid.T*(vapour.V-liquid.V)*dpdt), 2e-2)
+
+	_, err = SaturationTemperature(PCrit * 1.01)
+	assert.NotNil(t, err)
+}
+
+func TestBackward(t *testing.T) {
+	var points = []struct{ p, t float64 }{
+		{3e6, 300}, {3e6, 500}, {3.5e3, 700}, {30e6, 700}, {20e6, 640}, {20e6, 700},
+		{50e6, 650}, {0.5e6, 1500}, {25e6, 600}, {25e6, 660},
+	}
+	for _, point := range points {
+		var state, err = PT(point.p, point.t)
+		assert.Nil(t, err)
+
+		fromH, err := PH(point.p, state.H)
+		assert.Nil(t, err)
+		assert.InDelta(t, point.t, fromH.T, 1e-6, state.String())
+		assert.Equal(t, state.Region, fromH.Region)
+
+		fromS, err := PS(point.p, state.S)
+		assert.Nil(t, err)
+		assert.InDelta(t, point.t, fromS.T, 1e-6, state.String())
+	}
+}
+
+func TestBackward_TwoPhase(t *testing.T) {
+	for _, p := range []float64{5e3, 1e6, 18e6} {
+		var liquid, err = SaturatedLiquid(p)
+		assert.Nil(t, err)
+		vapour, err := SaturatedVapour(p)
+		assert.Nil(t, err)
+
+		var h = 0.3*liquid.H + 0.7*vapour.H
+		state, err := PH(p, h)
+		assert.Nil(t, err)
+		assert.Equal(t, Region4, state.Region)
+		assert.InDelta(t, 0.7, state.X, 1e-12)
+		assert.InDelta(t, liquid.T, state.T, 1e-12)
+		assert.True(t, math.IsInf(state.Cp, 1))
+
+		fromS, err := PS(p, state.S)
+		assert.Nil(t, err)
+		assert.InDelta(t, 0.7, fromS.X, 1e-9)
+	}
+
+	// wet steam after isentropic expansion in a condensing turbine
+	var inlet, err = PT(10e6, 823.15)
+	assert.Nil(t, err)
+	outlet, err := PS(5e3, inlet.S)
+	assert.Nil(t, err)
+	assert.Equal(t, Region4, outlet.Region)
+	assert.InDelta(t, 0.78, outlet.X, 0.02)
+}
+
+func TestRange(t *testing.T) {
+	var _, err = PT(101e6, 500)
+	assert.NotNil(t, err)
+	_, err = PT(1e6, 270)
+	assert.NotNil(t, err)
+	_, err = PT(60e6, 1500)
+	assert.NotNil(t, err)
+	_, err = PH(1e6, 1e8)
+	assert.NotNil(t, err)
+}